@@ -0,0 +1,227 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kelly-lin/12d-lang-server/format"
+	parser "github.com/kelly-lin/12d-lang-server/parser/12dpl"
+	"github.com/pmezard/go-difflib/difflib"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Exit codes of the fmt command.
+const (
+	fmtExitOK = iota
+	// Files are not formatted when listing or diffing without writing.
+	fmtExitUnformatted
+	// Bad usage or a file could not be read, parsed or written.
+	fmtExitError
+)
+
+// Options of the fmt command.
+type fmtOptions struct {
+	write  bool
+	list   bool
+	diff   bool
+	format format.Options
+}
+
+// Runs the fmt command with the arguments after "fmt" and returns the exit
+// code. Files are read from stdin when there are no path arguments.
+func runFmt(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("fmt", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	writeFlag := flags.Bool("w", false, "write the formatted source to the file instead of stdout")
+	listFlag := flags.Bool("l", false, "list files which are not formatted")
+	diffFlag := flags.Bool("d", false, "print unified diffs of files which are not formatted")
+	styleFlag := flags.String("style", "", fmt.Sprintf("style profile, one of %s", strings.Join(format.GetStyleNames(), ", ")))
+	maxLineWidthFlag := flags.Int("max-line-width", 0, "maximum line width, 0 to disable wrapping")
+	alignFlag := flags.Bool("align", false, "align consecutive declarations and assignments")
+	tabsFlag := flags.Bool("tabs", false, "indent with tabs instead of spaces")
+	organizeFlag := flags.Bool("organize", false, "sort consecutive includes and remove duplicate includes")
+	lineEndingFlag := flags.String("line-ending", "", "convert line endings to lf or crlf, kept as they were written when empty")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, `Format 12d programming language files
+
+Usage: 12dls fmt [-w][-l][-d][flags] [path ...]
+
+Formats stdin to stdout when no paths are given. Directories are formatted
+recursively, only .4dm and .h files are formatted. Exits with code %d when
+listing or diffing and a file is not formatted.
+
+Flags:
+`, fmtExitUnformatted)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return fmtExitOK
+		}
+		return fmtExitError
+	}
+
+	opts := fmtOptions{
+		write:  *writeFlag,
+		list:   *listFlag,
+		diff:   *diffFlag,
+		format: format.NewDefaultOptions(),
+	}
+	opts.format.InsertSpaces = !*tabsFlag
+	opts.format.InsertFinalNewline = true
+	opts.format.TrimFinalNewlines = true
+	opts.format.MaxLineWidth = *maxLineWidthFlag
+	opts.format.AlignConsecutive = *alignFlag
+	opts.format.OrganizeIncludes = *organizeFlag
+	if *styleFlag != "" {
+		style, ok := format.GetStyle(*styleFlag)
+		if !ok {
+			fmt.Fprintf(stderr, "unknown style %q\n", *styleFlag)
+			return fmtExitError
+		}
+		opts.format.Style = style
+	}
+	switch *lineEndingFlag {
+	case "":
+	case "lf":
+		opts.format.LineEnding = format.LineEndingLF
+	case "crlf":
+		opts.format.LineEnding = format.LineEndingCRLF
+	default:
+		fmt.Fprintf(stderr, "unknown line ending %q\n", *lineEndingFlag)
+		return fmtExitError
+	}
+
+	if flags.NArg() == 0 {
+		if opts.write {
+			fmt.Fprintln(stderr, "cannot use -w with stdin")
+			return fmtExitError
+		}
+		isFormatted, err := formatFile("<standard input>", stdin, stdout, opts)
+		return getFmtExitCode(isFormatted, err, opts, stderr)
+	}
+
+	exitCode := fmtExitOK
+	for _, path := range flags.Args() {
+		err := filepath.WalkDir(path, func(filePath string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			// Paths given explicitly are always formatted, files found by
+			// walking a directory only when they are 12d source files.
+			if entry.IsDir() || filePath != path && !isSourceFile(filePath) {
+				return nil
+			}
+			isFormatted, err := formatPath(filePath, stdout, opts)
+			if code := getFmtExitCode(isFormatted, err, opts, stderr); code > exitCode {
+				exitCode = code
+			}
+			return nil
+		})
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			exitCode = fmtExitError
+		}
+	}
+	return exitCode
+}
+
+// Returns true if the file is a 12d macro or header file.
+func isSourceFile(filePath string) bool {
+	switch filepath.Ext(filePath) {
+	case ".4dm", ".h":
+		return true
+	}
+	return false
+}
+
+// Formats the file at the path.
+func formatPath(filePath string, out io.Writer, opts fmtOptions) (bool, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+	return formatFile(filePath, file, out, opts)
+}
+
+// Formats the source code read from the reader. The formatted source code is
+// written to the file, printed or compared depending on the options. Returns
+// true if the source code was already formatted.
+func formatFile(filePath string, in io.Reader, out io.Writer, opts fmtOptions) (bool, error) {
+	sourceCode, err := io.ReadAll(in)
+	if err != nil {
+		return false, err
+	}
+	rootNode, err := sitter.ParseCtx(context.Background(), sourceCode, parser.GetLanguage())
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", filePath, err)
+	}
+	formatted := []byte(format.Print(rootNode, sourceCode, opts.format))
+	isFormatted := bytes.Equal(sourceCode, formatted)
+
+	if opts.list && !isFormatted {
+		fmt.Fprintln(out, filePath)
+	}
+	if opts.diff && !isFormatted {
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        splitLines(string(sourceCode)),
+			B:        splitLines(string(formatted)),
+			FromFile: filePath + ".orig",
+			ToFile:   filePath,
+			Context:  3,
+		})
+		if err != nil {
+			return false, fmt.Errorf("%s: %w", filePath, err)
+		}
+		fmt.Fprint(out, diff)
+	}
+	if opts.write && !isFormatted {
+		info, err := os.Stat(filePath)
+		if err != nil {
+			return false, err
+		}
+		if err := os.WriteFile(filePath, formatted, info.Mode().Perm()); err != nil {
+			return false, err
+		}
+	}
+	if !opts.write && !opts.list && !opts.diff {
+		_, err := out.Write(formatted)
+		return isFormatted, err
+	}
+	return isFormatted, nil
+}
+
+// Split the text into lines keeping the line endings. Unlike
+// difflib.SplitLines, a final newline does not start an empty line and a
+// missing final newline is marked the way diff does.
+func splitLines(text string) []string {
+	result := strings.SplitAfter(text, "\n")
+	lastIdx := len(result) - 1
+	if result[lastIdx] == "" {
+		return result[:lastIdx]
+	}
+	result[lastIdx] += "\n\\ No newline at end of file\n"
+	return result
+}
+
+// Get the exit code of formatting a file. Unformatted files are only a
+// failure when checking, listing or diffing without writing.
+func getFmtExitCode(isFormatted bool, err error, opts fmtOptions, stderr io.Writer) int {
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return fmtExitError
+	}
+	if !isFormatted && !opts.write && (opts.list || opts.diff) {
+		return fmtExitUnformatted
+	}
+	return fmtExitOK
+}
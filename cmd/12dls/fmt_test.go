@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const unformattedSourceCode = "void main() {\nInteger a=1;\n}\n"
+const formattedSourceCode = "void main() {\n    Integer a = 1;\n}\n"
+
+func TestFmt(t *testing.T) {
+	// Creates a directory with a formatted and an unformatted macro and a
+	// file which is not 12d source code.
+	setUpDir := func(t *testing.T) string {
+		dir := t.TempDir()
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, "lib"), 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "main.4dm"), []byte(unformattedSourceCode), 0644))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "lib", "helpers.h"), []byte(formattedSourceCode), 0644))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte(unformattedSourceCode), 0644))
+		return dir
+	}
+
+	t.Run("stdin is formatted to stdout", func(t *testing.T) {
+		assert := assert.New(t)
+		var stdout, stderr bytes.Buffer
+		code := runFmt([]string{}, strings.NewReader(unformattedSourceCode), &stdout, &stderr)
+		assert.Equal(fmtExitOK, code)
+		assert.Equal(formattedSourceCode, stdout.String())
+		assert.Empty(stderr.String())
+	})
+
+	t.Run("list unformatted files exits non-zero", func(t *testing.T) {
+		assert := assert.New(t)
+		dir := setUpDir(t)
+		var stdout, stderr bytes.Buffer
+		code := runFmt([]string{"-l", dir}, nil, &stdout, &stderr)
+		assert.Equal(fmtExitUnformatted, code)
+		assert.Equal(filepath.Join(dir, "main.4dm")+"\n", stdout.String())
+	})
+
+	t.Run("list formatted files exits zero", func(t *testing.T) {
+		assert := assert.New(t)
+		dir := setUpDir(t)
+		var stdout, stderr bytes.Buffer
+		code := runFmt([]string{"-l", filepath.Join(dir, "lib")}, nil, &stdout, &stderr)
+		assert.Equal(fmtExitOK, code)
+		assert.Empty(stdout.String())
+	})
+
+	t.Run("diff unformatted files", func(t *testing.T) {
+		assert := assert.New(t)
+		dir := setUpDir(t)
+		filePath := filepath.Join(dir, "main.4dm")
+		var stdout, stderr bytes.Buffer
+		code := runFmt([]string{"-d", filePath}, nil, &stdout, &stderr)
+		assert.Equal(fmtExitUnformatted, code)
+		want := "--- " + filePath + ".orig\n" +
+			"+++ " + filePath + "\n" +
+			"@@ -1,3 +1,3 @@\n" +
+			" void main() {\n" +
+			"-Integer a=1;\n" +
+			"+    Integer a = 1;\n" +
+			" }\n"
+		assert.Equal(want, stdout.String())
+	})
+
+	t.Run("write formats files in place", func(t *testing.T) {
+		assert := assert.New(t)
+		dir := setUpDir(t)
+		var stdout, stderr bytes.Buffer
+		code := runFmt([]string{"-w", dir}, nil, &stdout, &stderr)
+		assert.Equal(fmtExitOK, code)
+		assert.Empty(stdout.String())
+		got, err := os.ReadFile(filepath.Join(dir, "main.4dm"))
+		assert.NoError(err)
+		assert.Equal(formattedSourceCode, string(got))
+		got, err = os.ReadFile(filepath.Join(dir, "notes.txt"))
+		assert.NoError(err)
+		assert.Equal(unformattedSourceCode, string(got))
+	})
+
+	t.Run("convert line endings", func(t *testing.T) {
+		assert := assert.New(t)
+		var stdout, stderr bytes.Buffer
+		code := runFmt([]string{"-line-ending", "crlf"}, strings.NewReader(formattedSourceCode), &stdout, &stderr)
+		assert.Equal(fmtExitOK, code)
+		assert.Equal(strings.ReplaceAll(formattedSourceCode, "\n", "\r\n"), stdout.String())
+	})
+
+	t.Run("missing file is an error", func(t *testing.T) {
+		assert := assert.New(t)
+		var stdout, stderr bytes.Buffer
+		code := runFmt([]string{"-l", filepath.Join(t.TempDir(), "missing.4dm")}, nil, &stdout, &stderr)
+		assert.Equal(fmtExitError, code)
+		assert.NotEmpty(stderr.String())
+	})
+
+	t.Run("unknown style is an error", func(t *testing.T) {
+		assert := assert.New(t)
+		var stdout, stderr bytes.Buffer
+		code := runFmt([]string{"-style", "gnu"}, strings.NewReader(""), &stdout, &stderr)
+		assert.Equal(fmtExitError, code)
+		assert.Equal("unknown style \"gnu\"\n", stderr.String())
+	})
+}
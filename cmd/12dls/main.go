@@ -19,6 +19,9 @@ var versionFlag = flag.Bool("v", false, "show version")
 var experimentalFlag = flag.Bool("x", false, "enable experimental features")
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "fmt" {
+		os.Exit(runFmt(os.Args[2:], os.Stdin, os.Stdout, os.Stderr))
+	}
 	flag.Parse()
 	flag.CommandLine.SetOutput(os.Stdout)
 	flag.Usage = printUsage
@@ -54,6 +57,7 @@ func printUsage() {
 	fmt.Printf(`Language server for the 12d programming language
 
 Usage: 12dls [-i includes_dir][-l log_filepath][-hv]
+       12dls fmt [-w][-l][-d][flags] [path ...]
 
 Flags:
 `)
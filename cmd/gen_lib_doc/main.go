@@ -9,7 +9,9 @@ import (
 	"io"
 	"os"
 	"regexp"
+	"slices"
 	"strconv"
+	"strings"
 	"text/template"
 )
 
@@ -43,6 +45,7 @@ func main() {
 
 	hoverDocStrings := map[string][]string{}
 	completionDocStrings := map[string][]CompletionDoc{}
+	statusCodes := map[string][]bool{}
 	for _, api := range manual.Items {
 		for _, name := range api.Names {
 			re := regexp.MustCompile(`\w+ (\w+)\(`)
@@ -51,6 +54,7 @@ func main() {
 				funcName := matches[1]
 				docString := createDocMarkdownString(name, api.Desc)
 				hoverDocStrings[funcName] = append(hoverDocStrings[funcName], strconv.Quote(docString))
+				statusCodes[funcName] = append(statusCodes[funcName], isStatusCode(name, api.Desc))
 				completionDocStrings[funcName] = append(
 					completionDocStrings[funcName],
 					CompletionDoc{
@@ -62,6 +66,13 @@ func main() {
 			}
 		}
 	}
+	// Only the functions with an overload which returns a status code are
+	// generated.
+	for funcName, overloads := range statusCodes {
+		if !slices.Contains(overloads, true) {
+			delete(statusCodes, funcName)
+		}
+	}
 	templ := template.Must(template.New("sourceCode").Parse(`// AUTOGENERATED FILE DO NOT MODIFY
 package lang
 
@@ -78,6 +89,19 @@ var Lib = map[string][]string{
 {{- end}}
 }
 
+// Map of library func identifier and whether each overload returns a status
+// code, in the same order as the docstrings in Lib. Functions which do not
+// return a status code are not included.
+var LibStatusCodes = map[string][]bool{
+{{- range $name, $overloads := .StatusCodes}}
+	"{{$name}}": {
+{{- range $isStatusCode := $overloads}}
+		{{$isStatusCode}},
+{{- end}}
+	},
+{{- end}}
+}
+
 var LibCompletionItems = []protocol.CompletionItem{
 {{- range $name, $items := .CompletionDoc}}
 {{- range $item := $items}}
@@ -94,7 +118,7 @@ var LibCompletionItems = []protocol.CompletionItem{
 {{- end}}
 }
 `))
-	if err := templ.Execute(os.Stdout, Data{HoverDoc: hoverDocStrings, CompletionDoc: completionDocStrings}); err != nil {
+	if err := templ.Execute(os.Stdout, Data{HoverDoc: hoverDocStrings, CompletionDoc: completionDocStrings, StatusCodes: statusCodes}); err != nil {
 		fmt.Printf("could not execute template: %s\n", err)
 		os.Exit(1)
 	}
@@ -103,6 +127,7 @@ var LibCompletionItems = []protocol.CompletionItem{
 type Data struct {
 	HoverDoc      map[string][]string
 	CompletionDoc map[string][]CompletionDoc
+	StatusCodes   map[string][]bool
 }
 
 type Manual struct {
@@ -138,3 +163,15 @@ func createDocMarkdownString(signature, desc string) string {
 	encodedDesc, _ := json.Marshal(desc)
 	return fmt.Sprintf("```12dpl\n%s\n```\n---\n%s", signature, encodedDesc[1:len(encodedDesc)-1])
 }
+
+// Matches the sentences of a description which say that a return value of zero
+// means the function succeeded, for example "A function return value of zero
+// indicates the data was successfully returned." or "This call returns 0 if it
+// succeeds".
+var statusCodePattern = regexp.MustCompile(`(?i)(return val(ue|e) (of|is) (zero|0)\b|zero function return value indicates|returns? (zero|0) if)[^.]*(\bsucce|no errors)`)
+
+// Returns true if the function with the signature returns an Integer status
+// code according to its description.
+func isStatusCode(signature, desc string) bool {
+	return strings.HasPrefix(signature, "Integer ") && statusCodePattern.MatchString(desc)
+}
@@ -0,0 +1,193 @@
+package format
+
+import (
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// A line of a run of statements which are aligned.
+type alignedLine struct {
+	statementNode *sitter.Node
+	// Index of the trailing comment token of the line, -1 if the line does
+	// not have one.
+	commentTokenIdx int
+}
+
+// Aligns the identifiers, assignment operators and trailing comments of runs
+// of consecutive declarations and assignments, for example:
+//
+//	Integer_Box count = Create_integer_box("Count", message); // Positive.
+//	Text_Box    name  = Create_text_box("Name", message);     // Not empty.
+//
+// A run is broken by blank lines, comments on their own line and statements
+// which are not declarations or assignments. The columns are aligned from
+// left to right since aligning a column moves the columns after it.
+func (p *printer) alignStatements() {
+	tokenIdxsByStartByte := map[uint32]int{}
+	for idx, t := range p.tokens {
+		tokenIdxsByStartByte[t.startByte] = idx
+	}
+	text, tokenOffsets := p.render()
+	runs := p.getAlignmentRuns(text, tokenOffsets, tokenIdxsByStartByte)
+	getColumnTokenIdxFuncs := []func(alignedLine) int{
+		func(line alignedLine) int {
+			return getTokenIdx(tokenIdxsByStartByte, getDeclarationIdentifierNode(line.statementNode))
+		},
+		func(line alignedLine) int {
+			return getTokenIdx(tokenIdxsByStartByte, getAssignmentOperatorNode(line.statementNode))
+		},
+		func(line alignedLine) int { return line.commentTokenIdx },
+	}
+	for _, getColumnTokenIdx := range getColumnTokenIdxFuncs {
+		text, tokenOffsets = p.render()
+		for _, run := range runs {
+			tokenIdxs := []int{}
+			for _, line := range run {
+				// The first token does not have whitespace before it to pad.
+				if tokenIdx := getColumnTokenIdx(line); tokenIdx > 0 {
+					tokenIdxs = append(tokenIdxs, tokenIdx)
+				}
+			}
+			p.alignTokens(text, tokenOffsets, tokenIdxs)
+		}
+	}
+}
+
+// Get the runs of consecutive lines which are aligned. Runs have at least two
+// lines.
+func (p *printer) getAlignmentRuns(text string, tokenOffsets []int, tokenIdxsByStartByte map[uint32]int) [][]alignedLine {
+	result := [][]alignedLine{}
+	lineStartBytes := getLineStartBytes([]byte(text))
+	getLine := func(node *sitter.Node) int {
+		return int(getPosition(lineStartBytes, tokenOffsets[tokenIdxsByStartByte[node.StartByte()]]).Line)
+	}
+	getEndLine := func(node *sitter.Node) int {
+		lastTokenIdx := tokenIdxsByStartByte[node.StartByte()]
+		for lastTokenIdx+1 < len(p.tokens) && p.tokens[lastTokenIdx+1].endByte <= node.EndByte() {
+			lastTokenIdx++
+		}
+		return int(getPosition(lineStartBytes, tokenOffsets[lastTokenIdx]).Line)
+	}
+	for _, listNode := range p.getStatementListNodes() {
+		run := []alignedLine{}
+		prevLine := -1
+		flush := func() {
+			if len(run) > 1 {
+				result = append(result, run)
+			}
+			run = []alignedLine{}
+		}
+		for i := 0; i < int(listNode.NamedChildCount()); i++ {
+			child := listNode.NamedChild(i)
+			line := getLine(child)
+			if child.Type() == "comment" && line == prevLine && len(run) > 0 {
+				run[len(run)-1].commentTokenIdx = tokenIdxsByStartByte[child.StartByte()]
+				continue
+			}
+			if !isAlignableStatementNode(child) || getEndLine(child) != line || line != prevLine+1 {
+				flush()
+			}
+			if isAlignableStatementNode(child) && getEndLine(child) == line {
+				run = append(run, alignedLine{statementNode: child, commentTokenIdx: -1})
+			}
+			prevLine = getEndLine(child)
+		}
+		flush()
+	}
+	return result
+}
+
+// Get the nodes which contain lists of statements, blocks and the source file.
+func (p *printer) getStatementListNodes() []*sitter.Node {
+	result := []*sitter.Node{}
+	seen := map[uint32]bool{}
+	for _, t := range p.tokens {
+		parent := t.statementNode.Parent()
+		if parent == nil || !isBlockStatementNode(t.statementNode) || seen[parent.StartByte()] {
+			continue
+		}
+		seen[parent.StartByte()] = true
+		result = append(result, parent)
+	}
+	return result
+}
+
+// Returns true if the node is a declaration or an assignment statement
+// without syntax errors.
+func isAlignableStatementNode(node *sitter.Node) bool {
+	if node.HasError() {
+		return false
+	}
+	switch node.Type() {
+	case "declaration":
+		return true
+	case "expression_statement":
+		return node.NamedChildCount() > 0 && node.NamedChild(0).Type() == "assignment_expression"
+	}
+	return false
+}
+
+// Get the identifier of the declaration, "a" in "Integer a = 1;". Returns nil
+// if the statement is not a declaration.
+func getDeclarationIdentifierNode(statementNode *sitter.Node) *sitter.Node {
+	if statementNode.Type() != "declaration" {
+		return nil
+	}
+	return statementNode.ChildByFieldName("declarator")
+}
+
+// Get the assignment operator of the statement, "=" in "Integer a = 1;" and
+// "a = 1;". Returns nil if the statement does not assign a value.
+func getAssignmentOperatorNode(statementNode *sitter.Node) *sitter.Node {
+	switch statementNode.Type() {
+	case "declaration":
+		// Only the first declarator is aligned, "Integer a = 1, b = 2;".
+		declaratorNode := statementNode.ChildByFieldName("declarator")
+		if declaratorNode == nil || declaratorNode.Type() != "init_declarator" {
+			return nil
+		}
+		for i := 0; i < int(declaratorNode.ChildCount()); i++ {
+			if declaratorNode.Child(i).Type() == "=" {
+				return declaratorNode.Child(i)
+			}
+		}
+	case "expression_statement":
+		return statementNode.NamedChild(0).ChildByFieldName("operator")
+	}
+	return nil
+}
+
+// Get the index of the token which starts the node, -1 if the node is nil.
+func getTokenIdx(tokenIdxsByStartByte map[uint32]int, node *sitter.Node) int {
+	if node == nil {
+		return -1
+	}
+	tokenIdx, ok := tokenIdxsByStartByte[node.StartByte()]
+	if !ok {
+		return -1
+	}
+	return tokenIdx
+}
+
+// Pads the whitespace before the tokens so that they start on the same
+// column, one space after the end of the longest text before them.
+func (p *printer) alignTokens(text string, tokenOffsets []int, tokenIdxs []int) {
+	if len(tokenIdxs) < 2 {
+		return
+	}
+	contentEndColumns := make([]int, len(tokenIdxs))
+	maxContentEndColumn := 0
+	for i, tokenIdx := range tokenIdxs {
+		prevToken := p.tokens[tokenIdx-1]
+		prevTokenEndOffset := tokenOffsets[tokenIdx-1] + int(prevToken.endByte-prevToken.startByte)
+		lineStartOffset := strings.LastIndex(text[:prevTokenEndOffset], "\n") + 1
+		contentEndColumns[i] = prevTokenEndOffset - lineStartOffset
+		if contentEndColumns[i] > maxContentEndColumn {
+			maxContentEndColumn = contentEndColumns[i]
+		}
+	}
+	for i, tokenIdx := range tokenIdxs {
+		p.alignedGaps[tokenIdx] = strings.Repeat(" ", maxContentEndColumn-contentEndColumns[i]+1)
+	}
+}
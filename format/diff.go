@@ -0,0 +1,109 @@
+package format
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/kelly-lin/12d-lang-server/protocol"
+)
+
+// Get the edits which replace the original whitespace of the gap with its
+// canonical whitespace. When both span the same number of lines, only the
+// lines and line endings which differ are replaced so that the edits are as
+// small as possible.
+func getGapEdits(sourceCode []byte, lineStartBytes []int, g gap) []protocol.TextEdit {
+	result := []protocol.TextEdit{}
+	original := string(sourceCode[g.startByte:g.endByte])
+	if original == g.text {
+		return result
+	}
+	originalLines := strings.Split(original, "\n")
+	lines := strings.Split(g.text, "\n")
+	if len(originalLines) != len(lines) {
+		return append(result, protocol.TextEdit{
+			Range:   getRange(lineStartBytes, int(g.startByte), int(g.endByte)),
+			NewText: g.text,
+		})
+	}
+	startByte := int(g.startByte)
+	lastIdx := len(originalLines) - 1
+	for idx, originalLine := range originalLines {
+		line := lines[idx]
+		// The carriage return of a line ending is not part of the line, the
+		// position of the end of a line is before it.
+		isOriginalCRLF, isCRLF := false, false
+		if idx < lastIdx {
+			originalLine, isOriginalCRLF = strings.CutSuffix(originalLine, "\r")
+			line, isCRLF = strings.CutSuffix(line, "\r")
+		}
+		endByte := startByte + len(originalLine)
+		if originalLine != line {
+			result = append(result, protocol.TextEdit{
+				Range:   getRange(lineStartBytes, startByte, endByte),
+				NewText: line,
+			})
+		}
+		if isOriginalCRLF {
+			endByte++
+		}
+		if isOriginalCRLF != isCRLF {
+			lineEnding := LineEndingLF
+			if isCRLF {
+				lineEnding = LineEndingCRLF
+			}
+			result = append(result, protocol.TextEdit{
+				Range:   getRange(lineStartBytes, startByte+len(originalLine), endByte+1),
+				NewText: lineEnding,
+			})
+		}
+		startByte = endByte + 1
+	}
+	return result
+}
+
+// Get the edits which convert the line endings between the start and end byte
+// offsets to the line ending.
+func getLineEndingEdits(sourceCode []byte, lineStartBytes []int, startByte, endByte uint32, lineEnding string) []protocol.TextEdit {
+	result := []protocol.TextEdit{}
+	for idx := int(startByte); idx < int(endByte); idx++ {
+		if sourceCode[idx] != '\n' {
+			continue
+		}
+		lineEndingStartByte := idx
+		if idx > int(startByte) && sourceCode[idx-1] == '\r' {
+			lineEndingStartByte--
+		}
+		if string(sourceCode[lineEndingStartByte:idx+1]) != lineEnding {
+			result = append(result, protocol.TextEdit{
+				Range:   getRange(lineStartBytes, lineEndingStartByte, idx+1),
+				NewText: lineEnding,
+			})
+		}
+	}
+	return result
+}
+
+// Get the byte offsets of the start of each line.
+func getLineStartBytes(sourceCode []byte) []int {
+	result := []int{0}
+	for idx, char := range sourceCode {
+		if char == '\n' {
+			result = append(result, idx+1)
+		}
+	}
+	return result
+}
+
+// Get the range between the start and end byte offsets.
+func getRange(lineStartBytes []int, startByte, endByte int) protocol.Range {
+	return protocol.Range{
+		Start: getPosition(lineStartBytes, startByte),
+		End:   getPosition(lineStartBytes, endByte),
+	}
+}
+
+// Get the position of the byte offset.
+func getPosition(lineStartBytes []int, offset int) protocol.Position {
+	line := sort.Search(len(lineStartBytes), func(i int) bool { return lineStartBytes[i] > offset }) - 1
+	return protocol.Position{Line: uint(line), Character: uint(offset - lineStartBytes[line])}
+}
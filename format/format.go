@@ -1,510 +1,782 @@
 package format
 
 import (
+	"bytes"
 	"strings"
 
-	"github.com/kelly-lin/12d-lang-server/parser/12dpl"
 	"github.com/kelly-lin/12d-lang-server/protocol"
 	sitter "github.com/smacker/go-tree-sitter"
 )
 
-const numSpaces = 4
+// The maximum number of consecutive newlines between two tokens, which is a
+// single blank line.
+const maxNumNewlines = 2
 
-// Get formatting edits for block indentations.
-func GetIndentationEdits(node *sitter.Node) []protocol.TextEdit {
+// A token is a leaf of the syntax tree or a node which is printed as is such
+// as comments, string literals, preprocessor directives and syntax errors.
+type token struct {
+	node *sitter.Node
+	// The byte offsets of the token, trailing whitespace is excluded so that
+	// it is part of the whitespace between tokens.
+	startByte uint32
+	endByte   uint32
+	// The token is a syntax error or is inside of one. The whitespace around
+	// the token is printed as is since we cannot tell what the code means.
+	isError bool
+	// The statement which the token belongs to.
+	statementNode *sitter.Node
+}
+
+// A gap is the whitespace before a token, or the end of the document for the
+// gap after the last token.
+type gap struct {
+	startByte uint32
+	endByte   uint32
+	// The canonical whitespace.
+	text string
+}
+
+// The printer renders the syntax tree to canonical text. Tokens are printed
+// as is, only the whitespace between them changes.
+type printer struct {
+	sourceCode []byte
+	opts       Options
+	tokens     []token
+	// The argument and parameter lists which are broken one item per line
+	// because they do not fit in the maximum line width, by the start byte
+	// of the list.
+	wrappedLists map[uint32]bool
+	// The whitespace before the tokens which are aligned with the lines
+	// around them, by the index of the token.
+	alignedGaps map[int]string
+	// Line ending of inserted lines.
+	lineEnding string
+	// The blocks of include and define lines which are printed in organized
+	// order, by the index of their first token.
+	organizedBlocks map[int]organizedBlock
+}
+
+// The tokens of a block of include and define lines which are replaced by
+// their organized text.
+type organizedBlock struct {
+	endIdx int
+	text   string
+}
+
+// Get the formatting edits for the whole document. The edits are the
+// differences between the document and its canonical form and do not overlap.
+func GetEdits(rootNode *sitter.Node, sourceCode []byte, opts Options) []protocol.TextEdit {
 	result := []protocol.TextEdit{}
-	stack := parser.NewStack()
-	stack.Push(node)
-	for stack.HasItems() {
-		currentNode, _ := stack.Pop()
-		nodeType := currentNode.Type()
-		indentLevel := getIndentLevel(currentNode)
-		targetIndentation := indentLevel * numSpaces
-		if nodeType == "compound_statement" {
-			result = append(result, indentCompoundStatementNode(currentNode, targetIndentation)...)
+	p := newPrinter(rootNode, sourceCode, opts)
+	lineStartBytes := getLineStartBytes(sourceCode)
+	gaps := p.getGaps()
+	for idx := 0; idx < len(gaps); idx++ {
+		result = append(result, getGapEdits(sourceCode, lineStartBytes, gaps[idx])...)
+		if idx >= len(p.tokens) {
+			continue
 		}
-		shouldIndentNode := nodeType == "declaration" && currentNode.Parent().Type() != "for_statement" || nodeType == "while_statement" || nodeType == "function_definition" || nodeType == "for_statement" || nodeType == "if_statement" && currentNode.Parent().Type() != "if_statement"
-		if shouldIndentNode {
-			result = append(result, indentNode(currentNode, targetIndentation)...)
+		t := p.tokens[idx]
+		if block, ok := p.organizedBlocks[idx]; ok {
+			result = append(result, protocol.TextEdit{
+				Range:   getRange(lineStartBytes, int(t.startByte), int(p.tokens[block.endIdx].endByte)),
+				NewText: p.normalizeLineEndings(block.text),
+			})
+			idx = block.endIdx
+			continue
 		}
-		for i := 0; i < int(currentNode.ChildCount()); i++ {
-			stack.Push(currentNode.Child(i))
+		// Comments, strings and preprocessor directives can span lines.
+		if opts.LineEnding != "" {
+			result = append(result, getLineEndingEdits(sourceCode, lineStartBytes, t.startByte, t.endByte, opts.LineEnding)...)
 		}
 	}
 	return result
 }
 
-// Get formatting edits for trailing whitespaces.
-func GetTrailingWhitespaceEdits(sourceCode []byte) []protocol.TextEdit {
-	result := []protocol.TextEdit{}
-	lines := strings.Split(string(sourceCode), "\n")
-	for idx, line := range lines {
-		numSpaces := 0
-		for i := len(line) - 1; i >= 0; i-- {
-			if line[i] != ' ' {
-				break
-			}
-			numSpaces++
+// Print the document described by the syntax tree in canonical form.
+func Print(rootNode *sitter.Node, sourceCode []byte, opts Options) string {
+	p := newPrinter(rootNode, sourceCode, opts)
+	if len(p.tokens) == 0 {
+		return string(sourceCode)
+	}
+	result, _ := p.render()
+	return result
+}
+
+func newPrinter(rootNode *sitter.Node, sourceCode []byte, opts Options) *printer {
+	p := &printer{
+		sourceCode:      sourceCode,
+		opts:            opts,
+		wrappedLists:    map[uint32]bool{},
+		alignedGaps:     map[int]string{},
+		lineEnding:      opts.LineEnding,
+		organizedBlocks: map[int]organizedBlock{},
+	}
+	if p.lineEnding == "" {
+		p.lineEnding = getLineEnding(sourceCode)
+	}
+	p.collectTokens(rootNode, false)
+	if opts.OrganizeIncludes {
+		p.organizeBlocks(rootNode)
+	}
+	if opts.MaxLineWidth > 0 {
+		p.wrapLists()
+	}
+	if opts.AlignConsecutive {
+		p.alignStatements()
+	}
+	return p
+}
+
+// Render the document in canonical form. Returns the text and the offsets of
+// the tokens in the text.
+func (p *printer) render() (string, []int) {
+	var sb strings.Builder
+	tokenOffsets := []int{}
+	gaps := p.getGaps()
+	for idx := 0; idx < len(gaps); idx++ {
+		sb.WriteString(gaps[idx].text)
+		if idx >= len(p.tokens) {
+			continue
 		}
-		if numSpaces > 0 {
-			result = append(
-				result,
-				protocol.TextEdit{
-					Range: protocol.Range{
-						Start: protocol.Position{
-							Line:      uint(idx),
-							Character: uint(len(line) - numSpaces),
-						},
-						End: protocol.Position{
-							Line:      uint(idx),
-							Character: uint(len(line)),
-						},
-					},
-				},
-			)
+		// The tokens of an organized block are in a different order, they
+		// are all placed at the start of the block.
+		if block, ok := p.organizedBlocks[idx]; ok {
+			for i := idx; i <= block.endIdx; i++ {
+				tokenOffsets = append(tokenOffsets, sb.Len())
+			}
+			idx = block.endIdx
+			sb.WriteString(p.normalizeLineEndings(block.text))
+			continue
 		}
+		tokenOffsets = append(tokenOffsets, sb.Len())
+		sb.WriteString(p.normalizeLineEndings(string(p.sourceCode[p.tokens[idx].startByte:p.tokens[idx].endByte])))
 	}
-	return result
+	return sb.String(), tokenOffsets
 }
 
-// Get formatting edits for function definitions.
-func GetFuncDefEdits(rootNode *sitter.Node) []protocol.TextEdit {
-	result := []protocol.TextEdit{}
-	for i := 0; i < int(rootNode.ChildCount()); i++ {
-		currentNode := rootNode.Child(i)
-		if currentNode.Type() != "function_definition" {
+// Find the blocks of include and define lines which are not organized.
+func (p *printer) organizeBlocks(rootNode *sitter.Node) {
+	tokenIdxsByStartByte := map[uint32]int{}
+	for idx, t := range p.tokens {
+		tokenIdxsByStartByte[t.startByte] = idx
+	}
+	for _, block := range getDirectiveBlocks(rootNode, p.sourceCode, p.lineEnding, p.opts.IncludeInfo) {
+		startByte, endByte := block.getByteRange(p.sourceCode)
+		if string(p.sourceCode[startByte:endByte]) == block.text {
+			continue
+		}
+		startIdx, ok := tokenIdxsByStartByte[startByte]
+		if !ok {
 			continue
 		}
-		returnTypeNode := currentNode.ChildByFieldName("type")
-		funcDeclarationNode := currentNode.ChildByFieldName("declarator")
-		bodyNode := currentNode.ChildByFieldName("body")
+		endIdx := startIdx
+		for endIdx+1 < len(p.tokens) && p.tokens[endIdx+1].startByte < endByte {
+			endIdx++
+		}
+		p.organizedBlocks[startIdx] = organizedBlock{endIdx: endIdx, text: block.text}
+	}
+}
 
-		result = append(result, formatReturnTypeAndDeclarationSpacing(funcDeclarationNode, returnTypeNode)...)
-		result = append(result, formatFuncDeclarationAndBodySpacing(bodyNode, funcDeclarationNode)...)
-		result = append(result, formatParamList(funcDeclarationNode)...)
+// Collects the tokens of the node in document order.
+func (p *printer) collectTokens(node *sitter.Node, isError bool) {
+	// Missing nodes are inserted by the parser to recover from errors, they
+	// do not have any text.
+	if node.StartByte() == node.EndByte() {
+		return
 	}
-	return result
+	// The "do" of a do while loop is formatted like a keyword.
+	isError = isError || node.IsError() && !isDoKeyword(node, p.sourceCode)
+	if node.ChildCount() > 0 && !isAtomicNode(node) {
+		for i := 0; i < int(node.ChildCount()); i++ {
+			p.collectTokens(node.Child(i), isError)
+		}
+		return
+	}
+	endByte := node.EndByte()
+	for endByte > node.StartByte() && isWhitespace(p.sourceCode[endByte-1]) {
+		endByte--
+	}
+	if endByte == node.StartByte() {
+		return
+	}
+	p.tokens = append(p.tokens, token{
+		node:          node,
+		startByte:     node.StartByte(),
+		endByte:       endByte,
+		isError:       isError,
+		statementNode: getStatementNode(node),
+	})
 }
 
-// Formats the spacing between the function return type and declaration. For
-// example the spacing between "void" and "Foo" in "void Foo()".
-func formatReturnTypeAndDeclarationSpacing(funcDeclarationNode, returnTypeNode *sitter.Node) []protocol.TextEdit {
-	result := []protocol.TextEdit{}
-	numSpaces := funcDeclarationNode.StartPoint().Column - returnTypeNode.EndPoint().Column
-	if numSpaces != 1 {
-		lineNum := uint(returnTypeNode.StartPoint().Row)
-		result = append(
-			result,
-			protocol.TextEdit{
-				Range: protocol.Range{
-					Start: protocol.Position{
-						Line:      lineNum,
-						Character: uint(returnTypeNode.EndPoint().Column),
-					},
-					End: protocol.Position{
-						Line:      lineNum,
-						Character: uint(funcDeclarationNode.StartPoint().Column),
-					},
-				},
-				NewText: " ",
-			},
-		)
+// Returns true if the node is printed as is instead of printing its children.
+func isAtomicNode(node *sitter.Node) bool {
+	switch node.Type() {
+	case "comment", "string_literal", "char_literal", "ERROR":
+		return true
+	}
+	return isPreprocNode(node)
+}
+
+// Returns true if the node is a preprocessor directive.
+func isPreprocNode(node *sitter.Node) bool {
+	return strings.HasPrefix(node.Type(), "preproc_")
+}
+
+func isWhitespace(char byte) bool {
+	return char == ' ' || char == '\t' || char == '\r' || char == '\n'
+}
+
+// Get the gaps before each token followed by the gap after the last token.
+func (p *printer) getGaps() []gap {
+	if len(p.tokens) == 0 {
+		return []gap{}
+	}
+	// The line of a block whose lines have all been removed is removed with
+	// it.
+	isRemovedLine := map[int]bool{}
+	for _, block := range p.organizedBlocks {
+		isRemovedLine[block.endIdx+1] = block.text == ""
 	}
+	result := []gap{{startByte: 0, endByte: p.tokens[0].startByte, text: ""}}
+	for i := 1; i < len(p.tokens); i++ {
+		prevToken := p.tokens[i-1]
+		currentToken := p.tokens[i]
+		text, ok := p.alignedGaps[i]
+		if !ok {
+			text = p.getGapText(prevToken, currentToken)
+		}
+		if isRemovedLine[i] {
+			text = ""
+		}
+		result = append(result, gap{
+			startByte: prevToken.endByte,
+			endByte:   currentToken.startByte,
+			text:      p.normalizeLineEndings(text),
+		})
+	}
+	lastToken := p.tokens[len(p.tokens)-1]
+	result = append(result, gap{
+		startByte: lastToken.endByte,
+		endByte:   uint32(len(p.sourceCode)),
+		text:      p.normalizeLineEndings(p.getFinalGapText(string(p.sourceCode[lastToken.endByte:]))),
+	})
 	return result
 }
 
-// Get the formatting edits for the spacing between the ending parenthesis of
-// the function parameter list and opening body brace. i.e. the space between
-// the ")" and "{" in "void Foo() {}".
-func formatFuncDeclarationAndBodySpacing(bodyNode, funcDeclarationNode *sitter.Node) []protocol.TextEdit {
-	if bodyNode.StartPoint().Row > funcDeclarationNode.EndPoint().Row {
-		if bodyNode.StartPoint().Column > 0 {
-			return []protocol.TextEdit{
-				{
-					Range: protocol.Range{
-						Start: protocol.Position{
-							Line:      1,
-							Character: 0,
-						},
-						End: protocol.Position{
-							Line:      1,
-							Character: 2,
-						},
-					},
-					NewText: "",
-				},
-			}
+// Convert the line endings of the text to the line ending of the options.
+// The text is returned as is when line endings are kept as they were written.
+func (p *printer) normalizeLineEndings(text string) string {
+	if p.opts.LineEnding == "" {
+		return text
+	}
+	text = strings.ReplaceAll(text, LineEndingCRLF, LineEndingLF)
+	if p.opts.LineEnding == LineEndingCRLF {
+		text = strings.ReplaceAll(text, LineEndingLF, LineEndingCRLF)
+	}
+	return text
+}
+
+// Get the line ending of the first line of the source code. Source code
+// without line endings is assumed to use "\n".
+func getLineEnding(sourceCode []byte) string {
+	idx := bytes.IndexByte(sourceCode, '\n')
+	if idx > 0 && sourceCode[idx-1] == '\r' {
+		return LineEndingCRLF
+	}
+	return LineEndingLF
+}
+
+// Get the canonical whitespace between the previous and current tokens.
+func (p *printer) getGapText(prevToken, currentToken token) string {
+	original := string(p.sourceCode[prevToken.endByte:currentToken.startByte])
+	if prevToken.isError || currentToken.isError {
+		return original
+	}
+	// We only know that the statement is broken, not how, so we leave the
+	// inside of it alone.
+	if isSameNode(prevToken.statementNode, currentToken.statementNode) && prevToken.statementNode.HasError() {
+		return original
+	}
+	if text, ok := p.getListGapText(prevToken, currentToken); ok {
+		return text
+	}
+	if isJoinedGap(prevToken, currentToken, p.sourceCode) {
+		return " "
+	}
+	if text, ok := p.getStyleGapText(prevToken, currentToken, original); ok {
+		return text
+	}
+	numNewlines := strings.Count(original, "\n")
+	if numNewlines == 0 && isLineBreakRequired(prevToken, currentToken, p.sourceCode) {
+		numNewlines = 1
+	}
+	if numNewlines == 0 {
+		return getSpacing(prevToken, currentToken, p.sourceCode, original, p.opts.Style)
+	}
+	if numNewlines > maxNumNewlines {
+		numNewlines = maxNumNewlines
+	}
+	return p.getLineEndings(original, numNewlines) + p.getIndentation(currentToken)
+}
+
+// Get the whitespace between the previous and current tokens when it is
+// decided by the style profile. Returns false if the profile does not decide
+// it.
+func (p *printer) getStyleGapText(prevToken, currentToken token, original string) (string, bool) {
+	style := p.opts.Style
+	if style.Name == "" {
+		return "", false
+	}
+	prevNode := prevToken.node
+	currentNode := currentToken.node
+	switch {
+	// The brace cannot be moved above banner comments between the signature
+	// and the body.
+	case isBodyBrace(currentNode, p.sourceCode) && prevNode.Type() != "comment":
+		isOnNewLine := style.ControlBraceOnNewLine
+		if currentNode.Parent().Parent().Type() == "function_definition" {
+			isOnNewLine = style.FuncBraceOnNewLine
+		}
+		return p.getLineBreakText(original, isOnNewLine, currentToken), true
+	case prevNode.Type() == "}" && currentNode.Type() == "else":
+		return p.getLineBreakText(original, !style.CuddleElse, currentToken), true
+	case isControlKeyword(prevNode) && currentNode.Type() == "(":
+		if style.SpaceAfterKeyword {
+			return " ", true
 		}
-		return []protocol.TextEdit{}
+		return "", true
 	}
-	result := []protocol.TextEdit{}
-	numSpaces := bodyNode.StartPoint().Column - funcDeclarationNode.EndPoint().Column
-	if numSpaces != 1 {
-		lineNum := uint(bodyNode.StartPoint().Row)
-		result = append(
-			result,
-			protocol.TextEdit{
-				Range: protocol.Range{
-					Start: protocol.Position{
-						Line:      lineNum,
-						Character: uint(funcDeclarationNode.EndPoint().Column),
-					},
-					End: protocol.Position{
-						Line:      lineNum,
-						Character: uint(bodyNode.StartPoint().Column),
-					},
-				},
-				NewText: " ",
-			},
-		)
+	return "", false
+}
+
+// Returns true if the tokens are always on the same line separated by a
+// space, "else if", "} while (a);" of a do while loop and "} break;" after the
+// block of a case.
+func isJoinedGap(prevToken, currentToken token, sourceCode []byte) bool {
+	prevNode := prevToken.node
+	currentNode := currentToken.node
+	switch {
+	case prevNode.Type() == "else" && currentNode.Type() == "if":
+		return true
+	case prevNode.Type() == "}" && currentNode.Type() == "while":
+		return isDoWhileStatement(currentNode.Parent(), sourceCode)
+	case prevNode.Type() == "}" && currentNode.Type() == "break":
+		block := prevNode.Parent()
+		return block.Parent() != nil && block.Parent().Type() == "case_statement"
 	}
-	return result
+	return false
 }
 
-// Get formatting edits for the function parameter list.
-func formatParamList(funcDeclarationNode *sitter.Node) []protocol.TextEdit {
-	var result []protocol.TextEdit
-	paramsNode := funcDeclarationNode.ChildByFieldName("parameters")
-	paramIdx := 0
-	numChildren := int(paramsNode.ChildCount())
-	if numChildren == 0 {
-		return []protocol.TextEdit{}
-	}
-	prevLine := -1
-	var prevNode *sitter.Node
-	for i := 0; i < numChildren; i++ {
-		currentNode := paramsNode.Child(i)
-		if currentNode.Type() == "parameter_declaration" {
-			if prevNode != nil {
-				result = append(result, formatParamSpacing(currentNode, prevNode)...)
-			}
+// Get the whitespace before the token when it is either on a new line or
+// separated by a single space.
+func (p *printer) getLineBreakText(original string, isOnNewLine bool, t token) string {
+	if !isOnNewLine {
+		return " "
+	}
+	return p.getLineEndings(original, 1) + p.getIndentation(t)
+}
+
+// Get the canonical whitespace after the last token of the document.
+func (p *printer) getFinalGapText(original string) string {
+	lines := strings.Split(original, "\n")
+	numNewlines := len(lines) - 1
+	if p.opts.TrimFinalNewlines && numNewlines > 1 {
+		numNewlines = 1
+	}
+	if p.opts.InsertFinalNewline && numNewlines == 0 {
+		numNewlines = 1
+	}
+	lastLine := lines[len(lines)-1]
+	if p.opts.TrimTrailingWhitespace || numNewlines != len(lines)-1 {
+		lastLine = ""
+	}
+	return p.getLineEndings(original, numNewlines) + lastLine
+}
 
-			if prevLine < int(currentNode.StartPoint().Row) {
-				if int(currentNode.StartPoint().Row) == int(funcDeclarationNode.StartPoint().Row) {
-					funcIdentifierNode := funcDeclarationNode.ChildByFieldName("declarator")
-					if currentNode.StartPoint().Column-funcIdentifierNode.EndPoint().Column > 1 {
-						result = append(
-							result,
-							protocol.TextEdit{
-								Range: protocol.Range{
-									Start: protocol.Position{
-										Line:      uint(currentNode.StartPoint().Row),
-										Character: uint(funcIdentifierNode.EndPoint().Column) + 1,
-									},
-									End: protocol.Position{
-										Line:      uint(currentNode.StartPoint().Row),
-										Character: uint(currentNode.StartPoint().Column),
-									},
-								},
-								NewText: "",
-							},
-						)
-					}
-				} else {
-					if currentNode.StartPoint().Column < 4 {
-						result = append(
-							result,
-							protocol.TextEdit{
-								Range: protocol.Range{
-									Start: protocol.Position{
-										Line:      uint(currentNode.StartPoint().Row),
-										Character: 0,
-									},
-									End: protocol.Position{
-										Line:      uint(currentNode.StartPoint().Row),
-										Character: uint(currentNode.StartPoint().Column),
-									},
-								},
-								NewText: "    ",
-							},
-						)
-					}
+// Get the text of the first numNewlines lines of the original whitespace
+// including the line endings. The whitespace at the end of the lines is kept
+// unless we are trimming trailing whitespace. Lines which are not in the
+// original whitespace end with the line ending of the document.
+func (p *printer) getLineEndings(original string, numNewlines int) string {
+	var sb strings.Builder
+	lines := strings.Split(original, "\n")
+	for i := 0; i < numNewlines; i++ {
+		lineEnding := p.lineEnding
+		if i < len(lines)-1 {
+			// Carriage returns are part of the line ending, not trailing
+			// whitespace.
+			line, isCRLF := strings.CutSuffix(lines[i], "\r")
+			if p.opts.TrimTrailingWhitespace {
+				line = strings.Trim(line, " \t")
+			}
+			sb.WriteString(line)
+			if p.opts.LineEnding == "" {
+				lineEnding = LineEndingLF
+				if isCRLF {
+					lineEnding = LineEndingCRLF
 				}
-				prevLine = int(currentNode.StartPoint().Row)
 			}
+		}
+		sb.WriteString(lineEnding)
+	}
+	return sb.String()
+}
 
-			typeNode := currentNode.ChildByFieldName("type")
-			declaratorNode := currentNode.ChildByFieldName("declarator")
-			shouldFormatTypeIdentifierSpacing := declaratorNode.StartPoint().Column-typeNode.EndPoint().Column > 1
-			if shouldFormatTypeIdentifierSpacing {
-				result = append(
-					result,
-					protocol.TextEdit{
-						Range: protocol.Range{
-							Start: protocol.Position{
-								Line:      uint(typeNode.EndPoint().Row),
-								Character: uint(typeNode.EndPoint().Column),
-							},
-							End: protocol.Position{
-								Line:      uint(declaratorNode.StartPoint().Row),
-								Character: uint(declaratorNode.StartPoint().Column),
-							},
-						},
-						NewText: " ",
-					},
-				)
-			}
-			prevNode = currentNode
-			paramIdx++
+// Get the indentation of the token which starts a line.
+func (p *printer) getIndentation(t token) string {
+	if isPreprocNode(t.node) {
+		return ""
+	}
+	indentLevel := getIndentLevel(t.node)
+	if !isLineStart(t) {
+		indentLevel++
+	}
+	// Labels stand out from the statements around them.
+	if t.statementNode.Type() == "labeled_statement" && t.startByte == t.statementNode.StartByte() && indentLevel > 0 {
+		indentLevel--
+	}
+	return p.opts.indentText(indentLevel)
+}
+
+// Returns true if the current token must be on a new line.
+func isLineBreakRequired(prevToken, currentToken token, sourceCode []byte) bool {
+	if isPreprocNode(prevToken.node) || isPreprocNode(currentToken.node) || isLineComment(prevToken.node, sourceCode) {
+		return true
+	}
+	// Trailing comments stay on the line they are on.
+	if currentToken.node.Type() == "comment" {
+		return false
+	}
+	if isBlockBrace(prevToken.node, "{") || isBlockBrace(currentToken.node, "}") {
+		return true
+	}
+	// Statements start on a new line. The braces of blocks are their own
+	// statements but their placement is decided by the brace rules above.
+	statementType := currentToken.statementNode.Type()
+	if statementType == "{" || statementType == "}" ||
+		isSameNode(prevToken.statementNode, currentToken.statementNode) ||
+		currentToken.startByte != currentToken.statementNode.StartByte() {
+		return false
+	}
+	if isBlockStatementNode(currentToken.statementNode) {
+		return true
+	}
+	// The statements of a case are on the lines after the label, except for
+	// a block which opens on the line of the label, "case 1: {".
+	caseNode := currentToken.statementNode.Parent()
+	return caseNode != nil && caseNode.Type() == "case_statement" &&
+		isCaseBodyNode(caseNode, currentToken.statementNode) &&
+		!(prevToken.node.Type() == ":" && statementType == "compound_statement")
+}
+
+// Get the canonical spacing between two tokens on the same line.
+func getSpacing(prevToken, currentToken token, sourceCode []byte, original string, style Style) string {
+	prevNode := prevToken.node
+	currentNode := currentToken.node
+	prevType := prevNode.Type()
+	currentType := currentNode.Type()
+	switch {
+	case prevType == "comment" || currentType == "comment":
+		if original == "" {
+			return " "
 		}
+		return original
+	case prevType == "(" || prevType == "[" || currentType == ")" || currentType == "]":
+		return ""
+	case currentType == "," || currentType == ";" || currentType == ":":
+		return ""
+	case prevType == "," || prevType == ";":
+		return " "
+	case isBinaryOperator(prevNode) || isBinaryOperator(currentNode):
+		// Operators are spaced unless the style profile decides otherwise,
+		// "a - -1" is not joined into "a--1".
+		if style.Name != "" && !style.SpaceAroundOperators && !isJoinedOperator(prevNode.Content(sourceCode), currentNode.Content(sourceCode)) {
+			return ""
+		}
+		return " "
+	case currentType == "(":
+		switch currentNode.Parent().Type() {
+		case "argument_list", "parameter_list":
+			return ""
+		}
+		return original
+	case currentType == "[":
+		return ""
+	case currentType == "{":
+		return " "
+	case prevType == "{" || currentType == "}":
+		return ""
+	case prevType == "}" && currentType == "else", prevType == "else":
+		return " "
+	case isUpdateOperator(prevNode) || isUpdateOperator(currentNode):
+		return ""
+	case isPrefixOperator(prevNode):
+		return ""
+	case isWordEnd(prevNode.Content(sourceCode)) && isWordStart(currentNode.Content(sourceCode)):
+		return " "
+	case isPrefixOperator(currentNode) && isWordEnd(prevNode.Content(sourceCode)):
+		return " "
 	}
-	return result
+	// Parentheses after keywords keep the spacing they were written with
+	// unless the style profile decides it.
+	return original
 }
 
-// Get formatting edits for the spacing in between parameters. For example,
-// The spacing of "Integer a," and "Integer b" "void Foo(Integer a, Integer b)".
-// This function will return the edits so that the number of spaces between the
-// comma after "a" and "Integer" is exactly 1.
-func formatParamSpacing(currentNode, prevNode *sitter.Node) []protocol.TextEdit {
-	var result []protocol.TextEdit
-	currentTypeNode := currentNode.ChildByFieldName("type")
-	prevDeclaratorEndCol := int(prevNode.ChildByFieldName("declarator").EndPoint().Column)
-	currentTypeNodeStartCol := int(currentTypeNode.StartPoint().Column)
-	if currentTypeNodeStartCol-prevDeclaratorEndCol == 1 {
-		result = append(
-			result,
-			protocol.TextEdit{
-				Range: protocol.Range{
-					Start: protocol.Position{
-						Line:      uint(currentTypeNode.StartPoint().Row),
-						Character: uint(prevDeclaratorEndCol + 1),
-					},
-					End: protocol.Position{
-						Line:      uint(currentTypeNode.StartPoint().Row),
-						Character: uint(prevDeclaratorEndCol + 1),
-					},
-				},
-				NewText: " ",
-			},
-		)
-	}
-	if currentTypeNodeStartCol-prevDeclaratorEndCol > 2 {
-		result = append(
-			result,
-			protocol.TextEdit{
-				Range: protocol.Range{
-					Start: protocol.Position{
-						Line:      uint(currentTypeNode.StartPoint().Row),
-						Character: uint(prevDeclaratorEndCol + 1),
-					},
-					End: protocol.Position{
-						Line:      uint(currentTypeNode.StartPoint().Row),
-						Character: uint(currentTypeNode.StartPoint().Column),
-					},
-				},
-				NewText: " ",
-			},
-		)
+// Returns true if the texts written next to each other without a space would
+// read as a different operator, "-" and "-1" read as "--".
+func isJoinedOperator(prevText, currentText string) bool {
+	if prevText == "" || currentText == "" {
+		return false
 	}
-	return result
+	last := prevText[len(prevText)-1]
+	first := currentText[0]
+	return (last == '+' || last == '-') && last == first
+}
+
+// Returns true if the node is the brace of a block.
+func isBlockBrace(node *sitter.Node, brace string) bool {
+	if node.Type() != brace || node.Parent() == nil || node.Parent().Type() != "compound_statement" {
+		return false
+	}
+	block := node.Parent()
+	// Empty blocks can be on a single line, "{}".
+	return block.NamedChildCount() > 0
+}
+
+// Returns true if the node is the opening brace of the body of a function, a
+// control statement, a do while loop or the block of a case.
+func isBodyBrace(node *sitter.Node, sourceCode []byte) bool {
+	if node.Type() != "{" || node.Parent() == nil || node.Parent().Type() != "compound_statement" {
+		return false
+	}
+	block := node.Parent()
+	parent := block.Parent()
+	if parent == nil {
+		return false
+	}
+	switch parent.Type() {
+	case "function_definition":
+		return isSameNode(parent.ChildByFieldName("body"), block)
+	case "case_statement":
+		// The block directly after the ":", "case 1: {".
+		return block.PrevSibling() != nil && block.PrevSibling().Type() == ":"
+	}
+	// The parser does not know do while loops, the body is a block after the
+	// "do" keyword.
+	if isDoKeyword(block.PrevSibling(), sourceCode) {
+		return true
+	}
+	return isBodyNode(parent, block)
+}
+
+// Returns true if the node is the keyword of a control statement which is
+// followed by a parenthesis, "if", "while", "for" and "switch".
+func isControlKeyword(node *sitter.Node) bool {
+	switch node.Type() {
+	case "if", "while", "for", "switch":
+		return !node.IsNamed()
+	}
+	return false
+}
+
+// Returns true if the node is the operator of a binary expression, an
+// assignment or an initialisation.
+func isBinaryOperator(node *sitter.Node) bool {
+	parent := node.Parent()
+	if parent == nil {
+		return false
+	}
+	switch parent.Type() {
+	case "binary_expression", "assignment_expression":
+		return isSameNode(parent.ChildByFieldName("operator"), node)
+	case "init_declarator":
+		return node.Type() == "="
+	}
+	return false
+}
+
+// Returns true if the node is the while statement of a do while loop. The
+// grammar does not know about do while loops, "do" is parsed as an error or as
+// an expression statement missing its semicolon, followed by a block and a
+// while statement with an empty body.
+func isDoWhileStatement(node *sitter.Node, sourceCode []byte) bool {
+	if node == nil || node.Type() != "while_statement" {
+		return false
+	}
+	block := node.PrevSibling()
+	if block == nil || block.Type() != "compound_statement" {
+		return false
+	}
+	return isDoKeyword(block.PrevSibling(), sourceCode)
+}
+
+// Returns true if the node is the "do" keyword of a do while loop.
+func isDoKeyword(node *sitter.Node, sourceCode []byte) bool {
+	if node == nil || node.Content(sourceCode) != "do" {
+		return false
+	}
+	return node.IsError() || node.Type() == "expression_statement"
+}
+
+// Returns true if the node is a line comment, "// comment".
+func isLineComment(node *sitter.Node, sourceCode []byte) bool {
+	return node.Type() == "comment" && strings.HasPrefix(node.Content(sourceCode), "//")
 }
 
-// Get the indentation level of the start node.
-func getIndentLevel(startNode *sitter.Node) int {
+// Returns true if the node is the "++" or "--" operator of an update
+// expression.
+func isUpdateOperator(node *sitter.Node) bool {
+	parent := node.Parent()
+	return parent != nil && parent.Type() == "update_expression" && !isSameNode(parent.ChildByFieldName("argument"), node)
+}
+
+// Returns true if the node is the operator of a unary expression or the
+// reference of a reference parameter, for example "-" in "-1" and "&" in
+// "Integer &a".
+func isPrefixOperator(node *sitter.Node) bool {
+	parent := node.Parent()
+	if parent == nil || node.IsNamed() {
+		return false
+	}
+	switch parent.Type() {
+	case "unary_expression", "pointer_declarator", "pointer_expression":
+		return isSameNode(parent.Child(0), node)
+	}
+	return false
+}
+
+func isWordStart(text string) bool {
+	return text != "" && isWordChar(text[0])
+}
+
+func isWordEnd(text string) bool {
+	return text != "" && isWordChar(text[len(text)-1])
+}
+
+func isWordChar(char byte) bool {
+	return char == '_' || char == '"' || char == '\'' ||
+		'a' <= char && char <= 'z' || 'A' <= char && char <= 'Z' || '0' <= char && char <= '9'
+}
+
+// Returns true if the node contains a list of statements.
+func isStatementListNode(node *sitter.Node) bool {
+	if node == nil {
+		return false
+	}
+	switch node.Type() {
+	case "source_file", "compound_statement", "case_statement":
+		return true
+	}
+	return false
+}
+
+// Returns true if the node is a statement of a block or a top level statement.
+// The statements of a case can share the line with the case label.
+func isBlockStatementNode(node *sitter.Node) bool {
+	parent := node.Parent()
+	return parent != nil && (parent.Type() == "source_file" || parent.Type() == "compound_statement")
+}
+
+// Get the statement which the node belongs to. A statement is a child of a
+// node containing a list of statements, braces of blocks are their own
+// statements.
+func getStatementNode(node *sitter.Node) *sitter.Node {
+	for currentNode := node; currentNode.Parent() != nil; currentNode = currentNode.Parent() {
+		if isStatementListNode(currentNode.Parent()) {
+			return currentNode
+		}
+	}
+	return node
+}
+
+// Get the indentation level of the node.
+func getIndentLevel(node *sitter.Node) int {
 	indentLevel := 0
-	currentNode := startNode.Parent()
-	for currentNode != nil {
-		if currentNode.Type() == "compound_statement" {
+	for currentNode := node; currentNode.Parent() != nil; currentNode = currentNode.Parent() {
+		if isIndentedNode(currentNode.Parent(), currentNode) {
 			indentLevel++
 		}
-		currentNode = currentNode.Parent()
 	}
 	return indentLevel
 }
 
-// Build the indentation text with the given length.
-func buildIndentText(length int) string {
-	sb := strings.Builder{}
-	for i := 0; i < length; i++ {
-		sb.WriteRune(' ')
-	}
-	newText := sb.String()
-	return newText
-}
-
-func indentCompoundStatementNode(currentNode *sitter.Node, targetIndentation int) []protocol.TextEdit {
-	var result []protocol.TextEdit
-	if currentNode.EndPoint().Row > currentNode.StartPoint().Row {
-		currentIndentation := currentNode.EndPoint().Column - 1
-		if targetIndentation != int(currentIndentation) {
-			if targetIndentation == 0 {
-				result = append(
-					result,
-					protocol.TextEdit{
-						Range: protocol.Range{
-							Start: protocol.Position{
-								Line:      uint(currentNode.EndPoint().Row),
-								Character: 0,
-							},
-							End: protocol.Position{
-								Line:      uint(currentNode.EndPoint().Row),
-								Character: uint(currentNode.EndPoint().Column) - 1,
-							},
-						},
-						NewText: "",
-					},
-				)
-			} else {
-				newText := buildIndentText(targetIndentation)
-				result = append(
-					result,
-					protocol.TextEdit{
-						Range: protocol.Range{
-							Start: protocol.Position{
-								Line:      uint(currentNode.EndPoint().Row),
-								Character: 0,
-							},
-							End: protocol.Position{
-								Line:      uint(currentNode.EndPoint().Row),
-								Character: uint(currentNode.EndPoint().Column) - 1,
-							},
-						},
-						NewText: newText,
-					},
-				)
+// Returns true if the child is indented one level more than its parent.
+func isIndentedNode(parent, child *sitter.Node) bool {
+	switch parent.Type() {
+	case "compound_statement":
+		return child.Type() != "{" && child.Type() != "}"
+	case "case_statement":
+		return isCaseBodyNode(parent, child) && child.Type() != "compound_statement"
+	}
+	// Blocks are indented through their statements.
+	return isBodyNode(parent, child) && child.Type() != "compound_statement"
+}
+
+// Returns true if the token is the first token on its line when the code is
+// formatted, tokens which are not continue the line before them and are
+// indented one more level.
+func isLineStart(t token) bool {
+	// Comments on their own line, such as banner comments between the
+	// signature of a function and its body, are not continuations unless they
+	// are inside of a list.
+	if t.node.Type() == "comment" {
+		switch t.node.Parent().Type() {
+		case "argument_list", "parameter_list":
+			return false
+		}
+		return true
+	}
+	for currentNode := t.node; currentNode.Parent() != nil && currentNode.StartByte() == t.startByte; currentNode = currentNode.Parent() {
+		parent := currentNode.Parent()
+		switch parent.Type() {
+		case "source_file", "compound_statement":
+			return true
+		case "case_statement":
+			if isSameNode(parent.Child(0), currentNode) || isCaseBodyNode(parent, currentNode) {
+				return true
 			}
 		}
+		if isBodyNode(parent, currentNode) {
+			return true
+		}
+		switch currentNode.Type() {
+		case "else", ")", "]":
+			return true
+		}
 	}
-	return result
+	return false
 }
 
-func indentNode(currentNode *sitter.Node, targetIndentation int) []protocol.TextEdit {
-	var result []protocol.TextEdit
-	currentIndentation := currentNode.StartPoint().Column
-	if targetIndentation != int(currentIndentation) {
-		newText := buildIndentText(targetIndentation)
-		result = append(
-			result,
-			protocol.TextEdit{
-				Range: protocol.Range{
-					Start: protocol.Position{
-						Line:      uint(currentNode.StartPoint().Row),
-						Character: 0,
-					},
-					End: protocol.Position{
-						Line:      uint(currentNode.StartPoint().Row),
-						Character: uint(currentNode.StartPoint().Column),
-					},
-				},
-				NewText: newText,
-			},
-		)
+// Returns true if the child is a statement of the case, "foo();" in
+// "case 1: foo();".
+func isCaseBodyNode(caseNode, child *sitter.Node) bool {
+	switch child.Type() {
+	case "case", "default", ":":
+		return false
 	}
-	return result
+	return !isSameNode(child, caseNode.ChildByFieldName("value"))
 }
 
-func GetCallExpressionEdits(node *sitter.Node, sourceCode []byte) []protocol.TextEdit {
-	result := []protocol.TextEdit{}
-	stack := parser.NewStack()
-	stack.Push(node)
-	for stack.HasItems() {
-		currentNode, _ := stack.Pop()
-		nodeType := currentNode.Type()
-		if nodeType == "call_expression" {
-			argsNode := currentNode.ChildByFieldName("arguments")
-			if int(argsNode.ChildCount()) == 2 && argsNode.Child(0).Content(sourceCode) == "(" && argsNode.Child(1).Content(sourceCode) == ")" {
-				openingParenNode := argsNode.Child(0)
-				closingParenNode := argsNode.Child(1)
-				result = append(
-					result,
-					protocol.TextEdit{
-						Range: protocol.Range{
-							Start: protocol.Position{
-								Line:      uint(openingParenNode.StartPoint().Row),
-								Character: uint(openingParenNode.EndPoint().Column),
-							},
-							End: protocol.Position{
-								Line:      uint(openingParenNode.StartPoint().Row),
-								Character: uint(closingParenNode.StartPoint().Column),
-							},
-						},
-						NewText: "",
-					},
-				)
-			}
-			var prevArgNode *sitter.Node
-			var prevSeparatorNode *sitter.Node
-			for i := 0; i < int(argsNode.ChildCount()); i++ {
-				currentNode := argsNode.Child(i)
-				isStartOrEndChar := currentNode.Content(sourceCode) == "(" || currentNode.Content(sourceCode) == ")"
-				if isStartOrEndChar {
-					continue
-				}
-				isSeparator := currentNode.Content(sourceCode) == ","
-				isFirstArg := prevArgNode == nil
-				if isSeparator {
-					separatorNode := currentNode
-					// Trim the space between the current separator node and
-					// the end of the previous argument.
-					if separatorNode.StartPoint().Column != prevArgNode.EndPoint().Column {
-						result = append(
-							result,
-							protocol.TextEdit{
-								Range: protocol.Range{
-									Start: protocol.Position{
-										Line:      uint(prevArgNode.StartPoint().Row),
-										Character: uint(prevArgNode.EndPoint().Column),
-									},
-									End: protocol.Position{
-										Line:      uint(prevArgNode.StartPoint().Row),
-										Character: uint(separatorNode.StartPoint().Column),
-									},
-								},
-								NewText: "",
-							},
-						)
-					}
-					prevSeparatorNode = currentNode
-					continue
-				}
-				numOpeningParenFirstArgSpaces := currentNode.StartPoint().Column-argsNode.Child(0).StartPoint().Column != 1
-				shouldTrimLeadingSpaceOfFirstArg := isFirstArg && numOpeningParenFirstArgSpaces
-				if shouldTrimLeadingSpaceOfFirstArg {
-					result = append(
-						result,
-						protocol.TextEdit{
-							Range: protocol.Range{
-								Start: protocol.Position{
-									Line:      uint(argsNode.Child(0).EndPoint().Row),
-									Character: uint(argsNode.Child(0).EndPoint().Column),
-								},
-								End: protocol.Position{
-									Line:      uint(argsNode.Child(0).EndPoint().Row),
-									Character: uint(currentNode.StartPoint().Column),
-								},
-							},
-							NewText: "",
-						},
-					)
-				}
-				if !isFirstArg && prevSeparatorNode != nil {
-					// Trim space between the start of the argument node and the
-					// previous separator node.
-					if currentNode.StartPoint().Column-prevSeparatorNode.StartPoint().Column != 2 {
-						result = append(
-							result,
-							protocol.TextEdit{
-								Range: protocol.Range{
-									Start: protocol.Position{
-										Line:      uint(prevArgNode.StartPoint().Row),
-										Character: uint(prevArgNode.EndPoint().Column) + 1,
-									},
-									End: protocol.Position{
-										Line:      uint(currentNode.StartPoint().Row),
-										Character: uint(currentNode.StartPoint().Column),
-									},
-								},
-								NewText: " ",
-							},
-						)
-					}
-				}
-				prevArgNode = currentNode
-			}
-		}
-		for i := 0; i < int(currentNode.ChildCount()); i++ {
-			stack.Push(currentNode.Child(i))
+// Returns true if the child is the body of a control statement. An if
+// statement following an "else" is part of an "else if" chain rather than the
+// body of the else.
+func isBodyNode(parent, child *sitter.Node) bool {
+	switch parent.Type() {
+	case "if_statement":
+		if isSameNode(child, parent.ChildByFieldName("consequence")) {
+			return true
 		}
+		return isSameNode(child, parent.ChildByFieldName("alternative")) && child.Type() != "if_statement"
+	case "while_statement", "switch_statement":
+		return isSameNode(child, parent.ChildByFieldName("body"))
+	case "for_statement":
+		lastChild := parent.Child(int(parent.ChildCount()) - 1)
+		return lastChild.Type() != ")" && isSameNode(child, lastChild)
 	}
-	return result
+	return false
+}
+
+// Returns true if both nodes are the same node, nil nodes are never the same.
+func isSameNode(node, other *sitter.Node) bool {
+	return node != nil && other != nil && node.Equal(other)
 }
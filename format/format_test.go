@@ -0,0 +1,132 @@
+package format_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/kelly-lin/12d-lang-server/format"
+	"github.com/kelly-lin/12d-lang-server/parser/12dpl"
+	"github.com/kelly-lin/12d-lang-server/protocol"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/stretchr/testify/assert"
+)
+
+// Formats each macro in the test data directory and checks that formatting
+// the result again does not change it.
+func TestIdempotency(t *testing.T) {
+	filepaths, err := filepath.Glob(filepath.Join("testdata", "*"))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, filepaths)
+	tabOpts := format.NewDefaultOptions()
+	tabOpts.InsertSpaces = false
+	finalNewlineOpts := format.NewDefaultOptions()
+	finalNewlineOpts.InsertFinalNewline = true
+	finalNewlineOpts.TrimFinalNewlines = true
+	optsByName := map[string]format.Options{
+		"default":       format.NewDefaultOptions(),
+		"tabs":          tabOpts,
+		"final newline": finalNewlineOpts,
+	}
+	for _, maxLineWidth := range []int{40, 80} {
+		opts := format.NewDefaultOptions()
+		opts.MaxLineWidth = maxLineWidth
+		optsByName[fmt.Sprintf("max line width %d", maxLineWidth)] = opts
+	}
+	alignOpts := format.NewDefaultOptions()
+	alignOpts.AlignConsecutive = true
+	optsByName["align consecutive"] = alignOpts
+	organizeOpts := format.NewDefaultOptions()
+	organizeOpts.OrganizeIncludes = true
+	optsByName["organize includes"] = organizeOpts
+	for _, name := range format.GetStyleNames() {
+		opts := format.NewDefaultOptions()
+		opts.Style, _ = format.GetStyle(name)
+		optsByName[name] = opts
+	}
+	for name, lineEnding := range map[string]string{"lf": format.LineEndingLF, "crlf": format.LineEndingCRLF} {
+		opts := format.NewDefaultOptions()
+		opts.MaxLineWidth = 40
+		opts.LineEnding = lineEnding
+		optsByName["line ending "+name] = opts
+	}
+	// Each macro is also formatted with Windows line endings.
+	toCRLF := func(sourceCode []byte) []byte {
+		lf := strings.ReplaceAll(string(sourceCode), "\r\n", "\n")
+		return []byte(strings.ReplaceAll(lf, "\n", "\r\n"))
+	}
+	for _, fp := range filepaths {
+		for name, opts := range optsByName {
+			for _, isCRLF := range []bool{false, true} {
+				desc := filepath.Base(fp) + " - " + name
+				if isCRLF {
+					desc += " - crlf source"
+				}
+				t.Run(desc, func(t *testing.T) {
+					assert := assert.New(t)
+					sourceCode, err := os.ReadFile(fp)
+					assert.NoError(err)
+					if isCRLF {
+						sourceCode = toCRLF(sourceCode)
+					}
+
+					formatted := format.Print(parse(t, sourceCode), sourceCode, opts)
+					edits := format.GetEdits(parse(t, sourceCode), sourceCode, opts)
+					assert.Equal(formatted, applyEdits(sourceCode, edits), "edits should produce the printed document")
+
+					reformatted := format.Print(parse(t, []byte(formatted)), []byte(formatted), opts)
+					assert.Equal(formatted, reformatted)
+					assert.Empty(format.GetEdits(parse(t, []byte(formatted)), []byte(formatted), opts))
+
+					// Line endings are kept as they were written unless they
+					// are normalized, the inserted line endings match them.
+					lineEnding := opts.LineEnding
+					if lineEnding == "" && isCRLF {
+						lineEnding = format.LineEndingCRLF
+					} else if lineEnding == "" && !strings.Contains(string(sourceCode), "\r") {
+						lineEnding = format.LineEndingLF
+					}
+					switch lineEnding {
+					case format.LineEndingCRLF:
+						assert.Equal(strings.Count(formatted, "\n"), strings.Count(formatted, "\r\n"))
+					case format.LineEndingLF:
+						assert.NotContains(formatted, "\r")
+					}
+				})
+			}
+		}
+	}
+}
+
+func parse(t *testing.T, sourceCode []byte) *sitter.Node {
+	rootNode, err := sitter.ParseCtx(context.Background(), sourceCode, parser.GetLanguage())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rootNode
+}
+
+// Applies the edits to the source code, the edits must not overlap.
+func applyEdits(sourceCode []byte, edits []protocol.TextEdit) string {
+	lines := strings.SplitAfter(string(sourceCode), "\n")
+	getOffset := func(pos protocol.Position) int {
+		offset := 0
+		for i := 0; i < int(pos.Line); i++ {
+			offset += len(lines[i])
+		}
+		return offset + int(pos.Character)
+	}
+	sortedEdits := append([]protocol.TextEdit{}, edits...)
+	sort.SliceStable(sortedEdits, func(i, j int) bool {
+		return getOffset(sortedEdits[i].Range.Start) > getOffset(sortedEdits[j].Range.Start)
+	})
+	result := string(sourceCode)
+	for _, edit := range sortedEdits {
+		result = result[:getOffset(edit.Range.Start)] + edit.NewText + result[getOffset(edit.Range.End):]
+	}
+	return result
+}
@@ -0,0 +1,88 @@
+package format
+
+import (
+	"strings"
+
+	"github.com/kelly-lin/12d-lang-server/protocol"
+)
+
+const defaultTabSize = 4
+
+// Line endings which documents can be normalized to.
+const (
+	LineEndingLF   = "\n"
+	LineEndingCRLF = "\r\n"
+)
+
+// Options which control how documents are formatted.
+type Options struct {
+	// Number of spaces in an indentation level when indenting with spaces.
+	TabSize int
+	// Indent with spaces instead of tabs.
+	InsertSpaces bool
+	// Trim trailing whitespace on a line.
+	TrimTrailingWhitespace bool
+	// Insert a newline character at the end of the file if one does not exist.
+	InsertFinalNewline bool
+	// Trim all newlines after the final newline at the end of the file.
+	TrimFinalNewlines bool
+	// Style profile for brace placement and spacing.
+	Style Style
+	// Maximum width of a line, argument and parameter lists which do not fit
+	// are broken one item per line. Zero keeps the line breaks of lists as
+	// they were written.
+	MaxLineWidth int
+	// Align the identifiers, assignment operators and trailing comments of
+	// consecutive declarations and assignments.
+	AlignConsecutive bool
+	// Line ending which all line endings are converted to, LineEndingLF or
+	// LineEndingCRLF. When empty, line endings are kept as they were written
+	// and inserted lines end with the line ending of the first line.
+	LineEnding string
+	// Organize consecutive include and define lines, see GetOrganizeEdits.
+	OrganizeIncludes bool
+	// What is known about the includes when organizing them.
+	IncludeInfo IncludeInfo
+}
+
+// Creates the default formatting options, four spaces for indentation and
+// trimming trailing whitespace.
+func NewDefaultOptions() Options {
+	return Options{
+		TabSize:                defaultTabSize,
+		InsertSpaces:           true,
+		TrimTrailingWhitespace: true,
+	}
+}
+
+// Creates the formatting options from the formatting options of a formatting
+// request. Trailing whitespace is trimmed unless the client explicitly asks
+// not to.
+func NewOptions(options protocol.FormattingOptions) Options {
+	result := NewDefaultOptions()
+	if options.TabSize > 0 {
+		result.TabSize = int(options.TabSize)
+	}
+	result.InsertSpaces = options.InsertSpaces
+	if options.TrimTrailingWhitespace != nil {
+		result.TrimTrailingWhitespace = *options.TrimTrailingWhitespace
+	}
+	if options.InsertFinalNewline != nil {
+		result.InsertFinalNewline = *options.InsertFinalNewline
+	}
+	if options.TrimFinalNewlines != nil {
+		result.TrimFinalNewlines = *options.TrimFinalNewlines
+	}
+	return result
+}
+
+// Build the indentation text for the indentation level.
+func (o Options) indentText(level int) string {
+	if level <= 0 {
+		return ""
+	}
+	if !o.InsertSpaces {
+		return strings.Repeat("\t", level)
+	}
+	return strings.Repeat(" ", level*o.TabSize)
+}
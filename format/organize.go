@@ -0,0 +1,257 @@
+package format
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/kelly-lin/12d-lang-server/protocol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// What is known about the includes of a document from the documents they
+// resolve to. Includes are identified by their path as it is written including
+// the quotes or angle brackets, `"set_ups.h"` in `#include "set_ups.h"`.
+// Includes in quotes and angle brackets are searched for in different places
+// so they are different includes.
+type IncludeInfo struct {
+	// Includes which no symbol of the document is referenced from.
+	Unused map[string]bool
+	// Includes which each include depends on, an include is kept after the
+	// includes it depends on.
+	Dependencies map[string][]string
+}
+
+// A run of consecutive include and define lines at the top level of the
+// document.
+type directiveBlock struct {
+	nodes []*sitter.Node
+	// Organized text of the block without the line ending of the last line.
+	text string
+}
+
+// Get the edits which organize the includes and defines of the document.
+// Consecutive includes are sorted, duplicate and unused includes are removed
+// and the defines after them are grouped after an empty line. Defines before
+// an include are kept in place.
+func GetOrganizeEdits(rootNode *sitter.Node, sourceCode []byte, opts Options) []protocol.TextEdit {
+	result := []protocol.TextEdit{}
+	lineEnding := opts.LineEnding
+	if lineEnding == "" {
+		lineEnding = getLineEnding(sourceCode)
+	}
+	lineStartBytes := getLineStartBytes(sourceCode)
+	for _, block := range getDirectiveBlocks(rootNode, sourceCode, lineEnding, opts.IncludeInfo) {
+		startByte, endByte := block.getByteRange(sourceCode)
+		if string(sourceCode[startByte:endByte]) == block.text {
+			continue
+		}
+		// The line of a block whose lines have all been removed is removed
+		// with it.
+		if block.text == "" {
+			endByte = getNextLineStartByte(sourceCode, endByte)
+		}
+		result = append(result, protocol.TextEdit{
+			Range:   getRange(lineStartBytes, int(startByte), int(endByte)),
+			NewText: block.text,
+		})
+	}
+	return result
+}
+
+// Get the blocks of consecutive include and define lines of the document.
+func getDirectiveBlocks(rootNode *sitter.Node, sourceCode []byte, lineEnding string, info IncludeInfo) []directiveBlock {
+	result := []directiveBlock{}
+	nodes := []*sitter.Node{}
+	addBlock := func() {
+		if len(nodes) > 0 {
+			result = append(result, directiveBlock{nodes: nodes, text: getOrganizedText(nodes, sourceCode, lineEnding, info)})
+		}
+		nodes = []*sitter.Node{}
+	}
+	for i := 0; i < int(rootNode.NamedChildCount()); i++ {
+		node := rootNode.NamedChild(i)
+		if node.Type() != "preproc_include" && node.Type() != "preproc_def" {
+			addBlock()
+			continue
+		}
+		if len(nodes) > 0 && !isNextLine(nodes[len(nodes)-1], node, sourceCode) {
+			addBlock()
+		}
+		nodes = append(nodes, node)
+	}
+	addBlock()
+	return result
+}
+
+// Returns true if the node starts on the line after the previous node ends.
+func isNextLine(prevNode, node *sitter.Node, sourceCode []byte) bool {
+	between := string(sourceCode[getTrimmedEndByte(prevNode, sourceCode):node.StartByte()])
+	return strings.Count(between, "\n") == 1 && strings.TrimSpace(between) == ""
+}
+
+// Get the end byte of the node without the whitespace at its end, directives
+// end after the line ending.
+func getTrimmedEndByte(node *sitter.Node, sourceCode []byte) uint32 {
+	endByte := node.EndByte()
+	for endByte > node.StartByte() && isWhitespace(sourceCode[endByte-1]) {
+		endByte--
+	}
+	return endByte
+}
+
+// Get the byte offset of the start of the line after the byte offset.
+func getNextLineStartByte(sourceCode []byte, offset uint32) uint32 {
+	for int(offset) < len(sourceCode) {
+		offset++
+		if sourceCode[offset-1] == '\n' {
+			break
+		}
+	}
+	return offset
+}
+
+// Get the byte range of the text of the block.
+func (b directiveBlock) getByteRange(sourceCode []byte) (uint32, uint32) {
+	return b.nodes[0].StartByte(), getTrimmedEndByte(b.nodes[len(b.nodes)-1], sourceCode)
+}
+
+// Get the organized text of the lines. Defines before an include stay where
+// they are since they can configure the header, the includes between them are
+// organized. The defines after the last include are grouped after the
+// includes, separated from them by an empty line.
+func getOrganizedText(nodes []*sitter.Node, sourceCode []byte, lineEnding string, info IncludeInfo) string {
+	getText := func(node *sitter.Node) string {
+		return string(sourceCode[node.StartByte():getTrimmedEndByte(node, sourceCode)])
+	}
+	lines := []string{}
+	includes := []*sitter.Node{}
+	defines := []string{}
+	for _, node := range removeIncludes(nodes, sourceCode, info) {
+		if node.Type() != "preproc_include" {
+			defines = append(defines, getText(node))
+			continue
+		}
+		if len(defines) > 0 {
+			for _, includeNode := range organizeIncludes(includes, sourceCode, info) {
+				lines = append(lines, getText(includeNode))
+			}
+			lines = append(lines, defines...)
+			includes = []*sitter.Node{}
+			defines = []string{}
+		}
+		includes = append(includes, node)
+	}
+	for _, includeNode := range organizeIncludes(includes, sourceCode, info) {
+		lines = append(lines, getText(includeNode))
+	}
+	if len(includes) > 0 && len(defines) > 0 {
+		lines = append(lines, "")
+	}
+	lines = removeDuplicateLines(append(lines, defines...))
+	// The defines after the includes were all duplicates.
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, lineEnding)
+}
+
+// Remove the lines which are the same as a line before them.
+func removeDuplicateLines(lines []string) []string {
+	result := []string{}
+	seen := map[string]bool{}
+	for _, line := range lines {
+		if seen[line] {
+			continue
+		}
+		seen[line] = true
+		result = append(result, line)
+	}
+	return result
+}
+
+// Remove the unused includes and the includes which are included before them
+// from the nodes.
+func removeIncludes(nodes []*sitter.Node, sourceCode []byte, info IncludeInfo) []*sitter.Node {
+	result := []*sitter.Node{}
+	isIncluded := map[string]bool{}
+	for _, node := range nodes {
+		if node.Type() == "preproc_include" {
+			path := GetIncludePath(node, sourceCode)
+			if isIncluded[path] || info.Unused[path] {
+				continue
+			}
+			isIncluded[path] = true
+		}
+		result = append(result, node)
+	}
+	return result
+}
+
+// Sort the includes by path, the includes which an include depends on are
+// kept before it.
+func organizeIncludes(includeNodes []*sitter.Node, sourceCode []byte, info IncludeInfo) []*sitter.Node {
+	nodesByPath := map[string]*sitter.Node{}
+	paths := []string{}
+	for _, node := range includeNodes {
+		path := GetIncludePath(node, sourceCode)
+		nodesByPath[path] = node
+		paths = append(paths, path)
+	}
+	sort.SliceStable(paths, func(i, j int) bool {
+		return getIncludeSortKey(paths[i]) < getIncludeSortKey(paths[j])
+	})
+
+	// Place the first include in sorted order whose dependencies in the block
+	// have all been placed. Includes in a dependency cycle keep their sorted
+	// order.
+	result := []*sitter.Node{}
+	isPlaced := map[string]bool{}
+	for len(result) < len(paths) {
+		nextPath := ""
+		for _, path := range paths {
+			if !isPlaced[path] && isIncludeReady(path, info, nodesByPath, isPlaced) {
+				nextPath = path
+				break
+			}
+		}
+		if nextPath == "" {
+			for _, path := range paths {
+				if !isPlaced[path] {
+					nextPath = path
+					break
+				}
+			}
+		}
+		isPlaced[nextPath] = true
+		result = append(result, nodesByPath[nextPath])
+	}
+	return result
+}
+
+// Get the key which the include path is sorted by, the path without the quotes
+// or angle brackets in lower case.
+func getIncludeSortKey(path string) string {
+	return strings.ToLower(strings.Trim(path, `"<>`))
+}
+
+// Returns true if all of the includes of the block which the include depends
+// on have been placed.
+func isIncludeReady(path string, info IncludeInfo, nodesByPath map[string]*sitter.Node, isPlaced map[string]bool) bool {
+	for _, dependency := range info.Dependencies[path] {
+		if _, ok := nodesByPath[dependency]; ok && dependency != path && !isPlaced[dependency] {
+			return false
+		}
+	}
+	return true
+}
+
+// Get the path of the include as it is written including the quotes or angle
+// brackets, for example "set_ups.h" or <set_ups.h>. Includes without a path
+// have the text of the whole include as their path.
+func GetIncludePath(includeNode *sitter.Node, sourceCode []byte) string {
+	pathNode := includeNode.ChildByFieldName("path")
+	if pathNode == nil {
+		return includeNode.Content(sourceCode)
+	}
+	return pathNode.Content(sourceCode)
+}
@@ -0,0 +1,189 @@
+package format_test
+
+import (
+	"testing"
+
+	"github.com/kelly-lin/12d-lang-server/format"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrganize(t *testing.T) {
+	type TestCase struct {
+		Desc        string
+		SourceCode  string
+		IncludeInfo format.IncludeInfo
+		Want        string
+	}
+	testCases := []TestCase{
+		{
+			Desc: "sort includes",
+			SourceCode: `#include "strings.h"
+#include "set_ups.h"
+#include "Models.H"
+void main() {}
+`,
+			Want: `#include "Models.H"
+#include "set_ups.h"
+#include "strings.h"
+void main() {}
+`,
+		},
+		{
+			Desc: "remove duplicate includes",
+			SourceCode: `#include "set_ups.h"
+#include "strings.h"
+#include "set_ups.h"
+`,
+			Want: `#include "set_ups.h"
+#include "strings.h"
+`,
+		},
+		{
+			Desc: "includes in quotes and angle brackets are different includes",
+			SourceCode: `#include <set_ups.h>
+#include "strings.h"
+#include "set_ups.h"
+`,
+			Want: `#include <set_ups.h>
+#include "set_ups.h"
+#include "strings.h"
+`,
+		},
+		{
+			Desc: "remove unused includes",
+			SourceCode: `#include "set_ups.h"
+#include "strings.h"
+void main() {}
+`,
+			IncludeInfo: format.IncludeInfo{Unused: map[string]bool{`"strings.h"`: true}},
+			Want: `#include "set_ups.h"
+void main() {}
+`,
+		},
+		{
+			Desc: "remove line of block with only unused includes",
+			SourceCode: `#include "strings.h"
+void main() {}
+`,
+			IncludeInfo: format.IncludeInfo{Unused: map[string]bool{`"strings.h"`: true}},
+			Want: `void main() {}
+`,
+		},
+		{
+			Desc: "keep include after the include it depends on",
+			SourceCode: `#include "set_ups.h"
+#include "helpers.h"
+#include "ask.h"
+`,
+			IncludeInfo: format.IncludeInfo{Dependencies: map[string][]string{`"ask.h"`: {`"set_ups.h"`}}},
+			Want: `#include "helpers.h"
+#include "set_ups.h"
+#include "ask.h"
+`,
+		},
+		{
+			Desc: "keep defines before the includes after them",
+			SourceCode: `#define DEBUG 1
+#include "strings.h"
+#include "models.h"
+#define SCALE 2
+#include "set_ups.h"
+#include "b.h"
+#define MAX_NAME 32
+#define SCALE 2
+`,
+			Want: `#define DEBUG 1
+#include "models.h"
+#include "strings.h"
+#define SCALE 2
+#include "b.h"
+#include "set_ups.h"
+
+#define MAX_NAME 32
+`,
+		},
+		{
+			Desc: "group defines after the includes",
+			SourceCode: `#include "strings.h"
+#include "set_ups.h"
+#define MAX_NAME 32
+#define SCALE 2
+#define MAX_NAME 32
+`,
+			Want: `#include "set_ups.h"
+#include "strings.h"
+
+#define MAX_NAME 32
+#define SCALE 2
+`,
+		},
+		{
+			Desc: "group defines before a removed include after the includes",
+			SourceCode: `#include "set_ups.h"
+#define SCALE 2
+#include "unused.h"
+`,
+			IncludeInfo: format.IncludeInfo{Unused: map[string]bool{`"unused.h"`: true}},
+			Want: `#include "set_ups.h"
+
+#define SCALE 2
+`,
+		},
+		{
+			Desc: "remove include already included before a define",
+			SourceCode: `#include "a.h"
+#define SCALE 2
+#include "b.h"
+#include "a.h"
+`,
+			Want: `#include "a.h"
+#define SCALE 2
+#include "b.h"
+`,
+		},
+		{
+			Desc: "blocks are separated by blank lines",
+			SourceCode: `#include "strings.h"
+
+#include "set_ups.h"
+`,
+			Want: `#include "strings.h"
+
+#include "set_ups.h"
+`,
+		},
+		{
+			Desc: "trailing comment moves with include",
+			SourceCode: `#include "strings.h" // Text helpers.
+#include "set_ups.h"
+`,
+			Want: `#include "set_ups.h"
+#include "strings.h" // Text helpers.
+`,
+		},
+		{
+			Desc:       "crlf line endings",
+			SourceCode: "#include \"strings.h\"\r\n#include \"set_ups.h\"\r\n",
+			Want:       "#include \"set_ups.h\"\r\n#include \"strings.h\"\r\n",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.Desc, func(t *testing.T) {
+			assert := assert.New(t)
+			sourceCode := []byte(testCase.SourceCode)
+			opts := format.NewDefaultOptions()
+			opts.OrganizeIncludes = true
+			opts.IncludeInfo = testCase.IncludeInfo
+
+			got := format.Print(parse(t, sourceCode), sourceCode, opts)
+			assert.Equal(testCase.Want, got)
+			edits := format.GetEdits(parse(t, sourceCode), sourceCode, opts)
+			assert.Equal(testCase.Want, applyEdits(sourceCode, edits))
+			assert.Empty(format.GetEdits(parse(t, []byte(got)), []byte(got), opts))
+
+			// Organizing on its own leaves the rest of the document alone.
+			organizeEdits := format.GetOrganizeEdits(parse(t, sourceCode), sourceCode, opts)
+			assert.Equal(testCase.Want, applyEdits(sourceCode, organizeEdits))
+		})
+	}
+}
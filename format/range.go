@@ -0,0 +1,163 @@
+package format
+
+import (
+	"strings"
+
+	"github.com/kelly-lin/12d-lang-server/parser/12dpl"
+	"github.com/kelly-lin/12d-lang-server/protocol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Get the formatting edits for the provided range. The range is expanded to
+// the lines of the statements enclosing the start and end of the range so
+// that partially selected statements are formatted as a whole.
+func GetRangeEdits(rootNode *sitter.Node, sourceCode []byte, r protocol.Range, opts Options) []protocol.TextEdit {
+	startLine := r.Start.Line
+	endLine := r.End.Line
+	if startNode := getEnclosingStatementNode(rootNode, startLine); startNode != nil {
+		startLine = uint(startNode.StartPoint().Row)
+	}
+	if endNode := getEnclosingStatementNode(rootNode, endLine); endNode != nil {
+		endLine = uint(endNode.EndPoint().Row)
+	}
+	return filterEditsByLines(GetEdits(rootNode, sourceCode, opts), startLine, endLine)
+}
+
+// Get the formatting edits after the character ch has been typed at the
+// position. The block enclosing the position is re-indented and when the
+// character is a newline, the new line is indented to the level of the block.
+func GetOnTypeEdits(rootNode *sitter.Node, sourceCode []byte, position protocol.Position, ch string, opts Options) []protocol.TextEdit {
+	result := []protocol.TextEdit{}
+	blockNode := getEnclosingBlockNode(rootNode, position)
+	// When we are not inside of a block, for example we have just typed the
+	// closing brace of a function, format the statement on the line instead.
+	contextNode := blockNode
+	if contextNode == nil {
+		contextNode = getEnclosingStatementNode(rootNode, position.Line)
+	}
+	if contextNode != nil {
+		result = append(
+			result,
+			filterEditsByLines(
+				GetEdits(rootNode, sourceCode, opts),
+				uint(contextNode.StartPoint().Row),
+				uint(contextNode.EndPoint().Row),
+			)...,
+		)
+	}
+	if ch != "\n" {
+		return result
+	}
+
+	lines := strings.Split(string(sourceCode), "\n")
+	if int(position.Line) >= len(lines) {
+		return result
+	}
+	line := strings.TrimSuffix(lines[position.Line], "\r")
+	// Only indent blank lines, lines with content are indented through the
+	// block edits.
+	if strings.TrimSpace(line) != "" {
+		return result
+	}
+	indentLevel := 0
+	if blockNode != nil {
+		indentLevel = getIndentLevel(blockNode) + 1
+	}
+	// The blank line is trimmed by the block edits, the cursor is on it so we
+	// indent it instead.
+	result = filterEditsOutsideLine(result, position.Line)
+	targetIndentation := opts.indentText(indentLevel)
+	if line != targetIndentation {
+		result = append(
+			result,
+			protocol.TextEdit{
+				Range: protocol.Range{
+					Start: protocol.Position{Line: position.Line, Character: 0},
+					End:   protocol.Position{Line: position.Line, Character: uint(len(line))},
+				},
+				NewText: targetIndentation,
+			},
+		)
+	}
+	return result
+}
+
+// Keep only the edits which are on or between the start and end lines.
+func filterEditsByLines(edits []protocol.TextEdit, startLine, endLine uint) []protocol.TextEdit {
+	result := []protocol.TextEdit{}
+	for _, edit := range edits {
+		if edit.Range.Start.Line >= startLine && edit.Range.End.Line <= endLine {
+			result = append(result, edit)
+		}
+	}
+	return result
+}
+
+// Keep only the edits which do not touch the line.
+func filterEditsOutsideLine(edits []protocol.TextEdit, line uint) []protocol.TextEdit {
+	result := []protocol.TextEdit{}
+	for _, edit := range edits {
+		if edit.Range.End.Line < line || edit.Range.Start.Line > line {
+			result = append(result, edit)
+		}
+	}
+	return result
+}
+
+// Get the deepest statement node which contains the line. Statement nodes are
+// the direct children of the source file, blocks and case statements. Returns
+// nil if the line is not inside of a statement.
+func getEnclosingStatementNode(rootNode *sitter.Node, line uint) *sitter.Node {
+	var result *sitter.Node
+	currentNode := rootNode
+	for currentNode != nil {
+		var nextNode *sitter.Node
+		for i := 0; i < int(currentNode.NamedChildCount()); i++ {
+			child := currentNode.NamedChild(i)
+			if uint(child.StartPoint().Row) <= line && line <= uint(child.EndPoint().Row) {
+				nextNode = child
+				break
+			}
+		}
+		if nextNode == nil {
+			break
+		}
+		switch currentNode.Type() {
+		case "source_file", "compound_statement", "case_statement":
+			result = nextNode
+		}
+		currentNode = nextNode
+	}
+	return result
+}
+
+// Get the innermost block (compound statement) which contains the position.
+// Returns nil if the position is not inside of a block.
+func getEnclosingBlockNode(rootNode *sitter.Node, position protocol.Position) *sitter.Node {
+	var result *sitter.Node
+	stack := parser.NewStack()
+	stack.Push(rootNode)
+	for stack.HasItems() {
+		currentNode, _ := stack.Pop()
+		if !containsPosition(currentNode, position) {
+			continue
+		}
+		if currentNode.Type() == "compound_statement" {
+			result = currentNode
+		}
+		for i := 0; i < int(currentNode.ChildCount()); i++ {
+			stack.Push(currentNode.Child(i))
+		}
+	}
+	return result
+}
+
+// Returns true if the position is strictly between the start and end of the
+// node.
+func containsPosition(node *sitter.Node, position protocol.Position) bool {
+	isAfterStart := uint(node.StartPoint().Row) < position.Line ||
+		uint(node.StartPoint().Row) == position.Line && uint(node.StartPoint().Column) < position.Character
+	isBeforeEnd := position.Line < uint(node.EndPoint().Row) ||
+		position.Line == uint(node.EndPoint().Row) && position.Character < uint(node.EndPoint().Column)
+	return isAfterStart && isBeforeEnd
+}
@@ -0,0 +1,74 @@
+package format
+
+import "sort"
+
+// A style profile decides where braces go, the spacing after keywords and
+// around operators. The zero value keeps the brace placement and keyword
+// spacing the code was written with and spaces the operators.
+type Style struct {
+	// Name of the profile, empty for the zero value.
+	Name string
+	// Put the opening brace of function bodies on its own line.
+	FuncBraceOnNewLine bool
+	// Put the opening brace of "if", "else", loop, "switch" and case block
+	// bodies on its own line.
+	ControlBraceOnNewLine bool
+	// Put "else" on the same line as the closing brace before it, "} else {".
+	CuddleElse bool
+	// Put a space between "if", "while", "for" and "switch" and their
+	// opening parenthesis, "if (".
+	SpaceAfterKeyword bool
+	// Put a space around binary and assignment operators, "a = b + 1".
+	SpaceAroundOperators bool
+}
+
+var (
+	// Opening braces on the same line as the statement for functions and
+	// control statements.
+	StyleKR = Style{
+		Name:                 "k&r",
+		CuddleElse:           true,
+		SpaceAfterKeyword:    true,
+		SpaceAroundOperators: true,
+	}
+	// Opening braces and "else" on their own lines.
+	StyleAllman = Style{
+		Name:                  "allman",
+		FuncBraceOnNewLine:    true,
+		ControlBraceOnNewLine: true,
+		SpaceAfterKeyword:     true,
+		SpaceAroundOperators:  true,
+	}
+	// The style of the headers which ship with 12d, for example "set_ups.h".
+	// Function braces are on their own line, usually after banner comments,
+	// keywords are not followed by a space, "if(", and operators are not
+	// surrounded by spaces, "a=b+1".
+	Style12dStock = Style{
+		Name:               "12d-stock",
+		FuncBraceOnNewLine: true,
+		CuddleElse:         true,
+	}
+)
+
+var styles = map[string]Style{
+	StyleKR.Name:       StyleKR,
+	StyleAllman.Name:   StyleAllman,
+	Style12dStock.Name: Style12dStock,
+}
+
+// Get the style profile with the name. Returns false if there is no profile
+// with the name.
+func GetStyle(name string) (Style, bool) {
+	style, ok := styles[name]
+	return style, ok
+}
+
+// Get the names of the style profiles in alphabetical order.
+func GetStyleNames() []string {
+	result := []string{}
+	for name := range styles {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,32 @@
+#ifndef HELPERS_H
+#define HELPERS_H
+
+// Clamps the value between the minimum and maximum.
+Real Clamp(Real value, Real min, Real max) {
+    if (value < min) {
+        return min;
+    }
+    if (value > max)
+        return max;
+    return value;
+}
+
+// Sums the values of the array.
+Real Sum(Real values[], Integer num_values) {
+    Real total = 0;
+    Integer i = 1;
+    while (i <= num_values) {
+        total += values[i];
+        i++;
+    }
+    return total;
+}
+
+/* Swaps a and b. */
+void Swap(Integer &a, Integer &b) {
+    Integer tmp = a;
+    a = b;
+    b = tmp;
+}
+
+#endif
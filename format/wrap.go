@@ -0,0 +1,133 @@
+package format
+
+import (
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Breaks the argument and parameter lists which do not fit in the maximum line
+// width one item per line. Lists are wrapped outermost first, one at a time,
+// until every line fits or there are no more lists on the lines which do not
+// fit.
+func (p *printer) wrapLists() {
+	listTokenIdxs := p.getWrappableListTokenIdxs()
+	if len(listTokenIdxs) == 0 {
+		return
+	}
+	for {
+		text, tokenOffsets := p.render()
+		listNode := p.findListToWrap(text, tokenOffsets, listTokenIdxs)
+		if listNode == nil {
+			return
+		}
+		p.wrappedLists[listNode.StartByte()] = true
+	}
+}
+
+// Get the indexes of the opening parenthesis tokens of the lists which can be
+// wrapped.
+func (p *printer) getWrappableListTokenIdxs() []int {
+	result := []int{}
+	for idx, t := range p.tokens {
+		if t.node.Type() == "(" && isWrappableListNode(t.node.Parent()) && !t.isError {
+			result = append(result, idx)
+		}
+	}
+	return result
+}
+
+// Returns true if the node is an argument or parameter list with items which
+// can be moved between lines. Lists with comments are left alone since moving
+// the items would move the comments away from what they describe.
+func isWrappableListNode(node *sitter.Node) bool {
+	if node == nil || !isListNode(node) || node.HasError() || node.NamedChildCount() == 0 {
+		return false
+	}
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		if node.NamedChild(i).Type() == "comment" {
+			return false
+		}
+	}
+	return true
+}
+
+func isListNode(node *sitter.Node) bool {
+	return node.Type() == "argument_list" || node.Type() == "parameter_list"
+}
+
+// Find the first list which is not wrapped and starts on a line which is
+// longer than the maximum line width. Returns nil if there is no such list.
+func (p *printer) findListToWrap(text string, tokenOffsets []int, listTokenIdxs []int) *sitter.Node {
+	lineStartOffset := 0
+	for _, line := range strings.Split(text, "\n") {
+		lineEndOffset := lineStartOffset + len(line)
+		if p.getLineWidth(line) > p.opts.MaxLineWidth {
+			for _, tokenIdx := range listTokenIdxs {
+				listNode := p.tokens[tokenIdx].node.Parent()
+				offset := tokenOffsets[tokenIdx]
+				if lineStartOffset <= offset && offset < lineEndOffset && !p.wrappedLists[listNode.StartByte()] {
+					return listNode
+				}
+			}
+		}
+		lineStartOffset = lineEndOffset + 1
+	}
+	return nil
+}
+
+// Get the width of the line where a tab is as wide as the tab size.
+func (p *printer) getLineWidth(line string) int {
+	return len(strings.TrimRight(line, "\r")) + strings.Count(line, "\t")*(p.opts.TabSize-1)
+}
+
+// Get the whitespace inside of argument and parameter lists when we have a
+// maximum line width. Lists which fit are on a single line, lists which do
+// not have one item per line and the closing parenthesis after the last item.
+// Returns false if the whitespace is not between the items of a list.
+func (p *printer) getListGapText(prevToken, currentToken token) (string, bool) {
+	if p.opts.MaxLineWidth <= 0 {
+		return "", false
+	}
+	listNode := getListOfGap(prevToken.node, currentToken.node)
+	if listNode == nil || !isWrappableListNode(listNode) {
+		return "", false
+	}
+	isItemStart := prevToken.node.Type() == "(" || prevToken.node.Type() == ","
+	if p.wrappedLists[listNode.StartByte()] && isItemStart {
+		return p.lineEnding + p.opts.indentText(getIndentLevel(currentToken.node)+1+p.getNumWrappedLists(listNode)), true
+	}
+	return getSpacing(prevToken, currentToken, p.sourceCode, "", p.opts.Style), true
+}
+
+// Get the list which the whitespace between the tokens separates the items
+// of, the whitespace after the opening parenthesis, around the commas and
+// before the closing parenthesis. Returns nil if the whitespace is not between
+// the items of a list.
+func getListOfGap(prevNode, currentNode *sitter.Node) *sitter.Node {
+	switch {
+	case prevNode.Type() == "(" || prevNode.Type() == ",":
+		if parent := prevNode.Parent(); parent != nil && isListNode(parent) {
+			return parent
+		}
+	}
+	switch {
+	case currentNode.Type() == ")" || currentNode.Type() == ",":
+		if parent := currentNode.Parent(); parent != nil && isListNode(parent) {
+			return parent
+		}
+	}
+	return nil
+}
+
+// Get the number of wrapped lists which the list is inside of, each adds a
+// level of continuation indentation.
+func (p *printer) getNumWrappedLists(listNode *sitter.Node) int {
+	result := 0
+	for currentNode := listNode.Parent(); currentNode != nil; currentNode = currentNode.Parent() {
+		if isListNode(currentNode) && p.wrappedLists[currentNode.StartByte()] {
+			result++
+		}
+	}
+	return result
+}
@@ -683,7 +683,7 @@ var Lib = map[string][]string{
 		"```12dpl\nPanel Create_panel(Text title_text, Integer sizing_enable)\n```\n---\nPanels and Widgets Same as the above function, this function also creates a panel with the title title_text, but with anextra parameter sizing_enable to control the user resizing.  The resulting panel is resizable onlyif sizing_enable is not zero. The function return value is the created Panel.",
 	},
 	"Create_parameter_file": {
-		"```12dpl\nInteger Create_parameter_file(Plot_Parameter_File ppf, Text ppf_type)\n```\n---\nThe tin to be used by the apply manyThe mtf used by the apply manyThe separation between sectionsThe optional start chainage for the applymanyRealThe optional end chainage for the applymanyTextThe optional left prefix for template namesTextThe optional right prefix for template namesElementThe centreline / reference string to run theapply many downElementThe optional hinge stringTextThe optional report fileModel/Text The road strings model to be created by theapply manyModel/Text The road sections model to be created by theapply manyTextThe name of the colour for the road surfacestrings and sectionsModel/Text The optional model or name of a model forboxing strings for layer N (1 to 8)Model/ Text The optional model or name of a model forboxing sections for layer N (1 to 8)TextThe optional name of the colour for thestrings created for boxing layer N (1 to 8)Model/Text The optional model or name of a model fordifference sectionsTextThe name of the colour for differencesectionsModel/Text The optional model or name of a model forapply many polygonsModel/Text The optional model or name of a model forthe road boundary12d Model Macro_Functions\\u000cChaptercreate_arcsIntegerchord_arc_tolerancevolume_correctionRealIntegerpartial_interfacesIntegersections_as_4dIntegercopy_hingeIntegeruse_strippingshow_stripping_volumesIntegerIntegercalculate_natural_surface_to_design_volumescalculate_road_to_subgrade_volumecalculate_inter_boxing_layer_volumesmap_filecreate_road_tinroad_tinroad_tin_colourroad_tin_modelIntegerTextIntegerTin/TextTextModel/Textcreate_depth_range_polygonsIntegerdepth_range_fileTextdepth_range_polygons_modelModel/Textroad_tin_number_extra_modelsIntegerroad_tin_extra_model_NModel/Textcalculate_sight_distanceIntegersight_distance_minsight_distance_maxsight_distance_eye_heightsight_distance_eye_offsetRealRealRealRealWhat type of arcs to create 0 - no arcs1 - alignments 2 - polylines 3 - super stringsThe chord arc tolerance valueWhether or not to perform volume correction(0 or 1)Whether or not to create partial interfaces (0or 1)Whether or not to create sections as 4dstrings (0 or 1)Whether or not to copy the hinge string (0 or1)Whether or not to use stripping (0 or 1)Whether or not to show detailed strippingvolumes (0 or 1)Whether or not to calculate natural surface todesign volumes (0 or 1)Whether or not to calculate road to subgradevolumes (0 or 1)Whether or not to calculate inter boxinglayer volumes (0 or 1)The optional name of a map file to createWhether or not to create a tin (0 or 1)The tin or the name of the tin to createThe name of the colour for the created tinThe model or the name of the model tocreate the tin inWhether or not to create depth rangepolygons (0 or 1)The name of the depth range file to use whencreating depth range polygonsThe model or name of the model to createdepth range polygons inThe optional number of extra models for theroad tinThe model or name of the Nth model to beused as an extra model for the road tinWhether or not to calculate sight distances (0or 1)The minimum sight distanceThe maximum sight distanceThe eye height for the sight distance calcsThe eye offset for the sight distance calcssight_distance_target_heightsight_distance_target_offsetsight_distance_calc_intervalRealRealRealThe target height for the sight distance calcsThe target offset for the sight distance calcsThe calc interval for the sight distance calcsIntegerInteger12d Model Macro_Functions sight_distance_trial_intervalsight_distance_reportRealTextcreate_separation_barrier_linesIntegerbarrier_distancemin_barrier_road_lengthmin_barrier_line_lengthmin_barrier_betweenfilter_cross_sectionsRealRealRealRealIntegerfilter_sections_modelModel/Textfilter_sections_colourTextfilter_sections_intervalfilter_sections_toleranceRealRealfilter_sections_include_startIntegerfilter_sections_include_endIntegerfilter_sections_include_equalitiesfilter_sections_include_h_tangentfilter_sections_include_v_tangentfilter_sections_include_crest_sagfilter_sections_spc_fileIntegergenerate_long_section_plotIntegerlong_section_ppflong_section_plotter_typeTextTextlong_section_plot_stemlong_section_plot_cleanTextIntegergenerate_cross_section_plotIntegercross_section_ppfcross_section_plotter_typeTextTextcross_section_plot_stemcross_section_plot_cleanTextIntegercreate_tadpolesIntegerChaptertadpole_modeltadpole_intervaltadpole_search_widthtadpole_search_sideModel/TextRealRealIntegertadpole_counttadpole_N_string_1_nameIntegerTexttadpole_N_string_2_nameTexttadpole_N_start_chRealtadpole_N_end_chRealtadpole_N_symbol_1_nameTexttadpole_N_symbol_1_colourTexttadpole_N_symbol_1_sizeRealtadpole_N_symbol_1_rotationRealtadpole_N_symbol_1_offset_xRealtadpole_N_symbol_1_offset_yRealtadpole_N_symbol_1_percentRealtadpole_N_symbol_2_nameTexttadpole_N_symbol_2_colourTexttadpole_N_symbol_2_sizeRealtadpole_N_symbol_2_rotationRealtadpole_N_symbol_2_offset_xRealtadpole_N_symbol_2_offset_yRealtadpole_N_symbol_2_percentRealThe model or name of model for tadpolesThe interval at which to create tadpolesThe search width for creating tadpolesThe side on which to create tadpoles  0 - Left and Right1 - Left 2 - RightThe number of tadpole types to be createdThe name of string 1 for the Nth tadpoleentryThe name of string 2 for the Nth tadpoleentryThe start chainage for the Nth tadpole entry(optional)The end chainage for the Nth tadpole entry(optional)The name of the first tadpole symbol for theNth tadpole entryThe name of the colour of the first tadpolesymbol for the Nth tadpole entryThe size of the first tadpole symbol for theNth tadpole entry (optional)The rotation of the first tadpole symbol forthe Nth tadpole entry (optional)The x offset of the first tadpole symbol forthe Nth tadpole entry (optional)The y ofset of the first tadpole symbol for theNth tadpole entry (optional)The percentage modifier for the first symbolfor the Nth tadpole entry (optional)The name of the second tadpole symbol forthe Nth tadpole entryThe name of the colour of the second tadpolesymbol for the Nth tadpole entryThe size of the second tadpole symbol forthe Nth tadpole entry (optional)The rotation of the second tadpole symbolfor the Nth tadpole entry (optional)The x offset of the second tadpole symbolfor the Nth tadpole entry (optional)The y offset of the second tadpole symbolfor the Nth tadpole entry (optional)The percentage modifier for the secondsymbol for the Nth tadpole entry (optional)12d Model Macro_Functions 5. 65 Plot Parameters12d Model plot parameters control the look of the different plots that 12d Model can generate. The Plot_Parameter_File is a 12d Model Variable that can contain plot parameters and the plotparameter values for a given plot type. Plot_Parameter_File TypesThe valid Plot_Parameter_File types are:section_x_plotsection_long_plotmelb_water_sewer_long_plotpipeline_long_plotdrainage_long_plotdrainage_plan_plotplot_frame_plotrainfall_methodsdesign_parametersEach type of plot has its own set of valid plot parameters. When a Plot_Parameter_File, say ppf, is first defined, it starts as an empty structure until it hasits type defined using the Create_XX_parameter calls.  The ppf then knows what plot parametersare valid for that type of plot. The Plot_Parameter_File ppf is then loaded with particular plot parameters and their values bymaking Set_Parameter calls and/or reading in data from a plot parameter file stored already disk(Read_Parameter_File). When all the required plot parameters have been set, the Plot_Parameter_File ppf can be usedto create a plot (Plot_parameter_file). The Plot_Parameter_File ppf can also be written out as a disk file so that it can be used in thefuture (Write_parameter_file). Note: note all the available parameters for a particular plot type need to be set for aPlot_Parameter_File.  For most plot parameters, there is a default value used for plotting and thatis used if the parameter is not given a value by a Set_Parameter call. Create_parameter_file(Plot_Parameter_File ppf,Text ppf_type)Set the Plot_Parameter_File ppf to be of type ppf_type and clear out any information alreadycontained in ppf.  For the valid types, see Plot_Parameter_File Types. Hence if ppf already contained plot information, then all that information will be lost. A function return value of zero indicates the type is successfully set.",
+		"```12dpl\nInteger Create_parameter_file(Plot_Parameter_File ppf, Text ppf_type)\n```\n---\nThe tin to be used by the apply manyThe mtf used by the apply manyThe separation between sectionsThe optional start chainage for the applymanyRealThe optional end chainage for the applymanyTextThe optional left prefix for template namesTextThe optional right prefix for template namesElementThe centreline / reference string to run theapply many downElementThe optional hinge stringTextThe optional report fileModel/Text The road strings model to be created by theapply manyModel/Text The road sections model to be created by theapply manyTextThe name of the colour for the road surfacestrings and sectionsModel/Text The optional model or name of a model forboxing strings for layer N (1 to 8)Model/ Text The optional model or name of a model forboxing sections for layer N (1 to 8)TextThe optional name of the colour for thestrings created for boxing layer N (1 to 8)Model/Text The optional model or name of a model fordifference sectionsTextThe name of the colour for differencesectionsModel/Text The optional model or name of a model forapply many polygonsModel/Text The optional model or name of a model forthe road boundary12d Model Macro_Functions\\fChaptercreate_arcsIntegerchord_arc_tolerancevolume_correctionRealIntegerpartial_interfacesIntegersections_as_4dIntegercopy_hingeIntegeruse_strippingshow_stripping_volumesIntegerIntegercalculate_natural_surface_to_design_volumescalculate_road_to_subgrade_volumecalculate_inter_boxing_layer_volumesmap_filecreate_road_tinroad_tinroad_tin_colourroad_tin_modelIntegerTextIntegerTin/TextTextModel/Textcreate_depth_range_polygonsIntegerdepth_range_fileTextdepth_range_polygons_modelModel/Textroad_tin_number_extra_modelsIntegerroad_tin_extra_model_NModel/Textcalculate_sight_distanceIntegersight_distance_minsight_distance_maxsight_distance_eye_heightsight_distance_eye_offsetRealRealRealRealWhat type of arcs to create 0 - no arcs1 - alignments 2 - polylines 3 - super stringsThe chord arc tolerance valueWhether or not to perform volume correction(0 or 1)Whether or not to create partial interfaces (0or 1)Whether or not to create sections as 4dstrings (0 or 1)Whether or not to copy the hinge string (0 or1)Whether or not to use stripping (0 or 1)Whether or not to show detailed strippingvolumes (0 or 1)Whether or not to calculate natural surface todesign volumes (0 or 1)Whether or not to calculate road to subgradevolumes (0 or 1)Whether or not to calculate inter boxinglayer volumes (0 or 1)The optional name of a map file to createWhether or not to create a tin (0 or 1)The tin or the name of the tin to createThe name of the colour for the created tinThe model or the name of the model tocreate the tin inWhether or not to create depth rangepolygons (0 or 1)The name of the depth range file to use whencreating depth range polygonsThe model or name of the model to createdepth range polygons inThe optional number of extra models for theroad tinThe model or name of the Nth model to beused as an extra model for the road tinWhether or not to calculate sight distances (0or 1)The minimum sight distanceThe maximum sight distanceThe eye height for the sight distance calcsThe eye offset for the sight distance calcssight_distance_target_heightsight_distance_target_offsetsight_distance_calc_intervalRealRealRealThe target height for the sight distance calcsThe target offset for the sight distance calcsThe calc interval for the sight distance calcsIntegerInteger12d Model Macro_Functions sight_distance_trial_intervalsight_distance_reportRealTextcreate_separation_barrier_linesIntegerbarrier_distancemin_barrier_road_lengthmin_barrier_line_lengthmin_barrier_betweenfilter_cross_sectionsRealRealRealRealIntegerfilter_sections_modelModel/Textfilter_sections_colourTextfilter_sections_intervalfilter_sections_toleranceRealRealfilter_sections_include_startIntegerfilter_sections_include_endIntegerfilter_sections_include_equalitiesfilter_sections_include_h_tangentfilter_sections_include_v_tangentfilter_sections_include_crest_sagfilter_sections_spc_fileIntegergenerate_long_section_plotIntegerlong_section_ppflong_section_plotter_typeTextTextlong_section_plot_stemlong_section_plot_cleanTextIntegergenerate_cross_section_plotIntegercross_section_ppfcross_section_plotter_typeTextTextcross_section_plot_stemcross_section_plot_cleanTextIntegercreate_tadpolesIntegerChaptertadpole_modeltadpole_intervaltadpole_search_widthtadpole_search_sideModel/TextRealRealIntegertadpole_counttadpole_N_string_1_nameIntegerTexttadpole_N_string_2_nameTexttadpole_N_start_chRealtadpole_N_end_chRealtadpole_N_symbol_1_nameTexttadpole_N_symbol_1_colourTexttadpole_N_symbol_1_sizeRealtadpole_N_symbol_1_rotationRealtadpole_N_symbol_1_offset_xRealtadpole_N_symbol_1_offset_yRealtadpole_N_symbol_1_percentRealtadpole_N_symbol_2_nameTexttadpole_N_symbol_2_colourTexttadpole_N_symbol_2_sizeRealtadpole_N_symbol_2_rotationRealtadpole_N_symbol_2_offset_xRealtadpole_N_symbol_2_offset_yRealtadpole_N_symbol_2_percentRealThe model or name of model for tadpolesThe interval at which to create tadpolesThe search width for creating tadpolesThe side on which to create tadpoles  0 - Left and Right1 - Left 2 - RightThe number of tadpole types to be createdThe name of string 1 for the Nth tadpoleentryThe name of string 2 for the Nth tadpoleentryThe start chainage for the Nth tadpole entry(optional)The end chainage for the Nth tadpole entry(optional)The name of the first tadpole symbol for theNth tadpole entryThe name of the colour of the first tadpolesymbol for the Nth tadpole entryThe size of the first tadpole symbol for theNth tadpole entry (optional)The rotation of the first tadpole symbol forthe Nth tadpole entry (optional)The x offset of the first tadpole symbol forthe Nth tadpole entry (optional)The y ofset of the first tadpole symbol for theNth tadpole entry (optional)The percentage modifier for the first symbolfor the Nth tadpole entry (optional)The name of the second tadpole symbol forthe Nth tadpole entryThe name of the colour of the second tadpolesymbol for the Nth tadpole entryThe size of the second tadpole symbol forthe Nth tadpole entry (optional)The rotation of the second tadpole symbolfor the Nth tadpole entry (optional)The x offset of the second tadpole symbolfor the Nth tadpole entry (optional)The y offset of the second tadpole symbolfor the Nth tadpole entry (optional)The percentage modifier for the secondsymbol for the Nth tadpole entry (optional)12d Model Macro_Functions 5. 65 Plot Parameters12d Model plot parameters control the look of the different plots that 12d Model can generate. The Plot_Parameter_File is a 12d Model Variable that can contain plot parameters and the plotparameter values for a given plot type. Plot_Parameter_File TypesThe valid Plot_Parameter_File types are:section_x_plotsection_long_plotmelb_water_sewer_long_plotpipeline_long_plotdrainage_long_plotdrainage_plan_plotplot_frame_plotrainfall_methodsdesign_parametersEach type of plot has its own set of valid plot parameters. When a Plot_Parameter_File, say ppf, is first defined, it starts as an empty structure until it hasits type defined using the Create_XX_parameter calls.  The ppf then knows what plot parametersare valid for that type of plot. The Plot_Parameter_File ppf is then loaded with particular plot parameters and their values bymaking Set_Parameter calls and/or reading in data from a plot parameter file stored already disk(Read_Parameter_File). When all the required plot parameters have been set, the Plot_Parameter_File ppf can be usedto create a plot (Plot_parameter_file). The Plot_Parameter_File ppf can also be written out as a disk file so that it can be used in thefuture (Write_parameter_file). Note: note all the available parameters for a particular plot type need to be set for aPlot_Parameter_File.  For most plot parameters, there is a default value used for plotting and thatis used if the parameter is not given a value by a Set_Parameter call. Create_parameter_file(Plot_Parameter_File ppf,Text ppf_type)Set the Plot_Parameter_File ppf to be of type ppf_type and clear out any information alreadycontained in ppf.  For the valid types, see Plot_Parameter_File Types. Hence if ppf already contained plot information, then all that information will be lost. A function return value of zero indicates the type is successfully set.",
 	},
 	"Create_perspective_plot_parameter_file": {
 		"```12dpl\nInteger Create_perspective_plot_parameter_file(Plot_Parameter_File ppf)\n```\n---\nSet the Plot_Parameter_File ppf to be of type perspective_plot, and clear out any informationalready contained in ppf. Hence if ppf already contained plot information, then all that information will be lost. A function return value of zero indicates the type is successfully set.",
@@ -8845,6 +8845,6700 @@ var Lib = map[string][]string{
 	},
 }
 
+// Map of library func identifier and whether each overload returns a status
+// code, in the same order as the docstrings in Lib. Functions which do not
+// return a status code are not included.
+var LibStatusCodes = map[string][]bool{
+	"ADAC_get_xsd_path": {
+		true,
+	},
+	"Add_condition": {
+		true,
+		true,
+		true,
+	},
+	"Add_data": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+	},
+	"Add_dependancy_element": {
+		true,
+	},
+	"Add_dependancy_file": {
+		true,
+	},
+	"Add_dependancy_model": {
+		true,
+	},
+	"Add_dependancy_template": {
+		true,
+	},
+	"Add_dependancy_tin": {
+		true,
+	},
+	"Add_group_by": {
+		true,
+	},
+	"Add_item": {
+		true,
+		false,
+	},
+	"Add_log_line": {
+		true,
+	},
+	"Add_order_by": {
+		true,
+	},
+	"Add_parameter": {
+		true,
+		true,
+		true,
+	},
+	"Add_result_column": {
+		true,
+		true,
+	},
+	"Add_table": {
+		true,
+		true,
+	},
+	"Add_time_data": {
+		true,
+		true,
+	},
+	"Add_time_parameter": {
+		true,
+	},
+	"Add_wildcard": {
+		true,
+	},
+	"Affine": {
+		true,
+	},
+	"Angle_intersect": {
+		true,
+	},
+	"Angle_prompt": {
+		true,
+	},
+	"Append": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+		false,
+		false,
+	},
+	"Append_hip": {
+		true,
+		true,
+		true,
+	},
+	"Append_log_line": {
+		true,
+	},
+	"Append_node": {
+		true,
+	},
+	"Append_vip": {
+		true,
+		true,
+		true,
+	},
+	"Apply": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Apply_many": {
+		true,
+		true,
+		true,
+	},
+	"Attribute_debug": {
+		true,
+		true,
+	},
+	"Attribute_dump": {
+		true,
+		false,
+	},
+	"Begin_transaction": {
+		true,
+	},
+	"Boundary_polygon": {
+		true,
+	},
+	"Breakline": {
+		true,
+	},
+	"Calc_alignment": {
+		true,
+	},
+	"Calc_extent": {
+		true,
+		true,
+		true,
+	},
+	"Calc_super_alignment_equalities": {
+		true,
+	},
+	"Calculate_helmert_2d_transform_parameters": {
+		true,
+	},
+	"Chainage_2d_to_3d": {
+		true,
+	},
+	"Chainage_3d_to_2d": {
+		true,
+	},
+	"Change_of_angle": {
+		true,
+		true,
+	},
+	"Check_object_tree_enable": {
+		true,
+	},
+	"Check_unique_attributes": {
+		true,
+	},
+	"Check_unique_attributes_with_type": {
+		true,
+	},
+	"Check_unique_drainage_pipe_attributes": {
+		true,
+	},
+	"Check_unique_drainage_pipe_attributes_with_type": {
+		true,
+	},
+	"Check_unique_drainage_pit_attributes": {
+		true,
+	},
+	"Check_unique_drainage_pit_attributes_with_type": {
+		true,
+	},
+	"Check_unique_element_attributes": {
+		true,
+	},
+	"Check_unique_element_attributes_with_type": {
+		true,
+	},
+	"Check_unique_segment_attributes": {
+		true,
+	},
+	"Check_unique_segment_attributes_with_type": {
+		true,
+	},
+	"Check_unique_vertex_attributes": {
+		true,
+	},
+	"Check_unique_vertex_attributes_with_type": {
+		true,
+	},
+	"Choice_prompt": {
+		true,
+	},
+	"Clean_elements": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Clear": {
+		true,
+		true,
+	},
+	"Clip_string": {
+		true,
+		true,
+	},
+	"Close": {
+		false,
+		true,
+	},
+	"Colour_prompt": {
+		true,
+	},
+	"Colour_triangle": {
+		true,
+		true,
+	},
+	"Colour_triangles": {
+		true,
+	},
+	"Commit_transaction": {
+		true,
+	},
+	"Compute_merged_grid": {
+		true,
+	},
+	"Connect": {
+		true,
+		true,
+	},
+	"Console_to_clipboard": {
+		true,
+	},
+	"Contour": {
+		true,
+	},
+	"Convert": {
+		true,
+		true,
+	},
+	"Convert_SDR_flat_to_tree": {
+		true,
+	},
+	"Convert_colour": {
+		true,
+		true,
+		true,
+	},
+	"Convert_grid_string_to_grid_tin": {
+		true,
+	},
+	"Convert_grid_tin_to_grid_string": {
+		true,
+	},
+	"Convert_grid_to_strings": {
+		true,
+	},
+	"Convert_grid_to_tin": {
+		true,
+	},
+	"Convert_guid": {
+		true,
+		true,
+	},
+	"Convert_long_lat": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Convert_to_polymesh": {
+		true,
+	},
+	"Convert_uid": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Create_apply_many_function": {
+		true,
+		false,
+	},
+	"Create_button": {
+		true,
+		false,
+		false,
+	},
+	"Create_chain_parameters": {
+		true,
+	},
+	"Create_design_parameters_parameter_file": {
+		true,
+	},
+	"Create_drainage_long_plot_parameter_file": {
+		true,
+	},
+	"Create_drainage_plan_plot_parameter_file": {
+		true,
+	},
+	"Create_equality_label": {
+		true,
+	},
+	"Create_macro": {
+		true,
+	},
+	"Create_macro_function": {
+		true,
+	},
+	"Create_melb_water_sewer_long_plot_parameter_file": {
+		true,
+	},
+	"Create_parameter_file": {
+		true,
+	},
+	"Create_perspective_plot_parameter_file": {
+		true,
+	},
+	"Create_pipeline": {
+		true,
+		true,
+	},
+	"Create_pipeline_long_plot_parameter_file": {
+		true,
+	},
+	"Create_plot_frame_plot_parameter_file": {
+		true,
+	},
+	"Create_process": {
+		true,
+		true,
+	},
+	"Create_rainfall_methods_parameter_file": {
+		true,
+	},
+	"Create_section_long_plot_parameter_file": {
+		true,
+	},
+	"Create_section_plot_parameter_file": {
+		true,
+	},
+	"Create_section_x_plot_parameter_file": {
+		true,
+	},
+	"Create_water_node_diagram_plot_parameter_file": {
+		true,
+	},
+	"Cut_fill_trimeshes_between_tins": {
+		true,
+	},
+	"Cut_strings": {
+		true,
+		true,
+	},
+	"Cut_strings_with_nulls": {
+		true,
+		true,
+	},
+	"DRF_clear_overrides": {
+		true,
+	},
+	"DRF_dimension_aligned_points_create": {
+		true,
+	},
+	"DRF_dimension_aligned_points_fixoffset_create": {
+		true,
+	},
+	"DRF_dimension_aligned_segment_create": {
+		true,
+	},
+	"DRF_dimension_aligned_segment_fixoffset_create": {
+		true,
+	},
+	"DRF_dimension_angular_points_create": {
+		true,
+	},
+	"DRF_dimension_angular_segment_create": {
+		true,
+	},
+	"DRF_dimension_area_create": {
+		true,
+		true,
+	},
+	"DRF_dimension_diameter_create": {
+		true,
+	},
+	"DRF_dimension_drop_perpendicular_create": {
+		true,
+	},
+	"DRF_dimension_edit_move_dim": {
+		true,
+	},
+	"DRF_dimension_edit_move_end": {
+		true,
+	},
+	"DRF_dimension_edit_move_start": {
+		true,
+	},
+	"DRF_dimension_edit_set_end": {
+		true,
+	},
+	"DRF_dimension_edit_set_start": {
+		true,
+	},
+	"DRF_dimension_horizontal_points_create": {
+		true,
+	},
+	"DRF_dimension_horizontal_segment_create": {
+		true,
+	},
+	"DRF_dimension_length_create": {
+		true,
+	},
+	"DRF_dimension_length_fixoffset_create": {
+		true,
+	},
+	"DRF_dimension_radial_create": {
+		true,
+	},
+	"DRF_dimension_rotated_points_create": {
+		true,
+	},
+	"DRF_dimension_rotated_segment_create": {
+		true,
+	},
+	"DRF_dimension_style_property": {
+		true,
+		true,
+		true,
+	},
+	"DRF_dimension_vertical_points_create": {
+		true,
+		true,
+	},
+	"DRF_drafting_edit_set_format_text": {
+		true,
+	},
+	"DRF_drafting_edit_set_style": {
+		true,
+	},
+	"DRF_get_dimension_styles": {
+		true,
+	},
+	"DRF_get_leader_arrow": {
+		true,
+	},
+	"DRF_get_leader_hook": {
+		true,
+	},
+	"DRF_get_leader_hook_angle": {
+		true,
+	},
+	"DRF_get_leader_styles": {
+		true,
+	},
+	"DRF_get_leader_text": {
+		true,
+	},
+	"DRF_get_override_names": {
+		true,
+	},
+	"DRF_get_override_value": {
+		true,
+		true,
+		true,
+	},
+	"DRF_get_style": {
+		true,
+	},
+	"DRF_get_table_styles": {
+		true,
+	},
+	"DRF_is_associative": {
+		true,
+	},
+	"DRF_leader_create": {
+		true,
+	},
+	"DRF_leader_create_associative": {
+		true,
+	},
+	"DRF_leader_create_associative_element": {
+		true,
+		true,
+	},
+	"DRF_leader_create_associative_point": {
+		true,
+	},
+	"DRF_leader_create_associative_segment": {
+		true,
+	},
+	"DRF_leader_edit_move_arrow": {
+		true,
+	},
+	"DRF_leader_edit_move_hook": {
+		true,
+	},
+	"DRF_leader_edit_set_arrow_element": {
+		true,
+	},
+	"DRF_leader_edit_set_arrow_point": {
+		true,
+	},
+	"DRF_leader_edit_set_arrow_segment": {
+		true,
+	},
+	"DRF_leader_style_property": {
+		true,
+		true,
+		true,
+	},
+	"DRF_leader_text_create": {
+		true,
+	},
+	"DRF_recalc": {
+		true,
+	},
+	"DRF_remove_association": {
+		true,
+	},
+	"DRF_set_leader_hook_angle": {
+		true,
+	},
+	"DRF_set_leader_info_tin": {
+		true,
+	},
+	"DRF_set_override_value": {
+		true,
+		true,
+		true,
+	},
+	"DRF_table_create": {
+		true,
+	},
+	"DRF_table_edit_cell": {
+		true,
+		true,
+		true,
+	},
+	"DRF_table_edit_resize": {
+		true,
+	},
+	"DRF_table_edit_resize_column": {
+		true,
+	},
+	"DRF_table_edit_resize_row": {
+		true,
+	},
+	"DRF_table_get_column_width": {
+		true,
+	},
+	"DRF_table_get_number_row_column": {
+		true,
+	},
+	"DRF_table_get_offset": {
+		true,
+	},
+	"DRF_table_get_origin": {
+		true,
+	},
+	"DRF_table_get_rotation": {
+		true,
+	},
+	"DRF_table_get_row_height": {
+		true,
+	},
+	"DRF_table_set_column_width": {
+		true,
+	},
+	"DRF_table_set_offset": {
+		true,
+	},
+	"DRF_table_set_origin": {
+		true,
+	},
+	"DRF_table_set_rotation": {
+		true,
+	},
+	"DRF_table_set_row_height": {
+		true,
+	},
+	"DRF_table_style_property": {
+		true,
+		true,
+		true,
+	},
+	"Date": {
+		true,
+		true,
+	},
+	"Delete_all_dependancies": {
+		true,
+	},
+	"Delete_all_empty_models": {
+		true,
+		true,
+	},
+	"Delete_all_functions": {
+		true,
+	},
+	"Delete_all_models": {
+		true,
+	},
+	"Delete_all_rows": {
+		true,
+	},
+	"Delete_all_templates": {
+		true,
+	},
+	"Delete_all_tins": {
+		true,
+	},
+	"Delete_dependancy": {
+		true,
+	},
+	"Delete_element": {
+		true,
+	},
+	"Delete_files": {
+		true,
+	},
+	"Delete_function_strings_and_models": {
+		true,
+	},
+	"Delete_hip": {
+		true,
+	},
+	"Delete_item": {
+		true,
+		false,
+	},
+	"Delete_models": {
+		true,
+	},
+	"Delete_row": {
+		true,
+	},
+	"Delete_tins": {
+		true,
+	},
+	"Delete_vip": {
+		true,
+	},
+	"Directory_create": {
+		true,
+	},
+	"Directory_create_recursive": {
+		true,
+	},
+	"Directory_delete": {
+		true,
+	},
+	"Directory_delete_recursive": {
+		true,
+	},
+	"Directory_prompt": {
+		true,
+	},
+	"Display": {
+		true,
+	},
+	"Display_relative": {
+		true,
+	},
+	"Do_not_use": {
+		true,
+	},
+	"Drainage_Adjust_Pit_Connection_Points": {
+		true,
+	},
+	"Drainage_Adjust_Pit_Connection_Points_All": {
+		true,
+	},
+	"Drainage_default_grading_to_end": {
+		true,
+	},
+	"Drainage_grade_to_end": {
+		true,
+	},
+	"Drainage_join_strings": {
+		true,
+	},
+	"Drape": {
+		true,
+		true,
+		true,
+		false,
+	},
+	"Draw_elements": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Draw_polyline": {
+		true,
+	},
+	"Draw_text": {
+		true,
+	},
+	"Draw_to": {
+		true,
+	},
+	"Draw_triangle": {
+		true,
+	},
+	"Draw_triangles_about_point": {
+		true,
+	},
+	"Drop_point": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Drop_point_3d": {
+		true,
+	},
+	"Dump_slx_image": {
+		true,
+	},
+	"Dump_view_image": {
+		true,
+	},
+	"Element_delete": {
+		true,
+	},
+	"Element_draw": {
+		true,
+		true,
+	},
+	"Element_duplicate": {
+		true,
+	},
+	"Enable_3d": {
+		true,
+	},
+	"Error_prompt": {
+		true,
+	},
+	"Execute": {
+		false,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+	},
+	"Explode_segment_text_border": {
+		true,
+	},
+	"Explode_text": {
+		true,
+	},
+	"Explode_text_border": {
+		true,
+	},
+	"Explode_vertex_text_border": {
+		true,
+	},
+	"Extend_string": {
+		true,
+	},
+	"Face_drape": {
+		true,
+		true,
+	},
+	"Factor": {
+		true,
+	},
+	"File_close": {
+		true,
+	},
+	"File_contains": {
+		true,
+	},
+	"File_contains_XML_element": {
+		true,
+	},
+	"File_copy": {
+		true,
+	},
+	"File_flush": {
+		true,
+	},
+	"File_open": {
+		true,
+		true,
+	},
+	"File_prompt": {
+		true,
+	},
+	"File_read": {
+		true,
+		true,
+		true,
+		true,
+		true,
+	},
+	"File_read_line": {
+		true,
+	},
+	"File_read_short": {
+		true,
+		true,
+	},
+	"File_read_unicode": {
+		true,
+	},
+	"File_redirect": {
+		true,
+	},
+	"File_rewind": {
+		true,
+	},
+	"File_seek": {
+		true,
+	},
+	"File_tell": {
+		true,
+	},
+	"File_write": {
+		true,
+		true,
+		true,
+		true,
+		true,
+	},
+	"File_write_line": {
+		true,
+	},
+	"File_write_short": {
+		true,
+		true,
+	},
+	"File_write_unicode": {
+		true,
+	},
+	"Filter": {
+		true,
+	},
+	"Find_element": {
+		true,
+	},
+	"Find_models": {
+		true,
+	},
+	"Find_tins": {
+		true,
+	},
+	"Find_views": {
+		true,
+	},
+	"Fitarc": {
+		true,
+		false,
+		true,
+	},
+	"Flip_triangles": {
+		true,
+	},
+	"Form_trimesh_from_points": {
+		true,
+		true,
+	},
+	"Form_trimesh_from_polygons": {
+		true,
+		true,
+	},
+	"Form_trimesh_from_tin": {
+		true,
+	},
+	"Form_trimeshes_from_element": {
+		true,
+	},
+	"Form_trimeshes_from_tin": {
+		true,
+	},
+	"Format_grid": {
+		true,
+	},
+	"From_text": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+		false,
+		true,
+	},
+	"Function_attribute_debug": {
+		true,
+		true,
+	},
+	"Function_attribute_dump": {
+		true,
+		true,
+	},
+	"Function_delete": {
+		true,
+	},
+	"Function_prompt": {
+		true,
+	},
+	"Function_recalc": {
+		true,
+	},
+	"Function_rename": {
+		true,
+	},
+	"GUID_Gen": {
+		true,
+		true,
+	},
+	"Get_2d_data": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Get_3d_length": {
+		true,
+	},
+	"Get_4d_angle": {
+		true,
+	},
+	"Get_4d_angle2": {
+		true,
+		true,
+	},
+	"Get_4d_border": {
+		true,
+	},
+	"Get_4d_border_style": {
+		true,
+	},
+	"Get_4d_data": {
+		true,
+		true,
+		true,
+	},
+	"Get_4d_height": {
+		true,
+	},
+	"Get_4d_justify": {
+		true,
+	},
+	"Get_4d_offset": {
+		true,
+	},
+	"Get_4d_rise": {
+		true,
+	},
+	"Get_4d_size": {
+		true,
+	},
+	"Get_4d_slant": {
+		true,
+	},
+	"Get_4d_style": {
+		true,
+	},
+	"Get_4d_textstyle_data": {
+		true,
+	},
+	"Get_4d_ttf_italic": {
+		true,
+	},
+	"Get_4d_ttf_outline": {
+		true,
+	},
+	"Get_4d_ttf_strikeout": {
+		true,
+	},
+	"Get_4d_ttf_underline": {
+		true,
+	},
+	"Get_4d_ttf_weight": {
+		true,
+	},
+	"Get_4d_units": {
+		true,
+	},
+	"Get_4d_whiteout": {
+		true,
+	},
+	"Get_4d_x_factor": {
+		true,
+	},
+	"Get_SDR_field_file_type": {
+		true,
+	},
+	"Get_XML_declaration": {
+		true,
+	},
+	"Get_active_project_settings_profile": {
+		true,
+	},
+	"Get_active_theme_image_directory": {
+		true,
+	},
+	"Get_active_theme_name": {
+		true,
+	},
+	"Get_all_functions": {
+		true,
+	},
+	"Get_all_linestyles": {
+		true,
+	},
+	"Get_all_patterns": {
+		true,
+	},
+	"Get_all_symbols": {
+		true,
+	},
+	"Get_all_textstyles": {
+		true,
+	},
+	"Get_allow_holes": {
+		true,
+	},
+	"Get_angle": {
+		true,
+		false,
+		false,
+	},
+	"Get_angle2": {
+		true,
+	},
+	"Get_angle3": {
+		true,
+	},
+	"Get_apply_many_function_properties": {
+		true,
+	},
+	"Get_arc": {
+		true,
+	},
+	"Get_arc_centre": {
+		true,
+	},
+	"Get_arc_chord_arc": {
+		true,
+	},
+	"Get_arc_data": {
+		true,
+	},
+	"Get_arc_end": {
+		true,
+	},
+	"Get_arc_interval": {
+		true,
+	},
+	"Get_arc_radius": {
+		true,
+	},
+	"Get_arc_start": {
+		true,
+	},
+	"Get_attribute": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+	},
+	"Get_attribute_by_type": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+	},
+	"Get_attribute_length": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Get_attribute_name": {
+		true,
+		true,
+	},
+	"Get_attribute_type": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Get_attributes": {
+		true,
+	},
+	"Get_attributes_controlbar": {
+		true,
+	},
+	"Get_auto_cut_paste": {
+		true,
+		false,
+	},
+	"Get_billboard_size": {
+		true,
+	},
+	"Get_border": {
+		true,
+	},
+	"Get_border_style": {
+		true,
+	},
+	"Get_breakline": {
+		true,
+	},
+	"Get_cad_controlbar": {
+		true,
+	},
+	"Get_caret": {
+		true,
+		false,
+	},
+	"Get_carto_projection_datum_data": {
+		true,
+	},
+	"Get_cell": {
+		true,
+	},
+	"Get_chainage": {
+		true,
+	},
+	"Get_char": {
+		true,
+	},
+	"Get_child_node": {
+		true,
+		true,
+	},
+	"Get_circle_data": {
+		true,
+	},
+	"Get_clipboard_text": {
+		true,
+	},
+	"Get_colour": {
+		true,
+		true,
+	},
+	"Get_command_argument": {
+		true,
+	},
+	"Get_current_inquire_style": {
+		true,
+	},
+	"Get_current_page": {
+		true,
+	},
+	"Get_cursor_position": {
+		true,
+	},
+	"Get_curve": {
+		true,
+	},
+	"Get_data": {
+		true,
+		true,
+		false,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+	},
+	"Get_dependancy_data": {
+		true,
+		true,
+	},
+	"Get_dependancy_element": {
+		true,
+		true,
+	},
+	"Get_dependancy_file": {
+		true,
+		true,
+	},
+	"Get_dependancy_model": {
+		true,
+		true,
+	},
+	"Get_dependancy_name": {
+		true,
+	},
+	"Get_dependancy_template": {
+		true,
+		true,
+	},
+	"Get_dependancy_tin": {
+		true,
+		true,
+	},
+	"Get_dependancy_type": {
+		true,
+		true,
+	},
+	"Get_directory": {
+		true,
+	},
+	"Get_disk_file_name": {
+		true,
+		true,
+		true,
+		true,
+		true,
+	},
+	"Get_display_resolution": {
+		true,
+	},
+	"Get_drainage_data": {
+		true,
+		true,
+		true,
+	},
+	"Get_drainage_float": {
+		true,
+	},
+	"Get_drainage_flow": {
+		true,
+	},
+	"Get_drainage_fs_tin": {
+		true,
+	},
+	"Get_drainage_grade_curve_coords": {
+		true,
+	},
+	"Get_drainage_grade_curve_name": {
+		true,
+	},
+	"Get_drainage_grade_curve_threshold": {
+		true,
+	},
+	"Get_drainage_hc": {
+		true,
+	},
+	"Get_drainage_hc_adopted_level": {
+		true,
+	},
+	"Get_drainage_hc_bush": {
+		true,
+	},
+	"Get_drainage_hc_chainage": {
+		true,
+	},
+	"Get_drainage_hc_colour": {
+		true,
+	},
+	"Get_drainage_hc_depth": {
+		true,
+	},
+	"Get_drainage_hc_diameter": {
+		true,
+	},
+	"Get_drainage_hc_grade": {
+		true,
+	},
+	"Get_drainage_hc_hcb": {
+		true,
+	},
+	"Get_drainage_hc_ip": {
+		true,
+	},
+	"Get_drainage_hc_length": {
+		true,
+	},
+	"Get_drainage_hc_level": {
+		true,
+	},
+	"Get_drainage_hc_material": {
+		true,
+	},
+	"Get_drainage_hc_name": {
+		true,
+	},
+	"Get_drainage_hc_side": {
+		true,
+	},
+	"Get_drainage_hc_type": {
+		true,
+	},
+	"Get_drainage_hcs": {
+		true,
+	},
+	"Get_drainage_intensity": {
+		true,
+	},
+	"Get_drainage_manhole_capacities": {
+		true,
+	},
+	"Get_drainage_manhole_capacities_grade": {
+		true,
+	},
+	"Get_drainage_manhole_capacities_sag": {
+		true,
+	},
+	"Get_drainage_manhole_config": {
+		true,
+	},
+	"Get_drainage_manhole_description": {
+		true,
+	},
+	"Get_drainage_manhole_diam": {
+		true,
+	},
+	"Get_drainage_manhole_group": {
+		true,
+	},
+	"Get_drainage_manhole_length": {
+		true,
+	},
+	"Get_drainage_manhole_notes": {
+		true,
+	},
+	"Get_drainage_manhole_type": {
+		true,
+	},
+	"Get_drainage_manhole_types": {
+		true,
+	},
+	"Get_drainage_manhole_width": {
+		true,
+	},
+	"Get_drainage_ns_tin": {
+		true,
+	},
+	"Get_drainage_number_of_grade_curve_coords": {
+		true,
+	},
+	"Get_drainage_number_of_grade_curves": {
+		true,
+	},
+	"Get_drainage_number_of_manhole_types": {
+		true,
+	},
+	"Get_drainage_number_of_pipe_types": {
+		true,
+	},
+	"Get_drainage_number_of_sag_curve_coords": {
+		true,
+	},
+	"Get_drainage_number_of_sag_curves": {
+		true,
+	},
+	"Get_drainage_outfall_height": {
+		true,
+	},
+	"Get_drainage_pc_boundary": {
+		true,
+	},
+	"Get_drainage_pc_chainage": {
+		true,
+	},
+	"Get_drainage_pc_colour": {
+		true,
+	},
+	"Get_drainage_pc_cover": {
+		true,
+	},
+	"Get_drainage_pc_diameter": {
+		true,
+	},
+	"Get_drainage_pc_grade": {
+		true,
+	},
+	"Get_drainage_pc_ip": {
+		true,
+	},
+	"Get_drainage_pc_name": {
+		true,
+	},
+	"Get_drainage_pc_ratio": {
+		true,
+	},
+	"Get_drainage_pc_start_level": {
+		true,
+	},
+	"Get_drainage_pc_xyz": {
+		true,
+	},
+	"Get_drainage_pcs_count": {
+		true,
+	},
+	"Get_drainage_pipe_attribute": {
+		true,
+		true,
+		true,
+		true,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+	},
+	"Get_drainage_pipe_attribute_name": {
+		true,
+	},
+	"Get_drainage_pipe_attribute_type": {
+		true,
+		true,
+	},
+	"Get_drainage_pipe_attributes": {
+		true,
+	},
+	"Get_drainage_pipe_colour": {
+		true,
+	},
+	"Get_drainage_pipe_cover": {
+		true,
+	},
+	"Get_drainage_pipe_diameter": {
+		true,
+	},
+	"Get_drainage_pipe_flow": {
+		true,
+	},
+	"Get_drainage_pipe_fs": {
+		true,
+	},
+	"Get_drainage_pipe_grade": {
+		true,
+	},
+	"Get_drainage_pipe_hgls": {
+		true,
+	},
+	"Get_drainage_pipe_intersects_pit": {
+		true,
+	},
+	"Get_drainage_pipe_inverts": {
+		true,
+	},
+	"Get_drainage_pipe_length": {
+		true,
+	},
+	"Get_drainage_pipe_name": {
+		true,
+	},
+	"Get_drainage_pipe_nominal_diameter": {
+		true,
+	},
+	"Get_drainage_pipe_ns": {
+		true,
+	},
+	"Get_drainage_pipe_number_of_attributes": {
+		true,
+	},
+	"Get_drainage_pipe_number_of_pipes": {
+		true,
+	},
+	"Get_drainage_pipe_roughness": {
+		true,
+	},
+	"Get_drainage_pipe_separation": {
+		true,
+	},
+	"Get_drainage_pipe_shape": {
+		true,
+		true,
+	},
+	"Get_drainage_pipe_thickness": {
+		true,
+	},
+	"Get_drainage_pipe_top_width": {
+		true,
+	},
+	"Get_drainage_pipe_type": {
+		true,
+		true,
+	},
+	"Get_drainage_pipe_types": {
+		true,
+	},
+	"Get_drainage_pipe_velocity": {
+		true,
+	},
+	"Get_drainage_pipe_width": {
+		true,
+	},
+	"Get_drainage_pit": {
+		true,
+	},
+	"Get_drainage_pit_2d_connection_mode": {
+		true,
+	},
+	"Get_drainage_pit_angle": {
+		true,
+		true,
+	},
+	"Get_drainage_pit_area": {
+		true,
+	},
+	"Get_drainage_pit_attribute": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+	},
+	"Get_drainage_pit_attribute_length": {
+		true,
+		true,
+	},
+	"Get_drainage_pit_attribute_name": {
+		true,
+	},
+	"Get_drainage_pit_attribute_type": {
+		true,
+		true,
+	},
+	"Get_drainage_pit_attributes": {
+		true,
+	},
+	"Get_drainage_pit_base_angle": {
+		true,
+	},
+	"Get_drainage_pit_base_angle_mode": {
+		true,
+	},
+	"Get_drainage_pit_base_height": {
+		true,
+	},
+	"Get_drainage_pit_base_thickness_top": {
+		true,
+	},
+	"Get_drainage_pit_branches": {
+		true,
+	},
+	"Get_drainage_pit_chainage": {
+		true,
+	},
+	"Get_drainage_pit_chainages": {
+		true,
+	},
+	"Get_drainage_pit_colour": {
+		true,
+	},
+	"Get_drainage_pit_connection": {
+		true,
+	},
+	"Get_drainage_pit_connection_points": {
+		true,
+	},
+	"Get_drainage_pit_connection_points_mode": {
+		true,
+	},
+	"Get_drainage_pit_depth": {
+		true,
+	},
+	"Get_drainage_pit_diameter": {
+		true,
+	},
+	"Get_drainage_pit_drop": {
+		true,
+	},
+	"Get_drainage_pit_extended": {
+		true,
+	},
+	"Get_drainage_pit_float": {
+		true,
+	},
+	"Get_drainage_pit_float_sump": {
+		true,
+	},
+	"Get_drainage_pit_fs": {
+		true,
+	},
+	"Get_drainage_pit_hgl": {
+		true,
+	},
+	"Get_drainage_pit_hgls": {
+		true,
+	},
+	"Get_drainage_pit_inverts": {
+		true,
+	},
+	"Get_drainage_pit_length": {
+		true,
+	},
+	"Get_drainage_pit_name": {
+		true,
+	},
+	"Get_drainage_pit_ns": {
+		true,
+	},
+	"Get_drainage_pit_number_of_attributes": {
+		true,
+	},
+	"Get_drainage_pit_riser": {
+		true,
+	},
+	"Get_drainage_pit_riser_colour": {
+		true,
+	},
+	"Get_drainage_pit_riser_diameter": {
+		true,
+	},
+	"Get_drainage_pit_riser_extended": {
+		true,
+	},
+	"Get_drainage_pit_riser_offset_xy": {
+		true,
+	},
+	"Get_drainage_pit_riser_thickness": {
+		true,
+	},
+	"Get_drainage_pit_riser_width": {
+		true,
+	},
+	"Get_drainage_pit_road_chainage": {
+		true,
+	},
+	"Get_drainage_pit_road_name": {
+		true,
+	},
+	"Get_drainage_pit_shape": {
+		true,
+	},
+	"Get_drainage_pit_sump_level": {
+		true,
+	},
+	"Get_drainage_pit_surface_hgl": {
+		true,
+	},
+	"Get_drainage_pit_symbol_angle": {
+		true,
+	},
+	"Get_drainage_pit_symbol_angle_mode": {
+		true,
+	},
+	"Get_drainage_pit_thickness": {
+		true,
+	},
+	"Get_drainage_pit_type": {
+		true,
+	},
+	"Get_drainage_pit_width": {
+		true,
+	},
+	"Get_drainage_pits": {
+		true,
+	},
+	"Get_drainage_sag_curve_coords": {
+		true,
+	},
+	"Get_drainage_sag_curve_name": {
+		true,
+	},
+	"Get_drainage_sewer": {
+		true,
+	},
+	"Get_drainage_use_connection_points": {
+		true,
+	},
+	"Get_dump_name": {
+		true,
+		true,
+	},
+	"Get_dynamic_sizing": {
+		true,
+	},
+	"Get_element": {
+		true,
+		true,
+		true,
+	},
+	"Get_elements": {
+		true,
+		true,
+		true,
+		true,
+		true,
+	},
+	"Get_enable": {
+		true,
+	},
+	"Get_encoding": {
+		true,
+	},
+	"Get_end": {
+		false,
+		false,
+		true,
+		false,
+	},
+	"Get_end_chainage": {
+		true,
+	},
+	"Get_end_chainage_3d": {
+		true,
+	},
+	"Get_equality_info_name": {
+		true,
+	},
+	"Get_equality_info_offset": {
+		true,
+	},
+	"Get_equality_info_prename": {
+		true,
+	},
+	"Get_equality_info_preoffset": {
+		true,
+	},
+	"Get_equality_info_prevalid": {
+		true,
+	},
+	"Get_equality_info_prezone": {
+		true,
+	},
+	"Get_equality_info_valid": {
+		true,
+	},
+	"Get_equality_info_zone": {
+		true,
+	},
+	"Get_equality_label_data": {
+		true,
+		true,
+	},
+	"Get_extent_x": {
+		true,
+		true,
+	},
+	"Get_extent_y": {
+		true,
+		true,
+	},
+	"Get_extent_z": {
+		true,
+		true,
+	},
+	"Get_face_data": {
+		true,
+		true,
+		true,
+	},
+	"Get_face_edge_colour": {
+		true,
+	},
+	"Get_face_edge_mode": {
+		true,
+	},
+	"Get_face_fill_mode": {
+		true,
+	},
+	"Get_face_hatch_angle": {
+		true,
+	},
+	"Get_face_hatch_colour": {
+		true,
+	},
+	"Get_face_hatch_distance": {
+		true,
+	},
+	"Get_face_hatch_mode": {
+		true,
+	},
+	"Get_face_heights_at_point": {
+		true,
+	},
+	"Get_feature_centre": {
+		true,
+	},
+	"Get_feature_radius": {
+		true,
+	},
+	"Get_file_accessed_time_utc": {
+		true,
+	},
+	"Get_file_created_time_utc": {
+		true,
+	},
+	"Get_file_encoding": {
+		true,
+	},
+	"Get_file_modified_time_utc": {
+		true,
+	},
+	"Get_file_read_only": {
+		true,
+	},
+	"Get_file_size": {
+		true,
+		true,
+	},
+	"Get_function_attribute": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+	},
+	"Get_function_attribute_length": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Get_function_attribute_name": {
+		true,
+		true,
+	},
+	"Get_function_attribute_type": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Get_function_attributes": {
+		true,
+		true,
+	},
+	"Get_function_data_end_element_id": {
+		true,
+	},
+	"Get_function_data_end_model_id": {
+		true,
+	},
+	"Get_function_data_start_element_id": {
+		true,
+	},
+	"Get_function_data_start_model_id": {
+		true,
+	},
+	"Get_function_id": {
+		true,
+		true,
+	},
+	"Get_function_number_of_attributes": {
+		true,
+		true,
+	},
+	"Get_grid_geometry": {
+		true,
+	},
+	"Get_grid_height": {
+		true,
+	},
+	"Get_grid_range": {
+		true,
+	},
+	"Get_help": {
+		true,
+		true,
+	},
+	"Get_hip_data": {
+		true,
+		true,
+		true,
+	},
+	"Get_hip_geom": {
+		true,
+	},
+	"Get_hip_points": {
+		true,
+	},
+	"Get_hip_type": {
+		true,
+	},
+	"Get_horizontal_scroll_bar": {
+		true,
+	},
+	"Get_icon_size": {
+		true,
+	},
+	"Get_id": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+	},
+	"Get_image_size": {
+		true,
+	},
+	"Get_interface_data": {
+		true,
+		true,
+		true,
+	},
+	"Get_item": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+		false,
+	},
+	"Get_justify": {
+		true,
+	},
+	"Get_last_error": {
+		true,
+	},
+	"Get_last_view": {
+		true,
+	},
+	"Get_length": {
+		false,
+		false,
+		true,
+		true,
+		false,
+	},
+	"Get_length_3d": {
+		true,
+		true,
+		true,
+	},
+	"Get_libraries": {
+		true,
+	},
+	"Get_license_client": {
+		true,
+	},
+	"Get_line": {
+		true,
+	},
+	"Get_macro_attribute": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+	},
+	"Get_macro_attribute_length": {
+		true,
+		true,
+	},
+	"Get_macro_attribute_name": {
+		true,
+	},
+	"Get_macro_attribute_type": {
+		true,
+		true,
+	},
+	"Get_macro_attributes": {
+		true,
+	},
+	"Get_macro_function": {
+		true,
+	},
+	"Get_macro_number_of_attributes": {
+		true,
+	},
+	"Get_many": {
+		true,
+	},
+	"Get_matrix": {
+		true,
+		true,
+		false,
+		false,
+	},
+	"Get_matrix_inverse": {
+		true,
+		true,
+		false,
+		false,
+	},
+	"Get_matrix_row": {
+		true,
+		true,
+		false,
+		false,
+	},
+	"Get_matrix_transpose": {
+		true,
+		true,
+		false,
+		false,
+	},
+	"Get_model": {
+		false,
+		true,
+		true,
+		true,
+	},
+	"Get_model_attribute": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+	},
+	"Get_model_attribute_length": {
+		true,
+		true,
+	},
+	"Get_model_attribute_name": {
+		true,
+	},
+	"Get_model_attribute_type": {
+		true,
+		true,
+	},
+	"Get_model_attributes": {
+		true,
+	},
+	"Get_model_number_of_attributes": {
+		true,
+	},
+	"Get_models": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Get_multi_line": {
+		true,
+	},
+	"Get_name": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+	},
+	"Get_next_sibling_node": {
+		true,
+	},
+	"Get_node_attribute": {
+		true,
+		false,
+	},
+	"Get_node_attributes": {
+		true,
+		true,
+	},
+	"Get_node_name": {
+		true,
+	},
+	"Get_node_text": {
+		true,
+	},
+	"Get_number_of_attributes": {
+		true,
+		true,
+	},
+	"Get_number_of_dependancies": {
+		true,
+	},
+	"Get_number_of_items": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+	},
+	"Get_number_of_items_peek": {
+		true,
+	},
+	"Get_offset": {
+		true,
+		false,
+	},
+	"Get_optional": {
+		true,
+	},
+	"Get_page": {
+		true,
+		true,
+		false,
+		false,
+		false,
+	},
+	"Get_parameter": {
+		true,
+		true,
+	},
+	"Get_parameters": {
+		true,
+		true,
+	},
+	"Get_parent": {
+		true,
+	},
+	"Get_parent_id": {
+		true,
+	},
+	"Get_parent_node": {
+		true,
+	},
+	"Get_perspective_matrix": {
+		true,
+		false,
+	},
+	"Get_pipe_controlbar": {
+		true,
+	},
+	"Get_pipe_data": {
+		true,
+		true,
+		true,
+	},
+	"Get_pipe_diameter": {
+		true,
+	},
+	"Get_pipe_justify": {
+		true,
+	},
+	"Get_pipeline_culvert": {
+		true,
+	},
+	"Get_pipeline_diameter": {
+		true,
+	},
+	"Get_pipeline_justification": {
+		true,
+	},
+	"Get_pipeline_length": {
+		true,
+	},
+	"Get_pipeline_shape": {
+		true,
+	},
+	"Get_plot_frame_colour": {
+		true,
+	},
+	"Get_plot_frame_draw_border": {
+		true,
+	},
+	"Get_plot_frame_draw_title_file": {
+		true,
+	},
+	"Get_plot_frame_draw_viewport": {
+		true,
+	},
+	"Get_plot_frame_margins": {
+		true,
+	},
+	"Get_plot_frame_name": {
+		true,
+	},
+	"Get_plot_frame_origin": {
+		true,
+	},
+	"Get_plot_frame_plot_file": {
+		true,
+	},
+	"Get_plot_frame_plotter": {
+		true,
+	},
+	"Get_plot_frame_plotter_name": {
+		true,
+	},
+	"Get_plot_frame_rotation": {
+		true,
+	},
+	"Get_plot_frame_scale": {
+		true,
+	},
+	"Get_plot_frame_sheet_size": {
+		true,
+		true,
+	},
+	"Get_plot_frame_text_size": {
+		true,
+	},
+	"Get_plot_frame_textstyle": {
+		true,
+	},
+	"Get_plot_frame_title_1": {
+		true,
+	},
+	"Get_plot_frame_title_2": {
+		true,
+	},
+	"Get_plot_frame_title_file": {
+		true,
+	},
+	"Get_point": {
+		false,
+		true,
+		false,
+	},
+	"Get_points": {
+		true,
+	},
+	"Get_polygon_centroid": {
+		true,
+	},
+	"Get_polyline_data": {
+		true,
+		true,
+		true,
+	},
+	"Get_position": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Get_position_3d": {
+		true,
+	},
+	"Get_position_ex_3d": {
+		true,
+	},
+	"Get_prev_sibling_node": {
+		true,
+	},
+	"Get_project_attribute": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+	},
+	"Get_project_attribute_length": {
+		true,
+		true,
+	},
+	"Get_project_attribute_name": {
+		true,
+	},
+	"Get_project_attribute_type": {
+		true,
+		true,
+	},
+	"Get_project_attributes": {
+		true,
+	},
+	"Get_project_colours": {
+		true,
+	},
+	"Get_project_folder": {
+		true,
+	},
+	"Get_project_functions": {
+		true,
+	},
+	"Get_project_models": {
+		true,
+	},
+	"Get_project_name": {
+		true,
+	},
+	"Get_project_number_of_attributes": {
+		true,
+	},
+	"Get_project_settings_profile_name": {
+		false,
+		true,
+		false,
+	},
+	"Get_project_settings_profiles_count": {
+		true,
+		false,
+	},
+	"Get_project_templates": {
+		true,
+	},
+	"Get_project_tins": {
+		true,
+	},
+	"Get_project_views": {
+		true,
+	},
+	"Get_property": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+	},
+	"Get_property_colour": {
+		true,
+	},
+	"Get_rainfall_temporal_pattern": {
+		true,
+		true,
+	},
+	"Get_rainfall_temporal_patterns_enabled": {
+		true,
+	},
+	"Get_raise": {
+		true,
+	},
+	"Get_read_locks": {
+		true,
+	},
+	"Get_read_only": {
+		true,
+	},
+	"Get_result_column": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+	},
+	"Get_root_node": {
+		true,
+	},
+	"Get_rotation_matrix": {
+		true,
+		true,
+		false,
+		false,
+	},
+	"Get_scaling_matrix": {
+		true,
+		true,
+		false,
+		false,
+	},
+	"Get_section_profile_string": {
+		true,
+	},
+	"Get_segment": {
+		true,
+	},
+	"Get_segments": {
+		true,
+	},
+	"Get_select_coordinate": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Get_select_direction": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Get_selected_cells": {
+		true,
+	},
+	"Get_selection": {
+		true,
+		false,
+	},
+	"Get_selection_count": {
+		true,
+		false,
+	},
+	"Get_selection_list": {
+		true,
+		false,
+	},
+	"Get_selections": {
+		true,
+		false,
+	},
+	"Get_setups": {
+		true,
+	},
+	"Get_show_encodings": {
+		true,
+	},
+	"Get_size": {
+		true,
+		true,
+		true,
+	},
+	"Get_sizing_constraints": {
+		true,
+	},
+	"Get_slant": {
+		true,
+	},
+	"Get_slider_position": {
+		true,
+	},
+	"Get_sort": {
+		true,
+		false,
+	},
+	"Get_spiral": {
+		true,
+	},
+	"Get_stack_trace": {
+		true,
+	},
+	"Get_start": {
+		false,
+		false,
+		true,
+		false,
+	},
+	"Get_start_chainage_3d": {
+		true,
+	},
+	"Get_style": {
+		true,
+	},
+	"Get_sub_trimesh": {
+		true,
+	},
+	"Get_super_2d_level": {
+		true,
+	},
+	"Get_super_acad_pattern": {
+		true,
+	},
+	"Get_super_acad_pattern_angle": {
+		true,
+	},
+	"Get_super_acad_pattern_colour": {
+		true,
+	},
+	"Get_super_acad_pattern_size": {
+		true,
+	},
+	"Get_super_acad_pattern_type": {
+		true,
+	},
+	"Get_super_acad_pattern_view_angle": {
+		true,
+	},
+	"Get_super_alignment_equalities_active": {
+		true,
+	},
+	"Get_super_alignment_equality_chainage": {
+		true,
+		true,
+	},
+	"Get_super_alignment_equality_data": {
+		true,
+	},
+	"Get_super_alignment_equality_info": {
+		true,
+		true,
+	},
+	"Get_super_alignment_equality_part": {
+		true,
+	},
+	"Get_super_alignment_equality_part_id": {
+		true,
+	},
+	"Get_super_alignment_equality_part_type": {
+		true,
+	},
+	"Get_super_alignment_equality_parts": {
+		true,
+	},
+	"Get_super_alignment_named_chainage": {
+		true,
+	},
+	"Get_super_alignment_named_part_chainage": {
+		true,
+	},
+	"Get_super_alignment_named_part_segment": {
+		true,
+	},
+	"Get_super_alignment_named_part_segments": {
+		true,
+	},
+	"Get_super_alignment_named_parts": {
+		true,
+	},
+	"Get_super_alignment_named_position_chainage": {
+		true,
+	},
+	"Get_super_alignment_named_positions": {
+		true,
+	},
+	"Get_super_alignment_named_segment": {
+		true,
+	},
+	"Get_super_alignment_number_of_equalities": {
+		true,
+	},
+	"Get_super_alignment_number_of_profiles": {
+		true,
+	},
+	"Get_super_alignment_raw_chainage": {
+		true,
+	},
+	"Get_super_alignment_style": {
+		true,
+	},
+	"Get_super_alignment_use_equalities": {
+		true,
+	},
+	"Get_super_alignment_vertical_position": {
+		true,
+	},
+	"Get_super_bitmap": {
+		true,
+	},
+	"Get_super_bitmap_angle": {
+		true,
+	},
+	"Get_super_bitmap_origin": {
+		true,
+	},
+	"Get_super_bitmap_size": {
+		true,
+	},
+	"Get_super_bitmap_space": {
+		true,
+	},
+	"Get_super_bitmap_stagger": {
+		true,
+	},
+	"Get_super_bitmap_transparent": {
+		true,
+	},
+	"Get_super_bitmap_type": {
+		true,
+	},
+	"Get_super_bitmap_view_angle": {
+		true,
+	},
+	"Get_super_culvert": {
+		true,
+		true,
+	},
+	"Get_super_data": {
+		true,
+		true,
+	},
+	"Get_super_diameter": {
+		true,
+	},
+	"Get_super_extrude": {
+		true,
+	},
+	"Get_super_extrudes": {
+		true,
+	},
+	"Get_super_hatch_angle": {
+		true,
+	},
+	"Get_super_hatch_colour": {
+		true,
+	},
+	"Get_super_hatch_origin": {
+		true,
+	},
+	"Get_super_hatch_plot_spacing": {
+		true,
+	},
+	"Get_super_hatch_spacing": {
+		true,
+	},
+	"Get_super_hatch_type": {
+		true,
+	},
+	"Get_super_hatch_view_angle": {
+		true,
+	},
+	"Get_super_holes": {
+		true,
+	},
+	"Get_super_interval_chord_arc": {
+		true,
+	},
+	"Get_super_interval_distance": {
+		true,
+	},
+	"Get_super_pattern": {
+		true,
+	},
+	"Get_super_pattern_angle": {
+		true,
+	},
+	"Get_super_pattern_blend": {
+		true,
+	},
+	"Get_super_pattern_colour": {
+		true,
+	},
+	"Get_super_pattern_origin": {
+		true,
+	},
+	"Get_super_pattern_plot_size": {
+		true,
+	},
+	"Get_super_pattern_size": {
+		true,
+	},
+	"Get_super_pattern_solid_colour": {
+		true,
+	},
+	"Get_super_pattern_space": {
+		true,
+	},
+	"Get_super_pattern_stagger": {
+		true,
+	},
+	"Get_super_pattern_type": {
+		true,
+	},
+	"Get_super_pattern_view_angle": {
+		true,
+	},
+	"Get_super_pipe": {
+		true,
+		true,
+	},
+	"Get_super_pipe_justify": {
+		true,
+	},
+	"Get_super_segment_attribute": {
+		true,
+		true,
+		true,
+		true,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+	},
+	"Get_super_segment_attribute_length": {
+		true,
+		true,
+	},
+	"Get_super_segment_attributes": {
+		true,
+	},
+	"Get_super_segment_colour": {
+		true,
+	},
+	"Get_super_segment_culvert": {
+		true,
+		true,
+	},
+	"Get_super_segment_curve": {
+		true,
+	},
+	"Get_super_segment_diameter": {
+		true,
+	},
+	"Get_super_segment_geometry": {
+		true,
+	},
+	"Get_super_segment_linestyle": {
+		true,
+	},
+	"Get_super_segment_major": {
+		true,
+	},
+	"Get_super_segment_number_of_attributes": {
+		true,
+	},
+	"Get_super_segment_pipe": {
+		false,
+		true,
+	},
+	"Get_super_segment_radius": {
+		true,
+	},
+	"Get_super_segment_spiral": {
+		true,
+		true,
+	},
+	"Get_super_segment_text": {
+		true,
+	},
+	"Get_super_segment_text_angle": {
+		true,
+	},
+	"Get_super_segment_text_angle2": {
+		true,
+	},
+	"Get_super_segment_text_angle3": {
+		true,
+	},
+	"Get_super_segment_text_border": {
+		true,
+	},
+	"Get_super_segment_text_border_style": {
+		true,
+	},
+	"Get_super_segment_text_colour": {
+		true,
+	},
+	"Get_super_segment_text_justify": {
+		true,
+	},
+	"Get_super_segment_text_offset_height": {
+		true,
+	},
+	"Get_super_segment_text_offset_width": {
+		true,
+	},
+	"Get_super_segment_text_size": {
+		true,
+	},
+	"Get_super_segment_text_slant": {
+		true,
+	},
+	"Get_super_segment_text_style": {
+		true,
+	},
+	"Get_super_segment_text_ttf_italic": {
+		true,
+	},
+	"Get_super_segment_text_ttf_outline": {
+		true,
+	},
+	"Get_super_segment_text_ttf_strikeout": {
+		true,
+	},
+	"Get_super_segment_text_ttf_underline": {
+		true,
+	},
+	"Get_super_segment_text_ttf_weight": {
+		true,
+	},
+	"Get_super_segment_text_type": {
+		true,
+	},
+	"Get_super_segment_text_whiteout": {
+		true,
+	},
+	"Get_super_segment_text_x_factor": {
+		true,
+	},
+	"Get_super_segment_textstyle_data": {
+		true,
+	},
+	"Get_super_segment_tinability": {
+		true,
+	},
+	"Get_super_segment_uid": {
+		true,
+	},
+	"Get_super_segment_visibility": {
+		true,
+	},
+	"Get_super_solid_blend": {
+		true,
+	},
+	"Get_super_solid_colour": {
+		true,
+	},
+	"Get_super_use_2d_level": {
+		true,
+	},
+	"Get_super_use_3d_level": {
+		true,
+	},
+	"Get_super_use_acad_pattern": {
+		true,
+	},
+	"Get_super_use_bitmap": {
+		true,
+	},
+	"Get_super_use_culvert": {
+		true,
+	},
+	"Get_super_use_diameter": {
+		true,
+	},
+	"Get_super_use_extrude": {
+		true,
+	},
+	"Get_super_use_hatch": {
+		true,
+	},
+	"Get_super_use_hole": {
+		true,
+	},
+	"Get_super_use_interval": {
+		true,
+	},
+	"Get_super_use_pattern": {
+		true,
+	},
+	"Get_super_use_pipe": {
+		true,
+	},
+	"Get_super_use_pipe_justify": {
+		true,
+	},
+	"Get_super_use_segment_annotation_array": {
+		true,
+	},
+	"Get_super_use_segment_annotation_value": {
+		true,
+	},
+	"Get_super_use_segment_attribute": {
+		true,
+	},
+	"Get_super_use_segment_colour": {
+		true,
+	},
+	"Get_super_use_segment_culvert": {
+		true,
+	},
+	"Get_super_use_segment_geometry": {
+		true,
+	},
+	"Get_super_use_segment_linestyle": {
+		true,
+	},
+	"Get_super_use_segment_radius": {
+		true,
+	},
+	"Get_super_use_segment_text_array": {
+		true,
+	},
+	"Get_super_use_segment_text_value": {
+		true,
+	},
+	"Get_super_use_segment_tinability_array": {
+		true,
+	},
+	"Get_super_use_segment_tinability_value": {
+		true,
+	},
+	"Get_super_use_segment_visibility_array": {
+		true,
+	},
+	"Get_super_use_segment_visibility_value": {
+		true,
+	},
+	"Get_super_use_solid": {
+		true,
+	},
+	"Get_super_use_symbol": {
+		true,
+	},
+	"Get_super_use_tinability": {
+		true,
+	},
+	"Get_super_use_vertex_annotation_array": {
+		true,
+	},
+	"Get_super_use_vertex_annotation_value": {
+		true,
+	},
+	"Get_super_use_vertex_attribute": {
+		true,
+	},
+	"Get_super_use_vertex_image_array": {
+		true,
+	},
+	"Get_super_use_vertex_image_value": {
+		true,
+	},
+	"Get_super_use_vertex_point_number": {
+		true,
+	},
+	"Get_super_use_vertex_symbol": {
+		true,
+	},
+	"Get_super_use_vertex_text_array": {
+		true,
+	},
+	"Get_super_use_vertex_text_value": {
+		true,
+	},
+	"Get_super_use_vertex_tinability_array": {
+		true,
+	},
+	"Get_super_use_vertex_tinability_value": {
+		true,
+	},
+	"Get_super_use_vertex_visibility_array": {
+		true,
+	},
+	"Get_super_use_vertex_visibility_value": {
+		true,
+	},
+	"Get_super_use_visibility": {
+		true,
+	},
+	"Get_super_vertex_attribute": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+	},
+	"Get_super_vertex_attribute_length": {
+		true,
+		true,
+	},
+	"Get_super_vertex_attribute_name": {
+		true,
+	},
+	"Get_super_vertex_attribute_type": {
+		true,
+		true,
+	},
+	"Get_super_vertex_attributes": {
+		true,
+	},
+	"Get_super_vertex_backward_direction": {
+		true,
+	},
+	"Get_super_vertex_coord": {
+		true,
+	},
+	"Get_super_vertex_forward_direction": {
+		true,
+	},
+	"Get_super_vertex_number_of_attributes": {
+		true,
+	},
+	"Get_super_vertex_number_of_images": {
+		true,
+	},
+	"Get_super_vertex_point_number": {
+		true,
+		true,
+	},
+	"Get_super_vertex_symbol_colour": {
+		true,
+	},
+	"Get_super_vertex_symbol_offset_height": {
+		true,
+	},
+	"Get_super_vertex_symbol_offset_width": {
+		true,
+	},
+	"Get_super_vertex_symbol_rotation": {
+		true,
+	},
+	"Get_super_vertex_symbol_size": {
+		true,
+	},
+	"Get_super_vertex_symbol_style": {
+		true,
+	},
+	"Get_super_vertex_text": {
+		true,
+	},
+	"Get_super_vertex_text_angle": {
+		true,
+	},
+	"Get_super_vertex_text_angle2": {
+		true,
+	},
+	"Get_super_vertex_text_angle3": {
+		true,
+	},
+	"Get_super_vertex_text_border": {
+		true,
+	},
+	"Get_super_vertex_text_border_style": {
+		true,
+	},
+	"Get_super_vertex_text_colour": {
+		true,
+	},
+	"Get_super_vertex_text_justify": {
+		true,
+	},
+	"Get_super_vertex_text_offset_height": {
+		true,
+	},
+	"Get_super_vertex_text_offset_width": {
+		true,
+	},
+	"Get_super_vertex_text_size": {
+		true,
+	},
+	"Get_super_vertex_text_slant": {
+		true,
+	},
+	"Get_super_vertex_text_style": {
+		true,
+	},
+	"Get_super_vertex_text_ttf_italic": {
+		true,
+	},
+	"Get_super_vertex_text_ttf_outline": {
+		true,
+	},
+	"Get_super_vertex_text_ttf_strikeout": {
+		true,
+	},
+	"Get_super_vertex_text_ttf_underline": {
+		true,
+	},
+	"Get_super_vertex_text_ttf_weight": {
+		true,
+	},
+	"Get_super_vertex_text_type": {
+		true,
+	},
+	"Get_super_vertex_text_whiteout": {
+		true,
+	},
+	"Get_super_vertex_text_x_factor": {
+		true,
+	},
+	"Get_super_vertex_textstyle_data": {
+		true,
+	},
+	"Get_super_vertex_tinability": {
+		true,
+	},
+	"Get_super_vertex_uid": {
+		true,
+	},
+	"Get_super_vertex_visibility": {
+		true,
+	},
+	"Get_symbol_controlbar": {
+		true,
+		true,
+	},
+	"Get_temporary_12d_directory": {
+		true,
+	},
+	"Get_temporary_directory": {
+		true,
+	},
+	"Get_temporary_project_directory": {
+		true,
+	},
+	"Get_text_angle": {
+		true,
+	},
+	"Get_text_angle2": {
+		true,
+	},
+	"Get_text_angle3": {
+		true,
+	},
+	"Get_text_border": {
+		true,
+	},
+	"Get_text_border_style": {
+		true,
+	},
+	"Get_text_controlbar": {
+		true,
+		true,
+	},
+	"Get_text_data": {
+		true,
+		false,
+	},
+	"Get_text_height": {
+		true,
+	},
+	"Get_text_justify": {
+		true,
+	},
+	"Get_text_length": {
+		true,
+	},
+	"Get_text_offset": {
+		true,
+	},
+	"Get_text_rise": {
+		true,
+	},
+	"Get_text_size": {
+		true,
+	},
+	"Get_text_slant": {
+		true,
+	},
+	"Get_text_style": {
+		true,
+	},
+	"Get_text_textstyle_data": {
+		true,
+	},
+	"Get_text_ttf_italic": {
+		true,
+	},
+	"Get_text_ttf_outline": {
+		true,
+	},
+	"Get_text_ttf_strikeout": {
+		true,
+	},
+	"Get_text_ttf_underline": {
+		true,
+	},
+	"Get_text_ttf_weight": {
+		true,
+	},
+	"Get_text_type": {
+		true,
+	},
+	"Get_text_units": {
+		true,
+	},
+	"Get_text_value": {
+		true,
+	},
+	"Get_text_whiteout": {
+		true,
+	},
+	"Get_text_x_factor": {
+		true,
+	},
+	"Get_text_xyz": {
+		true,
+		false,
+	},
+	"Get_textstyle": {
+		true,
+	},
+	"Get_time_created": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Get_time_result_column": {
+		true,
+		true,
+	},
+	"Get_time_updated": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Get_tooltip": {
+		true,
+	},
+	"Get_translation_matrix": {
+		true,
+		true,
+		false,
+		false,
+	},
+	"Get_trimesh_areas": {
+		true,
+		true,
+	},
+	"Get_trimesh_bottom_faces": {
+		true,
+		true,
+	},
+	"Get_trimesh_centroid": {
+		true,
+	},
+	"Get_trimesh_collapsing_faces": {
+		true,
+		true,
+	},
+	"Get_trimesh_surface_area": {
+		true,
+	},
+	"Get_trimesh_surrounding_faces": {
+		true,
+		true,
+	},
+	"Get_trimesh_top_faces": {
+		true,
+		true,
+	},
+	"Get_trimesh_volume": {
+		true,
+	},
+	"Get_ttf_italic": {
+		true,
+	},
+	"Get_ttf_outline": {
+		true,
+	},
+	"Get_ttf_strikeout": {
+		true,
+	},
+	"Get_ttf_underline": {
+		true,
+	},
+	"Get_ttf_weight": {
+		true,
+	},
+	"Get_type": {
+		false,
+		false,
+		false,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+	},
+	"Get_type_like": {
+		true,
+		true,
+	},
+	"Get_user_name": {
+		true,
+	},
+	"Get_vector": {
+		true,
+		true,
+		true,
+		false,
+		true,
+		true,
+		false,
+		false,
+		false,
+	},
+	"Get_vector_homogenize": {
+		true,
+		true,
+		false,
+		false,
+	},
+	"Get_vector_length": {
+		true,
+		true,
+		true,
+		false,
+		false,
+		false,
+	},
+	"Get_vector_length_squared": {
+		true,
+		true,
+		true,
+		false,
+		false,
+		false,
+	},
+	"Get_vector_normalize": {
+		true,
+		true,
+		true,
+		false,
+		false,
+		false,
+	},
+	"Get_vertical_scroll_bar": {
+		true,
+	},
+	"Get_vip_data": {
+		true,
+		true,
+		true,
+	},
+	"Get_vip_geom": {
+		true,
+	},
+	"Get_vip_points": {
+		true,
+	},
+	"Get_vip_type": {
+		true,
+	},
+	"Get_visible": {
+		true,
+	},
+	"Get_weight": {
+		true,
+	},
+	"Get_whiteout": {
+		true,
+	},
+	"Get_widget_position": {
+		true,
+	},
+	"Get_widget_size": {
+		true,
+	},
+	"Get_wildcard": {
+		true,
+	},
+	"Get_word_wrap": {
+		true,
+	},
+	"Get_write_locks": {
+		true,
+	},
+	"Get_x_factor": {
+		true,
+	},
+	"Grid_cell_to_grid": {
+		true,
+		true,
+	},
+	"Grid_cell_to_world": {
+		true,
+		true,
+	},
+	"Grid_get_x_count": {
+		true,
+	},
+	"Grid_get_x_range": {
+		true,
+	},
+	"Grid_get_y_count": {
+		true,
+	},
+	"Grid_get_y_range": {
+		true,
+	},
+	"Grid_grid_to_cell": {
+		true,
+		true,
+	},
+	"Grid_grid_to_world": {
+		true,
+	},
+	"Grid_world_to_cell": {
+		true,
+		true,
+	},
+	"Grid_world_to_grid": {
+		true,
+	},
+	"Head_to_tail": {
+		true,
+	},
+	"Helmert": {
+		true,
+	},
+	"Helmert_2d_Transform": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Helmert_3d_Transform": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Hide_widget": {
+		true,
+	},
+	"Insert_attribute_at_position": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+	},
+	"Insert_hip": {
+		true,
+		true,
+		true,
+	},
+	"Insert_item": {
+		true,
+		false,
+	},
+	"Insert_vip": {
+		true,
+		true,
+		true,
+	},
+	"Interface": {
+		true,
+		true,
+	},
+	"Intersect": {
+		true,
+	},
+	"Intersect_extended": {
+		true,
+	},
+	"Join_strings": {
+		true,
+	},
+	"Justify_prompt": {
+		true,
+	},
+	"License_module_valid": {
+		true,
+	},
+	"Line_cut_string": {
+		true,
+		false,
+	},
+	"Line_cut_trimesh_named_edges": {
+		true,
+	},
+	"Linestyle_prompt": {
+		true,
+	},
+	"Load_row_from_widgets": {
+		true,
+	},
+	"Load_widgets_from_row": {
+		true,
+	},
+	"Locate_point": {
+		true,
+	},
+	"Loop_clean": {
+		true,
+	},
+	"Macro_attribute_delete_all": {
+		false,
+		true,
+	},
+	"Macro_attribute_dump": {
+		true,
+	},
+	"Map_file_add_key": {
+		true,
+		false,
+	},
+	"Map_file_close": {
+		true,
+	},
+	"Map_file_create": {
+		true,
+	},
+	"Map_file_find_key": {
+		true,
+	},
+	"Map_file_get_key": {
+		true,
+		false,
+	},
+	"Map_file_number_of_keys": {
+		true,
+	},
+	"Map_file_open": {
+		true,
+	},
+	"Match_name": {
+		false,
+		true,
+	},
+	"Medial_axis_polygon": {
+		true,
+		true,
+	},
+	"Menu_delete": {
+		true,
+	},
+	"Merge_grids": {
+		true,
+	},
+	"Model_attribute_debug": {
+		true,
+	},
+	"Model_attribute_dump": {
+		true,
+	},
+	"Model_clean": {
+		true,
+	},
+	"Model_delete": {
+		true,
+	},
+	"Model_draw": {
+		true,
+		true,
+	},
+	"Model_duplicate": {
+		true,
+	},
+	"Model_empty": {
+		true,
+	},
+	"Model_get_views": {
+		true,
+	},
+	"Model_prompt": {
+		true,
+	},
+	"Model_rename": {
+		true,
+	},
+	"Move_to": {
+		true,
+	},
+	"Name_prompt": {
+		true,
+	},
+	"Null": {
+		true,
+		true,
+		false,
+		false,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+		true,
+		false,
+	},
+	"Null_by_angle_length": {
+		true,
+	},
+	"Null_item": {
+		true,
+	},
+	"Null_triangles": {
+		true,
+	},
+	"Offset_intersect": {
+		true,
+	},
+	"Offset_intersect_extended": {
+		true,
+	},
+	"Panel_prompt": {
+		true,
+		true,
+	},
+	"Parallel": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Perspective_view_get_eye_point": {
+		true,
+	},
+	"Perspective_view_get_target_point": {
+		true,
+	},
+	"Perspective_view_set_eye_point": {
+		true,
+	},
+	"Perspective_view_set_target_point": {
+		true,
+	},
+	"Place_mesh": {
+		true,
+		true,
+	},
+	"Plan_area": {
+		true,
+		true,
+	},
+	"Plan_area_signed": {
+		true,
+	},
+	"Plan_view_get_plot_scale": {
+		true,
+	},
+	"Plan_view_get_rotation": {
+		true,
+	},
+	"Plan_view_get_viewport": {
+		true,
+	},
+	"Plan_view_set_plot_scale": {
+		true,
+	},
+	"Plan_view_set_rotation": {
+		true,
+	},
+	"Plan_view_set_viewport": {
+		true,
+	},
+	"Play_sound": {
+		true,
+	},
+	"Plot_mps": {
+		true,
+	},
+	"Plot_parameter_file": {
+		true,
+		true,
+	},
+	"Plot_ppf_file": {
+		true,
+	},
+	"Plotter_prompt": {
+		true,
+	},
+	"Print_log_line": {
+		true,
+	},
+	"Project_attribute_delete_all": {
+		false,
+		true,
+	},
+	"Project_attribute_dump": {
+		true,
+	},
+	"Project_prompt": {
+		true,
+	},
+	"Project_save": {
+		true,
+	},
+	"Projection": {
+		true,
+		true,
+	},
+	"Prompt": {
+		false,
+		true,
+		true,
+		true,
+	},
+	"Quick_sort": {
+		true,
+		true,
+		true,
+	},
+	"Read_12d_data": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Read_4d_ascii": {
+		true,
+		true,
+	},
+	"Read_PDF": {
+		true,
+	},
+	"Read_SLX": {
+		true,
+	},
+	"Read_XML_document": {
+		true,
+	},
+	"Read_parameter_file": {
+		true,
+	},
+	"Read_project_settings_file": {
+		true,
+	},
+	"Regrade_pipes": {
+		true,
+		true,
+	},
+	"Remove_model": {
+		true,
+	},
+	"Remove_node": {
+		true,
+	},
+	"Remove_node_attribute": {
+		true,
+	},
+	"Remove_parameter": {
+		true,
+	},
+	"Remove_project_setting": {
+		true,
+	},
+	"Reset_colour_triangles": {
+		true,
+		true,
+	},
+	"Reset_null_triangles": {
+		true,
+		true,
+	},
+	"Resolve_parameter": {
+		true,
+	},
+	"Resolve_parameter_strict": {
+		true,
+	},
+	"Resolve_super_alignment": {
+		true,
+	},
+	"Retriangulate": {
+		true,
+	},
+	"Rollback_transaction": {
+		true,
+	},
+	"Rotate": {
+		true,
+	},
+	"Run_chain": {
+		true,
+	},
+	"Run_drainage_analysis": {
+		true,
+		true,
+	},
+	"Run_drainage_analysis_dynamic": {
+		true,
+		true,
+	},
+	"Run_dws_network": {
+		true,
+		true,
+	},
+	"Run_slf_data": {
+		true,
+		true,
+		true,
+	},
+	"Run_slf_file": {
+		true,
+	},
+	"Section_view_get_viewport": {
+		true,
+	},
+	"Section_view_profile": {
+		true,
+	},
+	"Section_view_regenerate": {
+		true,
+	},
+	"Section_view_set_viewport": {
+		true,
+	},
+	"Select_end": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Select_start": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Set_2d_data": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Set_4d_angle": {
+		true,
+	},
+	"Set_4d_angle2": {
+		true,
+	},
+	"Set_4d_angle3": {
+		true,
+	},
+	"Set_4d_border": {
+		true,
+	},
+	"Set_4d_border_style": {
+		true,
+	},
+	"Set_4d_data": {
+		true,
+		true,
+		true,
+	},
+	"Set_4d_height": {
+		true,
+	},
+	"Set_4d_justify": {
+		true,
+	},
+	"Set_4d_offset": {
+		true,
+	},
+	"Set_4d_rise": {
+		true,
+	},
+	"Set_4d_size": {
+		true,
+	},
+	"Set_4d_slant": {
+		true,
+	},
+	"Set_4d_style": {
+		true,
+	},
+	"Set_4d_textstyle_data": {
+		true,
+	},
+	"Set_4d_ttf_italic": {
+		true,
+	},
+	"Set_4d_ttf_outline": {
+		true,
+	},
+	"Set_4d_ttf_strikeout": {
+		true,
+	},
+	"Set_4d_ttf_underline": {
+		true,
+	},
+	"Set_4d_ttf_weight": {
+		true,
+	},
+	"Set_4d_units": {
+		true,
+	},
+	"Set_4d_whiteout": {
+		true,
+	},
+	"Set_4d_x_factor": {
+		true,
+	},
+	"Set_XML_declaration": {
+		true,
+	},
+	"Set_active_project_settings_profile": {
+		true,
+	},
+	"Set_all_tin_modes": {
+		true,
+	},
+	"Set_all_tin_types": {
+		true,
+	},
+	"Set_all_view_types": {
+		true,
+	},
+	"Set_allow_holes": {
+		true,
+	},
+	"Set_anchor": {
+		true,
+		true,
+	},
+	"Set_angle": {
+		true,
+	},
+	"Set_angle2": {
+		true,
+	},
+	"Set_angle3": {
+		true,
+	},
+	"Set_apply_many_function_properties": {
+		true,
+	},
+	"Set_arc": {
+		true,
+	},
+	"Set_arc_centre": {
+		true,
+	},
+	"Set_arc_chord_arc": {
+		true,
+	},
+	"Set_arc_data": {
+		true,
+	},
+	"Set_arc_end": {
+		true,
+	},
+	"Set_arc_interval": {
+		true,
+	},
+	"Set_arc_radius": {
+		true,
+	},
+	"Set_arc_start": {
+		true,
+	},
+	"Set_attribute": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+	},
+	"Set_attribute_by_type": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+	},
+	"Set_attributes": {
+		true,
+	},
+	"Set_attributes_controlbar": {
+		true,
+	},
+	"Set_auto_cut_paste": {
+		true,
+		false,
+	},
+	"Set_border": {
+		true,
+		true,
+		true,
+		true,
+		true,
+	},
+	"Set_border_style": {
+		true,
+	},
+	"Set_breakline": {
+		true,
+	},
+	"Set_cad_controlbar": {
+		true,
+	},
+	"Set_caret": {
+		true,
+		false,
+	},
+	"Set_cell": {
+		true,
+	},
+	"Set_cell_back_colour": {
+		true,
+	},
+	"Set_cell_colour": {
+		true,
+	},
+	"Set_cell_read_only": {
+		true,
+	},
+	"Set_cell_text_colour": {
+		true,
+	},
+	"Set_chainage": {
+		true,
+	},
+	"Set_char": {
+		true,
+	},
+	"Set_circle_data": {
+		true,
+	},
+	"Set_clipboard_text": {
+		true,
+	},
+	"Set_colour": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Set_colour_value": {
+		true,
+	},
+	"Set_column_width": {
+		true,
+	},
+	"Set_cursor_position": {
+		true,
+		true,
+	},
+	"Set_curve": {
+		true,
+	},
+	"Set_data": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+	},
+	"Set_direction": {
+		true,
+		true,
+	},
+	"Set_directory": {
+		true,
+	},
+	"Set_drainage_data": {
+		true,
+		true,
+		true,
+	},
+	"Set_drainage_float": {
+		true,
+	},
+	"Set_drainage_flow": {
+		true,
+	},
+	"Set_drainage_fs_tin": {
+		true,
+	},
+	"Set_drainage_hc_adopted_level": {
+		true,
+	},
+	"Set_drainage_hc_bush": {
+		true,
+	},
+	"Set_drainage_hc_colour": {
+		true,
+	},
+	"Set_drainage_hc_depth": {
+		true,
+	},
+	"Set_drainage_hc_diameter": {
+		true,
+	},
+	"Set_drainage_hc_grade": {
+		true,
+	},
+	"Set_drainage_hc_hcb": {
+		true,
+	},
+	"Set_drainage_hc_length": {
+		true,
+	},
+	"Set_drainage_hc_level": {
+		true,
+	},
+	"Set_drainage_hc_material": {
+		true,
+	},
+	"Set_drainage_hc_name": {
+		true,
+	},
+	"Set_drainage_hc_side": {
+		true,
+	},
+	"Set_drainage_hc_type": {
+		true,
+	},
+	"Set_drainage_ns_tin": {
+		true,
+	},
+	"Set_drainage_outfall_height": {
+		true,
+	},
+	"Set_drainage_pc_boundary": {
+		true,
+	},
+	"Set_drainage_pc_colour": {
+		true,
+	},
+	"Set_drainage_pc_cover": {
+		true,
+	},
+	"Set_drainage_pc_diameter": {
+		true,
+	},
+	"Set_drainage_pc_grade": {
+		true,
+	},
+	"Set_drainage_pc_name": {
+		true,
+	},
+	"Set_drainage_pc_start_level": {
+		true,
+	},
+	"Set_drainage_pipe_attribute": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+	},
+	"Set_drainage_pipe_attributes": {
+		true,
+	},
+	"Set_drainage_pipe_colour": {
+		true,
+	},
+	"Set_drainage_pipe_cover": {
+		true,
+	},
+	"Set_drainage_pipe_diameter": {
+		true,
+	},
+	"Set_drainage_pipe_flow": {
+		true,
+	},
+	"Set_drainage_pipe_hgls": {
+		true,
+	},
+	"Set_drainage_pipe_inverts": {
+		true,
+	},
+	"Set_drainage_pipe_name": {
+		true,
+	},
+	"Set_drainage_pipe_nominal_diameter": {
+		true,
+	},
+	"Set_drainage_pipe_number_of_pipes": {
+		true,
+	},
+	"Set_drainage_pipe_separation": {
+		true,
+	},
+	"Set_drainage_pipe_thickness": {
+		true,
+	},
+	"Set_drainage_pipe_top_width": {
+		true,
+	},
+	"Set_drainage_pipe_type": {
+		true,
+	},
+	"Set_drainage_pipe_velocity": {
+		true,
+	},
+	"Set_drainage_pipe_width": {
+		true,
+	},
+	"Set_drainage_pit": {
+		true,
+	},
+	"Set_drainage_pit_2d_connection_mode": {
+		true,
+	},
+	"Set_drainage_pit_attribute": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+	},
+	"Set_drainage_pit_attributes": {
+		true,
+	},
+	"Set_drainage_pit_base_angle": {
+		true,
+	},
+	"Set_drainage_pit_base_angle_mode": {
+		true,
+	},
+	"Set_drainage_pit_base_height": {
+		true,
+	},
+	"Set_drainage_pit_base_thickness_top": {
+		true,
+	},
+	"Set_drainage_pit_colour": {
+		true,
+	},
+	"Set_drainage_pit_connection_points_mode": {
+		true,
+	},
+	"Set_drainage_pit_diameter": {
+		true,
+	},
+	"Set_drainage_pit_extended": {
+		true,
+	},
+	"Set_drainage_pit_float": {
+		true,
+	},
+	"Set_drainage_pit_float_sump": {
+		true,
+	},
+	"Set_drainage_pit_hgl": {
+		true,
+	},
+	"Set_drainage_pit_hgls": {
+		true,
+	},
+	"Set_drainage_pit_inverts": {
+		true,
+	},
+	"Set_drainage_pit_length": {
+		true,
+	},
+	"Set_drainage_pit_name": {
+		true,
+	},
+	"Set_drainage_pit_riser": {
+		true,
+	},
+	"Set_drainage_pit_riser_colour": {
+		true,
+	},
+	"Set_drainage_pit_riser_diameter": {
+		true,
+	},
+	"Set_drainage_pit_riser_extended": {
+		true,
+	},
+	"Set_drainage_pit_riser_offset_xy": {
+		true,
+	},
+	"Set_drainage_pit_riser_thickness": {
+		true,
+	},
+	"Set_drainage_pit_riser_width": {
+		true,
+	},
+	"Set_drainage_pit_road_chainage": {
+		true,
+	},
+	"Set_drainage_pit_road_name": {
+		true,
+	},
+	"Set_drainage_pit_sump_level": {
+		true,
+	},
+	"Set_drainage_pit_surface_hgl": {
+		true,
+	},
+	"Set_drainage_pit_symbol_angle": {
+		true,
+	},
+	"Set_drainage_pit_symbol_angle_mode": {
+		true,
+	},
+	"Set_drainage_pit_thickness": {
+		true,
+	},
+	"Set_drainage_pit_type": {
+		true,
+	},
+	"Set_drainage_pit_width": {
+		true,
+	},
+	"Set_drainage_sewer": {
+		true,
+	},
+	"Set_drainage_use_connection_points": {
+		true,
+	},
+	"Set_dump_name": {
+		true,
+	},
+	"Set_dynamic_sizing": {
+		true,
+	},
+	"Set_enable": {
+		true,
+	},
+	"Set_encoding": {
+		true,
+	},
+	"Set_end": {
+		false,
+		false,
+		true,
+		false,
+	},
+	"Set_end_height": {
+		true,
+		true,
+	},
+	"Set_end_length": {
+		true,
+		true,
+	},
+	"Set_equality_label_data": {
+		true,
+		true,
+	},
+	"Set_error_message": {
+		true,
+	},
+	"Set_face_data": {
+		true,
+	},
+	"Set_face_edge_colour": {
+		true,
+	},
+	"Set_face_edge_mode": {
+		true,
+	},
+	"Set_face_fill_mode": {
+		true,
+	},
+	"Set_face_hatch_angle": {
+		true,
+	},
+	"Set_face_hatch_colour": {
+		true,
+	},
+	"Set_face_hatch_distance": {
+		true,
+	},
+	"Set_face_hatch_mode": {
+		true,
+	},
+	"Set_feature_centre": {
+		true,
+	},
+	"Set_feature_radius": {
+		true,
+	},
+	"Set_file_read_only": {
+		true,
+	},
+	"Set_fixed_row_count": {
+		true,
+	},
+	"Set_focus": {
+		true,
+	},
+	"Set_format": {
+		true,
+	},
+	"Set_function_attribute": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+	},
+	"Set_function_attributes": {
+		true,
+		true,
+	},
+	"Set_function_id": {
+		true,
+		true,
+	},
+	"Set_function_value": {
+		true,
+	},
+	"Set_gap": {
+		true,
+		true,
+	},
+	"Set_gmt": {
+		true,
+	},
+	"Set_grid_geometry": {
+		true,
+	},
+	"Set_grid_height": {
+		true,
+	},
+	"Set_grid_heights": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Set_grid_range": {
+		true,
+	},
+	"Set_grid_value": {
+		true,
+	},
+	"Set_height": {
+		true,
+	},
+	"Set_help": {
+		true,
+		true,
+	},
+	"Set_hip_data": {
+		true,
+		true,
+		true,
+	},
+	"Set_horizontal_scroll_bar": {
+		true,
+	},
+	"Set_inquire_style": {
+		true,
+	},
+	"Set_integer_value": {
+		true,
+	},
+	"Set_interface_data": {
+		true,
+		true,
+		true,
+	},
+	"Set_item": {
+		true,
+		true,
+		true,
+		true,
+		true,
+	},
+	"Set_justify": {
+		true,
+	},
+	"Set_leading": {
+		true,
+		true,
+	},
+	"Set_length": {
+		true,
+		false,
+	},
+	"Set_level": {
+		true,
+	},
+	"Set_libraries": {
+		true,
+	},
+	"Set_limit": {
+		true,
+	},
+	"Set_line": {
+		true,
+	},
+	"Set_macro_attribute": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+	},
+	"Set_macro_attributes": {
+		true,
+	},
+	"Set_many": {
+		true,
+	},
+	"Set_matrix": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Set_matrix_identity": {
+		true,
+		true,
+	},
+	"Set_matrix_row": {
+		true,
+		true,
+	},
+	"Set_matrix_zero": {
+		true,
+		true,
+	},
+	"Set_message_mode": {
+		true,
+	},
+	"Set_model": {
+		true,
+		true,
+		true,
+	},
+	"Set_model_attribute": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+	},
+	"Set_model_attributes": {
+		true,
+	},
+	"Set_model_value": {
+		true,
+	},
+	"Set_modified": {
+		true,
+	},
+	"Set_multi_line": {
+		true,
+	},
+	"Set_name": {
+		true,
+		true,
+		true,
+	},
+	"Set_node_attribute": {
+		true,
+	},
+	"Set_node_text": {
+		true,
+	},
+	"Set_offset": {
+		true,
+		true,
+	},
+	"Set_optional": {
+		true,
+	},
+	"Set_origin": {
+		true,
+	},
+	"Set_page": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+		false,
+		false,
+		false,
+	},
+	"Set_parameter": {
+		true,
+		true,
+	},
+	"Set_pipe_controlbar": {
+		true,
+	},
+	"Set_pipe_data": {
+		true,
+		true,
+		true,
+	},
+	"Set_pipe_diameter": {
+		true,
+	},
+	"Set_pipe_justify": {
+		true,
+	},
+	"Set_pipeline_culvert": {
+		true,
+	},
+	"Set_pipeline_diameter": {
+		true,
+	},
+	"Set_pipeline_justification": {
+		true,
+	},
+	"Set_pipeline_length": {
+		true,
+	},
+	"Set_pipeline_shape": {
+		true,
+	},
+	"Set_pit_details": {
+		true,
+		true,
+	},
+	"Set_plot_frame_colour": {
+		true,
+	},
+	"Set_plot_frame_draw_border": {
+		true,
+	},
+	"Set_plot_frame_draw_title_file": {
+		true,
+	},
+	"Set_plot_frame_draw_viewport": {
+		true,
+	},
+	"Set_plot_frame_margins": {
+		true,
+	},
+	"Set_plot_frame_name": {
+		true,
+	},
+	"Set_plot_frame_origin": {
+		true,
+	},
+	"Set_plot_frame_plot_file": {
+		true,
+	},
+	"Set_plot_frame_plotter": {
+		true,
+	},
+	"Set_plot_frame_plotter_name": {
+		true,
+	},
+	"Set_plot_frame_rotation": {
+		true,
+	},
+	"Set_plot_frame_scale": {
+		true,
+	},
+	"Set_plot_frame_sheet_size": {
+		true,
+		true,
+	},
+	"Set_plot_frame_text_size": {
+		true,
+	},
+	"Set_plot_frame_textstyle": {
+		true,
+	},
+	"Set_plot_frame_title_1": {
+		true,
+	},
+	"Set_plot_frame_title_2": {
+		true,
+	},
+	"Set_plot_frame_title_file": {
+		true,
+	},
+	"Set_point": {
+		true,
+	},
+	"Set_polyline_data": {
+		true,
+		true,
+		true,
+	},
+	"Set_project_attribute": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+	},
+	"Set_project_attributes": {
+		true,
+	},
+	"Set_property": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+	},
+	"Set_property_colour": {
+		true,
+	},
+	"Set_radius": {
+		false,
+		true,
+	},
+	"Set_raise": {
+		true,
+	},
+	"Set_raised_button": {
+		true,
+	},
+	"Set_read_only": {
+		true,
+	},
+	"Set_real_value": {
+		true,
+	},
+	"Set_scale": {
+		true,
+	},
+	"Set_select_coordinate": {
+		true,
+		true,
+		true,
+		false,
+	},
+	"Set_select_direction": {
+		true,
+		true,
+		true,
+		false,
+	},
+	"Set_select_snap_mode": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+	},
+	"Set_select_type": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Set_selection": {
+		true,
+		false,
+	},
+	"Set_selection_list": {
+		true,
+		false,
+	},
+	"Set_selections": {
+		true,
+		false,
+	},
+	"Set_setups": {
+		true,
+	},
+	"Set_show_encodings": {
+		true,
+	},
+	"Set_size": {
+		true,
+		true,
+	},
+	"Set_sizing_constraints": {
+		true,
+	},
+	"Set_slant": {
+		true,
+	},
+	"Set_slider_position": {
+		true,
+	},
+	"Set_sort": {
+		true,
+		false,
+	},
+	"Set_spiral": {
+		true,
+	},
+	"Set_start": {
+		false,
+		false,
+		true,
+		false,
+	},
+	"Set_start_height": {
+		true,
+		true,
+	},
+	"Set_start_length": {
+		true,
+		true,
+	},
+	"Set_string_value": {
+		true,
+	},
+	"Set_style": {
+		true,
+	},
+	"Set_super_2d_level": {
+		true,
+	},
+	"Set_super_acad_pattern": {
+		true,
+	},
+	"Set_super_acad_pattern_angle": {
+		true,
+	},
+	"Set_super_acad_pattern_colour": {
+		true,
+	},
+	"Set_super_acad_pattern_device": {
+		true,
+	},
+	"Set_super_acad_pattern_paper": {
+		true,
+	},
+	"Set_super_acad_pattern_size": {
+		true,
+	},
+	"Set_super_acad_pattern_type": {
+		true,
+	},
+	"Set_super_acad_pattern_view_angle": {
+		true,
+	},
+	"Set_super_acad_pattern_world": {
+		true,
+	},
+	"Set_super_alignment_equalities_active": {
+		true,
+	},
+	"Set_super_alignment_style": {
+		true,
+	},
+	"Set_super_alignment_use_equalities": {
+		true,
+	},
+	"Set_super_bitmap": {
+		true,
+	},
+	"Set_super_bitmap_angle": {
+		true,
+	},
+	"Set_super_bitmap_device": {
+		true,
+	},
+	"Set_super_bitmap_origin": {
+		true,
+	},
+	"Set_super_bitmap_paper": {
+		true,
+	},
+	"Set_super_bitmap_size": {
+		true,
+	},
+	"Set_super_bitmap_space": {
+		true,
+	},
+	"Set_super_bitmap_stagger": {
+		true,
+	},
+	"Set_super_bitmap_transparent": {
+		true,
+	},
+	"Set_super_bitmap_type": {
+		true,
+	},
+	"Set_super_bitmap_view_angle": {
+		true,
+	},
+	"Set_super_bitmap_world": {
+		true,
+	},
+	"Set_super_culvert": {
+		true,
+		true,
+	},
+	"Set_super_data": {
+		true,
+		true,
+		true,
+	},
+	"Set_super_extrude": {
+		true,
+	},
+	"Set_super_hatch_angle": {
+		true,
+	},
+	"Set_super_hatch_colour": {
+		true,
+	},
+	"Set_super_hatch_device": {
+		true,
+	},
+	"Set_super_hatch_origin": {
+		true,
+	},
+	"Set_super_hatch_plot_spacing": {
+		true,
+	},
+	"Set_super_hatch_spacing": {
+		true,
+	},
+	"Set_super_hatch_type": {
+		true,
+	},
+	"Set_super_hatch_view_angle": {
+		true,
+	},
+	"Set_super_hatch_world": {
+		true,
+	},
+	"Set_super_interval_chord_arc": {
+		true,
+	},
+	"Set_super_interval_distance": {
+		true,
+	},
+	"Set_super_pattern": {
+		true,
+	},
+	"Set_super_pattern_angle": {
+		true,
+	},
+	"Set_super_pattern_blend": {
+		true,
+	},
+	"Set_super_pattern_colour": {
+		true,
+	},
+	"Set_super_pattern_origin": {
+		true,
+	},
+	"Set_super_pattern_plot_size": {
+		true,
+	},
+	"Set_super_pattern_size": {
+		true,
+	},
+	"Set_super_pattern_solid_colour": {
+		true,
+	},
+	"Set_super_pattern_space": {
+		true,
+	},
+	"Set_super_pattern_stagger": {
+		true,
+	},
+	"Set_super_pattern_type": {
+		true,
+	},
+	"Set_super_pattern_view_angle": {
+		true,
+	},
+	"Set_super_pipe": {
+		true,
+		false,
+	},
+	"Set_super_pipe_justify": {
+		true,
+	},
+	"Set_super_segment_attribute": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+	},
+	"Set_super_segment_attributes": {
+		true,
+	},
+	"Set_super_segment_colour": {
+		true,
+	},
+	"Set_super_segment_culvert": {
+		true,
+		true,
+	},
+	"Set_super_segment_curve": {
+		true,
+	},
+	"Set_super_segment_device_text": {
+		true,
+	},
+	"Set_super_segment_diameter": {
+		true,
+	},
+	"Set_super_segment_geometry": {
+		true,
+		true,
+	},
+	"Set_super_segment_linestyle": {
+		true,
+	},
+	"Set_super_segment_major": {
+		true,
+	},
+	"Set_super_segment_paper_text": {
+		true,
+	},
+	"Set_super_segment_pipe": {
+		true,
+		true,
+	},
+	"Set_super_segment_radius": {
+		true,
+	},
+	"Set_super_segment_spiral": {
+		true,
+		true,
+	},
+	"Set_super_segment_text": {
+		true,
+	},
+	"Set_super_segment_text_angle": {
+		true,
+	},
+	"Set_super_segment_text_angle2": {
+		true,
+	},
+	"Set_super_segment_text_angle3": {
+		true,
+	},
+	"Set_super_segment_text_border": {
+		true,
+	},
+	"Set_super_segment_text_border_style": {
+		true,
+	},
+	"Set_super_segment_text_colour": {
+		true,
+	},
+	"Set_super_segment_text_justify": {
+		true,
+	},
+	"Set_super_segment_text_offset_height": {
+		true,
+	},
+	"Set_super_segment_text_offset_width": {
+		true,
+	},
+	"Set_super_segment_text_size": {
+		true,
+	},
+	"Set_super_segment_text_slant": {
+		true,
+	},
+	"Set_super_segment_text_style": {
+		true,
+	},
+	"Set_super_segment_text_ttf_italic": {
+		true,
+	},
+	"Set_super_segment_text_ttf_outline": {
+		true,
+	},
+	"Set_super_segment_text_ttf_strikeout": {
+		true,
+	},
+	"Set_super_segment_text_ttf_underline": {
+		true,
+	},
+	"Set_super_segment_text_ttf_weight": {
+		true,
+	},
+	"Set_super_segment_text_type": {
+		true,
+	},
+	"Set_super_segment_text_whiteout": {
+		true,
+	},
+	"Set_super_segment_text_x_factor": {
+		true,
+	},
+	"Set_super_segment_textstyle_data": {
+		true,
+	},
+	"Set_super_segment_tinability": {
+		true,
+	},
+	"Set_super_segment_uid": {
+		true,
+	},
+	"Set_super_segment_visibility": {
+		true,
+	},
+	"Set_super_segment_world_text": {
+		true,
+	},
+	"Set_super_solid_blend": {
+		true,
+	},
+	"Set_super_solid_colour": {
+		true,
+	},
+	"Set_super_use_2d_level": {
+		true,
+	},
+	"Set_super_use_3d_level": {
+		true,
+	},
+	"Set_super_use_acad_pattern": {
+		true,
+	},
+	"Set_super_use_bitmap": {
+		true,
+	},
+	"Set_super_use_culvert": {
+		true,
+	},
+	"Set_super_use_diameter": {
+		true,
+	},
+	"Set_super_use_extrude": {
+		true,
+	},
+	"Set_super_use_hatch": {
+		true,
+	},
+	"Set_super_use_hole": {
+		true,
+	},
+	"Set_super_use_interval": {
+		true,
+	},
+	"Set_super_use_pattern": {
+		true,
+	},
+	"Set_super_use_pipe": {
+		true,
+	},
+	"Set_super_use_pipe_justify": {
+		true,
+	},
+	"Set_super_use_segment_annotation_array": {
+		true,
+	},
+	"Set_super_use_segment_annotation_value": {
+		true,
+	},
+	"Set_super_use_segment_attribute": {
+		true,
+	},
+	"Set_super_use_segment_colour": {
+		true,
+	},
+	"Set_super_use_segment_culvert": {
+		true,
+	},
+	"Set_super_use_segment_diameter": {
+		true,
+	},
+	"Set_super_use_segment_geometry": {
+		true,
+	},
+	"Set_super_use_segment_linestyle": {
+		true,
+	},
+	"Set_super_use_segment_pipe": {
+		true,
+	},
+	"Set_super_use_segment_radius": {
+		true,
+	},
+	"Set_super_use_segment_text_array": {
+		true,
+	},
+	"Set_super_use_segment_text_value": {
+		true,
+	},
+	"Set_super_use_segment_tinability_array": {
+		true,
+	},
+	"Set_super_use_segment_tinability_value": {
+		true,
+	},
+	"Set_super_use_segment_uid": {
+		true,
+	},
+	"Set_super_use_segment_visibility_array": {
+		true,
+	},
+	"Set_super_use_segment_visibility_value": {
+		true,
+	},
+	"Set_super_use_solid": {
+		true,
+	},
+	"Set_super_use_symbol": {
+		true,
+	},
+	"Set_super_use_tinability": {
+		true,
+	},
+	"Set_super_use_vertex_annotation_array": {
+		true,
+	},
+	"Set_super_use_vertex_annotation_value": {
+		true,
+	},
+	"Set_super_use_vertex_attribute": {
+		true,
+	},
+	"Set_super_use_vertex_image_array": {
+		true,
+	},
+	"Set_super_use_vertex_image_value": {
+		true,
+	},
+	"Set_super_use_vertex_point_number": {
+		true,
+	},
+	"Set_super_use_vertex_symbol": {
+		true,
+	},
+	"Set_super_use_vertex_text_array": {
+		true,
+	},
+	"Set_super_use_vertex_text_value": {
+		true,
+	},
+	"Set_super_use_vertex_tinability_array": {
+		true,
+	},
+	"Set_super_use_vertex_tinability_value": {
+		true,
+	},
+	"Set_super_use_vertex_uid": {
+		true,
+	},
+	"Set_super_use_vertex_visibility_array": {
+		true,
+	},
+	"Set_super_use_vertex_visibility_value": {
+		true,
+	},
+	"Set_super_use_visibility": {
+		true,
+	},
+	"Set_super_vertex_attribute": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+	},
+	"Set_super_vertex_attributes": {
+		true,
+	},
+	"Set_super_vertex_coord": {
+		true,
+	},
+	"Set_super_vertex_device_text": {
+		true,
+	},
+	"Set_super_vertex_paper_text": {
+		true,
+	},
+	"Set_super_vertex_point_number": {
+		true,
+		true,
+	},
+	"Set_super_vertex_symbol_colour": {
+		true,
+	},
+	"Set_super_vertex_symbol_offset_height": {
+		true,
+	},
+	"Set_super_vertex_symbol_offset_width": {
+		true,
+	},
+	"Set_super_vertex_symbol_rotation": {
+		true,
+	},
+	"Set_super_vertex_symbol_size": {
+		true,
+	},
+	"Set_super_vertex_symbol_style": {
+		true,
+	},
+	"Set_super_vertex_text": {
+		true,
+	},
+	"Set_super_vertex_text_angle": {
+		true,
+	},
+	"Set_super_vertex_text_angle2": {
+		true,
+	},
+	"Set_super_vertex_text_angle3": {
+		true,
+	},
+	"Set_super_vertex_text_border": {
+		true,
+	},
+	"Set_super_vertex_text_border_style": {
+		true,
+	},
+	"Set_super_vertex_text_colour": {
+		true,
+	},
+	"Set_super_vertex_text_justify": {
+		true,
+	},
+	"Set_super_vertex_text_offset_height": {
+		true,
+	},
+	"Set_super_vertex_text_offset_width": {
+		true,
+	},
+	"Set_super_vertex_text_size": {
+		true,
+	},
+	"Set_super_vertex_text_slant": {
+		true,
+	},
+	"Set_super_vertex_text_style": {
+		true,
+	},
+	"Set_super_vertex_text_ttf_italic": {
+		true,
+	},
+	"Set_super_vertex_text_ttf_outline": {
+		true,
+	},
+	"Set_super_vertex_text_ttf_strikeout": {
+		true,
+	},
+	"Set_super_vertex_text_ttf_underline": {
+		true,
+	},
+	"Set_super_vertex_text_ttf_weight": {
+		true,
+	},
+	"Set_super_vertex_text_type": {
+		true,
+	},
+	"Set_super_vertex_text_whiteout": {
+		true,
+	},
+	"Set_super_vertex_text_x_factor": {
+		true,
+	},
+	"Set_super_vertex_textstyle_data": {
+		true,
+	},
+	"Set_super_vertex_tinability": {
+		true,
+	},
+	"Set_super_vertex_uid": {
+		true,
+	},
+	"Set_super_vertex_visibility": {
+		true,
+	},
+	"Set_super_vertex_world_text": {
+		true,
+	},
+	"Set_supertin": {
+		true,
+	},
+	"Set_symbol_controlbar": {
+		true,
+		true,
+	},
+	"Set_text_align": {
+		true,
+	},
+	"Set_text_angle": {
+		true,
+	},
+	"Set_text_angle2": {
+		true,
+	},
+	"Set_text_angle3": {
+		true,
+	},
+	"Set_text_border": {
+		true,
+	},
+	"Set_text_border_style": {
+		true,
+	},
+	"Set_text_colour": {
+		true,
+	},
+	"Set_text_controlbar": {
+		true,
+		true,
+	},
+	"Set_text_data": {
+		true,
+	},
+	"Set_text_font": {
+		true,
+	},
+	"Set_text_height": {
+		true,
+	},
+	"Set_text_justify": {
+		true,
+	},
+	"Set_text_offset": {
+		true,
+	},
+	"Set_text_rise": {
+		true,
+	},
+	"Set_text_size": {
+		true,
+	},
+	"Set_text_slant": {
+		true,
+	},
+	"Set_text_style": {
+		true,
+	},
+	"Set_text_textstyle_data": {
+		true,
+	},
+	"Set_text_ttf_italic": {
+		true,
+	},
+	"Set_text_ttf_outline": {
+		true,
+	},
+	"Set_text_ttf_strikeout": {
+		true,
+	},
+	"Set_text_ttf_underline": {
+		true,
+	},
+	"Set_text_ttf_weight": {
+		true,
+	},
+	"Set_text_type": {
+		true,
+	},
+	"Set_text_units": {
+		true,
+	},
+	"Set_text_value": {
+		true,
+		true,
+	},
+	"Set_text_weight": {
+		true,
+	},
+	"Set_text_whiteout": {
+		true,
+	},
+	"Set_text_x_factor": {
+		true,
+	},
+	"Set_text_xyz": {
+		true,
+		false,
+	},
+	"Set_textstyle": {
+		true,
+	},
+	"Set_tick_value": {
+		true,
+	},
+	"Set_time_updated": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Set_tin_access": {
+		true,
+		true,
+	},
+	"Set_tin_mode": {
+		true,
+		true,
+	},
+	"Set_tin_type": {
+		true,
+		true,
+		true,
+	},
+	"Set_tin_value": {
+		true,
+	},
+	"Set_tooltip": {
+		true,
+	},
+	"Set_trimesh_edge_info_by_string": {
+		true,
+	},
+	"Set_ttf_italic": {
+		true,
+	},
+	"Set_ttf_outline": {
+		true,
+	},
+	"Set_ttf_strikeout": {
+		true,
+	},
+	"Set_ttf_underline": {
+		true,
+	},
+	"Set_ttf_weight": {
+		true,
+	},
+	"Set_type": {
+		false,
+		true,
+		true,
+		true,
+	},
+	"Set_vector": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+	},
+	"Set_vertical_scroll_bar": {
+		true,
+	},
+	"Set_view_engine": {
+		true,
+	},
+	"Set_view_type": {
+		true,
+		true,
+		true,
+	},
+	"Set_vip_data": {
+		true,
+		true,
+		true,
+	},
+	"Set_visible": {
+		true,
+	},
+	"Set_warn_on_modified": {
+		true,
+	},
+	"Set_weight": {
+		true,
+	},
+	"Set_whiteout": {
+		true,
+	},
+	"Set_width_in_chars": {
+		true,
+	},
+	"Set_wildcard": {
+		true,
+	},
+	"Set_word_wrap": {
+		true,
+	},
+	"Set_x_factor": {
+		true,
+	},
+	"Share_status": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Sheet_size_prompt": {
+		true,
+	},
+	"Shell_execute": {
+		true,
+	},
+	"Shift_grid_range": {
+		true,
+	},
+	"Show_browse_button": {
+		true,
+	},
+	"Show_console": {
+		true,
+	},
+	"Show_widget": {
+		true,
+		true,
+	},
+	"Sleep": {
+		true,
+	},
+	"Split_string": {
+		true,
+	},
+	"Start_batch_draw": {
+		true,
+	},
+	"String_close": {
+		true,
+	},
+	"String_closed": {
+		true,
+	},
+	"String_open": {
+		true,
+	},
+	"String_replace": {
+		true,
+	},
+	"String_reverse": {
+		true,
+	},
+	"String_self_intersects": {
+		true,
+	},
+	"Super_alignment_equality_part_append": {
+		true,
+	},
+	"Super_alignment_equality_part_delete": {
+		true,
+	},
+	"Super_alignment_equality_part_insert": {
+		true,
+	},
+	"Super_alignment_validate": {
+		true,
+	},
+	"Super_append_extrude": {
+		true,
+	},
+	"Super_append_string_extrude": {
+		true,
+		false,
+	},
+	"Super_delete_all_extrudes": {
+		true,
+	},
+	"Super_delete_all_holes": {
+		true,
+	},
+	"Super_delete_extrude": {
+		true,
+	},
+	"Super_delete_hole": {
+		true,
+		true,
+	},
+	"Super_get_hole": {
+		true,
+	},
+	"Super_insert_extrude": {
+		true,
+	},
+	"Super_insert_vertex": {
+		true,
+	},
+	"Super_offset": {
+		true,
+	},
+	"Super_remove_vertex": {
+		true,
+	},
+	"Super_segment_annotate_value_to_array": {
+		true,
+	},
+	"Super_segment_attribute_exists": {
+		false,
+		true,
+	},
+	"Super_segment_text_value_to_array": {
+		true,
+	},
+	"Super_vertex_annotate_value_to_array": {
+		true,
+	},
+	"Super_vertex_attribute_debug": {
+		true,
+	},
+	"Super_vertex_attribute_delete_all": {
+		true,
+	},
+	"Super_vertex_attribute_dump": {
+		true,
+	},
+	"Super_vertex_attribute_exists": {
+		true,
+		false,
+	},
+	"Super_vertex_image_delete": {
+		true,
+	},
+	"Super_vertex_image_delete_all": {
+		true,
+	},
+	"Super_vertex_image_value_to_array": {
+		true,
+	},
+	"Super_vertex_level_value_to_array": {
+		true,
+	},
+	"Super_vertex_symbol_value_to_array": {
+		true,
+	},
+	"Super_vertex_text_value_to_array": {
+		true,
+	},
+	"Supertin_get_tin": {
+		true,
+	},
+	"Surface_area_tin_polygon": {
+		true,
+	},
+	"Swap_matrix_cols": {
+		true,
+		true,
+		true,
+	},
+	"Swap_matrix_rows": {
+		true,
+		true,
+	},
+	"Swap_xy": {
+		true,
+	},
+	"Synergy_build_attribute_string": {
+		true,
+	},
+	"Synergy_cancel_checkout": {
+		true,
+	},
+	"Synergy_check_in_entity": {
+		true,
+	},
+	"Synergy_check_out": {
+		true,
+	},
+	"Synergy_connect": {
+		true,
+		true,
+	},
+	"Synergy_get": {
+		true,
+		true,
+	},
+	"Synergy_get_workspace_path": {
+		true,
+		false,
+	},
+	"Synergy_local_folder": {
+		true,
+	},
+	"System": {
+		true,
+	},
+	"Tangent": {
+		true,
+	},
+	"Template_prompt": {
+		true,
+	},
+	"Template_rename": {
+		true,
+	},
+	"Text_units_prompt": {
+		true,
+	},
+	"Textstyle_prompt": {
+		true,
+	},
+	"Tile_all_views": {
+		true,
+	},
+	"Time": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Tin_aspect": {
+		true,
+	},
+	"Tin_boundary": {
+		true,
+	},
+	"Tin_colour": {
+		true,
+	},
+	"Tin_delete": {
+		true,
+	},
+	"Tin_drop_point_3d": {
+		true,
+	},
+	"Tin_duplicate": {
+		true,
+	},
+	"Tin_get_point": {
+		true,
+	},
+	"Tin_get_point_from_point": {
+		true,
+	},
+	"Tin_get_triangle": {
+		true,
+	},
+	"Tin_get_triangle_colour": {
+		true,
+	},
+	"Tin_get_triangle_from_point": {
+		true,
+	},
+	"Tin_get_triangle_inside": {
+		true,
+	},
+	"Tin_get_triangle_neighbours": {
+		true,
+	},
+	"Tin_get_triangle_points": {
+		true,
+	},
+	"Tin_get_triangles_about_point": {
+		true,
+		true,
+	},
+	"Tin_height": {
+		true,
+	},
+	"Tin_models": {
+		true,
+	},
+	"Tin_null_by_colour": {
+		true,
+	},
+	"Tin_null_by_colours": {
+		true,
+	},
+	"Tin_number_of_duplicate_points": {
+		true,
+	},
+	"Tin_number_of_items": {
+		true,
+	},
+	"Tin_number_of_points": {
+		true,
+	},
+	"Tin_number_of_triangles": {
+		true,
+	},
+	"Tin_prompt": {
+		true,
+		true,
+	},
+	"Tin_rename": {
+		true,
+	},
+	"Tin_slope": {
+		true,
+	},
+	"Tin_tin_depth_contours": {
+		true,
+	},
+	"Tin_tin_intersect": {
+		true,
+		true,
+	},
+	"Translate": {
+		true,
+	},
+	"Translate_XML_file": {
+		true,
+	},
+	"Triangle_aspect": {
+		true,
+		true,
+	},
+	"Triangle_normal": {
+		true,
+		true,
+	},
+	"Triangle_slope": {
+		true,
+		true,
+	},
+	"Triangles_clip": {
+		true,
+	},
+	"Triangulate": {
+		true,
+		true,
+	},
+	"Trimesh_append_edge_info": {
+		true,
+	},
+	"Trimesh_append_face_info": {
+		true,
+	},
+	"Trimesh_append_vertex_info": {
+		true,
+	},
+	"Trimesh_boolean_difference": {
+		true,
+	},
+	"Trimesh_boolean_intersection": {
+		true,
+	},
+	"Trimesh_boolean_union": {
+		true,
+	},
+	"Trimesh_closed": {
+		true,
+	},
+	"Trimesh_drop_point_3d": {
+		true,
+	},
+	"Trimesh_edit_add_face": {
+		true,
+	},
+	"Trimesh_edit_add_vertex": {
+		true,
+	},
+	"Trimesh_edit_hide_edge": {
+		true,
+	},
+	"Trimesh_edit_hide_edges": {
+		true,
+	},
+	"Trimesh_edit_hide_face": {
+		true,
+	},
+	"Trimesh_edit_hide_faces": {
+		true,
+	},
+	"Trimesh_edit_hide_vertex": {
+		true,
+	},
+	"Trimesh_edit_hide_vertices": {
+		true,
+	},
+	"Trimesh_edit_move_edge": {
+		true,
+	},
+	"Trimesh_edit_move_face": {
+		true,
+	},
+	"Trimesh_edit_move_vertex": {
+		true,
+	},
+	"Trimesh_edit_move_vertices": {
+		true,
+		true,
+	},
+	"Trimesh_edit_remove_edge": {
+		true,
+	},
+	"Trimesh_edit_remove_edges": {
+		true,
+	},
+	"Trimesh_edit_remove_face": {
+		true,
+	},
+	"Trimesh_edit_remove_faces": {
+		true,
+	},
+	"Trimesh_edit_remove_vertex": {
+		true,
+	},
+	"Trimesh_edit_remove_vertices": {
+		true,
+	},
+	"Trimesh_edit_set_vertex": {
+		true,
+	},
+	"Trimesh_edit_split_edge": {
+		true,
+	},
+	"Trimesh_get_blend_factor": {
+		true,
+	},
+	"Trimesh_get_closest_point": {
+		true,
+	},
+	"Trimesh_get_disjoint": {
+		true,
+	},
+	"Trimesh_get_edge_info_by_index": {
+		true,
+	},
+	"Trimesh_get_edge_info_index": {
+		true,
+	},
+	"Trimesh_get_edge_infos_count": {
+		true,
+	},
+	"Trimesh_get_edge_triangles_points": {
+		true,
+	},
+	"Trimesh_get_face_colour": {
+		true,
+	},
+	"Trimesh_get_face_info_by_index": {
+		true,
+	},
+	"Trimesh_get_face_info_index": {
+		true,
+	},
+	"Trimesh_get_face_infos_count": {
+		true,
+	},
+	"Trimesh_get_furthest_point": {
+		true,
+	},
+	"Trimesh_get_highest_point": {
+		true,
+	},
+	"Trimesh_get_lowest_point": {
+		true,
+	},
+	"Trimesh_get_point_coord": {
+		true,
+	},
+	"Trimesh_get_solid": {
+		true,
+	},
+	"Trimesh_get_triangle_edges": {
+		true,
+	},
+	"Trimesh_get_triangle_points": {
+		true,
+	},
+	"Trimesh_get_triangle_points_coords": {
+		true,
+	},
+	"Trimesh_get_vertex_info_by_index": {
+		true,
+	},
+	"Trimesh_get_vertex_info_index": {
+		true,
+	},
+	"Trimesh_get_vertex_infos_count": {
+		true,
+	},
+	"Trimesh_heal": {
+		true,
+	},
+	"Trimesh_number_of_edges": {
+		true,
+	},
+	"Trimesh_number_of_points": {
+		true,
+	},
+	"Trimesh_number_of_triangles": {
+		true,
+	},
+	"Trimesh_section": {
+		true,
+	},
+	"Trimesh_set_blend_factor": {
+		true,
+	},
+	"Trimesh_set_edge_info_by_index": {
+		true,
+	},
+	"Trimesh_set_edge_info_index": {
+		true,
+	},
+	"Trimesh_set_edge_infos_flags": {
+		true,
+	},
+	"Trimesh_set_face_info_by_index": {
+		true,
+	},
+	"Trimesh_set_face_info_index": {
+		true,
+	},
+	"Trimesh_set_face_infos_flags": {
+		true,
+	},
+	"Trimesh_set_solid": {
+		true,
+	},
+	"Trimesh_set_vertex_info_by_index": {
+		true,
+	},
+	"Trimesh_set_vertex_info_index": {
+		true,
+	},
+	"Trimesh_set_vertex_infos_flags": {
+		true,
+	},
+	"Tunnel_profile_3d": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Validate": {
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		true,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+	},
+	"Validate_apply_many_function": {
+		true,
+	},
+	"View_add_model": {
+		true,
+	},
+	"View_apply_favourite": {
+		true,
+	},
+	"View_apply_position": {
+		true,
+	},
+	"View_check_empty": {
+		true,
+	},
+	"View_clone": {
+		true,
+	},
+	"View_create": {
+		true,
+	},
+	"View_delete": {
+		true,
+	},
+	"View_favourite_file_exists": {
+		true,
+	},
+	"View_fit": {
+		true,
+	},
+	"View_get_attribute": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+	},
+	"View_get_background_colour": {
+		true,
+	},
+	"View_get_draw_area_size": {
+		true,
+		true,
+	},
+	"View_get_engine_type": {
+		true,
+	},
+	"View_get_exaggeration": {
+		true,
+	},
+	"View_get_grid_settings": {
+		true,
+	},
+	"View_get_models": {
+		true,
+	},
+	"View_get_placement": {
+		true,
+	},
+	"View_get_size": {
+		true,
+	},
+	"View_maximize": {
+		true,
+	},
+	"View_minimize": {
+		true,
+	},
+	"View_move_resize": {
+		true,
+	},
+	"View_position_file_exists": {
+		true,
+	},
+	"View_prompt": {
+		true,
+	},
+	"View_redraw": {
+		true,
+	},
+	"View_remove_attribute": {
+		true,
+		true,
+	},
+	"View_remove_model": {
+		true,
+	},
+	"View_resize": {
+		true,
+	},
+	"View_restore": {
+		true,
+	},
+	"View_restore_normal": {
+		true,
+	},
+	"View_set_attribute": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+	},
+	"View_set_background_colour": {
+		true,
+	},
+	"View_set_draw_area_size": {
+		true,
+		true,
+	},
+	"View_set_engine_type": {
+		true,
+	},
+	"View_set_exaggeration": {
+		true,
+	},
+	"View_set_grid_settings": {
+		true,
+	},
+	"View_set_name": {
+		true,
+	},
+	"View_write_favourite_file": {
+		true,
+	},
+	"View_write_position_file": {
+		true,
+	},
+	"Volume": {
+		true,
+		true,
+		false,
+	},
+	"Volume_exact": {
+		true,
+		true,
+	},
+	"Wait_on_events": {
+		true,
+	},
+	"Wait_on_widgets": {
+		true,
+	},
+	"Winhelp": {
+		true,
+		true,
+		true,
+		true,
+	},
+	"Write_12d": {
+		true,
+	},
+	"Write_12d_data": {
+		true,
+		true,
+		true,
+	},
+	"Write_4d_ascii": {
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+		true,
+	},
+	"Write_DAE_file": {
+		true,
+	},
+	"Write_SLX": {
+		true,
+	},
+	"Write_XML": {
+		true,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+		false,
+	},
+	"Write_XML_Document": {
+		true,
+	},
+	"Write_XML_document": {
+		true,
+	},
+	"Write_favorite": {
+		true,
+	},
+	"Write_parameter_file": {
+		true,
+	},
+	"XML_to_12da": {
+		true,
+	},
+	"XSD_get_type_enumerations": {
+		true,
+	},
+	"XYZ_prompt": {
+		true,
+	},
+	"Yes_no_prompt": {
+		true,
+	},
+}
+
 var LibCompletionItems = []protocol.CompletionItem{
 	{
 		Label:  "ADAC_get_xsd_path",
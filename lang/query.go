@@ -13,3 +13,15 @@ func GetReturnType(libFuncDocString string) (string, error) {
 	}
 	return split[0], nil
 }
+
+// Gets the function signature from the library function doc string. For
+// example, the doc string "```12dpl\nvoid Print(Text msg)\n```\n---\nPrint the
+// Text msg to the Output Window." will return "void Print(Text msg)".
+func GetSignature(libFuncDocString string) (string, error) {
+	trimmed := strings.TrimPrefix(libFuncDocString, "```12dpl\n")
+	endIdx := strings.Index(trimmed, "\n```")
+	if endIdx == -1 {
+		return "", errors.New("no signature in library function doc string")
+	}
+	return trimmed[:endIdx], nil
+}
@@ -0,0 +1,124 @@
+package lang
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// A parameter of a library function signature.
+type Param struct {
+	Type string
+	// Name of the parameter, some library functions do not name their
+	// parameters.
+	Name string
+	// The argument is passed by reference, for example "Text &name".
+	IsRef bool
+	// The argument is an array, for example "Real values[]".
+	IsArray bool
+}
+
+// The signature of a library function, for example the signature
+// "Integer Get_item(Dynamic_Text &items, Integer i, Text &item)".
+type Signature struct {
+	ReturnType string
+	Name       string
+	Params     []Param
+	// The function returns an Integer status code where zero indicates
+	// success, only known for library functions.
+	ReturnsStatusCode bool
+}
+
+var (
+	signaturePattern = regexp.MustCompile(`^(\w+)\s+(\w+)\s*\((.*)\)$`)
+	paramPattern     = regexp.MustCompile(`^(\w+)\s*(&)?\s*(\w*)\s*(\[\s*\])?$`)
+)
+
+// Parses the function signature text, for example "void Print(Text msg)".
+func ParseSignature(signature string) (Signature, error) {
+	matches := signaturePattern.FindStringSubmatch(strings.TrimSpace(signature))
+	if matches == nil {
+		return Signature{}, fmt.Errorf("invalid signature %q", signature)
+	}
+	result := Signature{ReturnType: matches[1], Name: matches[2], Params: []Param{}}
+	paramsText := strings.TrimSpace(matches[3])
+	if paramsText == "" || paramsText == "void" {
+		return result, nil
+	}
+	for _, paramText := range strings.Split(paramsText, ",") {
+		paramMatches := paramPattern.FindStringSubmatch(strings.TrimSpace(paramText))
+		if paramMatches == nil {
+			return Signature{}, fmt.Errorf("invalid parameter %q in signature %q", paramText, signature)
+		}
+		result.Params = append(result.Params, Param{
+			Type:    paramMatches[1],
+			Name:    paramMatches[3],
+			IsRef:   paramMatches[2] != "",
+			IsArray: paramMatches[4] != "",
+		})
+	}
+	return result, nil
+}
+
+// Gets the parsed function signature from the library function doc string.
+func GetLibSignature(libFuncDocString string) (Signature, error) {
+	signature, err := GetSignature(libFuncDocString)
+	if err != nil {
+		return Signature{}, err
+	}
+	return ParseSignature(signature)
+}
+
+// Gets the parsed signatures of the overloads of the library function in the
+// same order as their doc strings in Lib, flagging the overloads which return
+// a status code. Returns an error if the function is
+// not a library function or one of its signatures cannot be parsed, a few of
+// the signatures in the 12d documentation are malformed.
+func GetLibSignatures(name string) ([]Signature, error) {
+	libItems, ok := Lib[name]
+	if !ok {
+		return nil, fmt.Errorf("%s is not a library function", name)
+	}
+	if len(libItems) == 0 {
+		return nil, errors.New("library function has no signatures")
+	}
+	result := []Signature{}
+	for idx, item := range libItems {
+		signature, err := GetLibSignature(item)
+		if err != nil {
+			return nil, err
+		}
+		if statusCodes, ok := LibStatusCodes[name]; ok && idx < len(statusCodes) {
+			signature.ReturnsStatusCode = statusCodes[idx]
+		}
+		result = append(result, signature)
+	}
+	return result, nil
+}
+
+// Formats the parameter as it is written in a signature, for example
+// "Text &name".
+func (p Param) String() string {
+	result := p.Type
+	if p.Name != "" || p.IsRef {
+		result += " "
+	}
+	if p.IsRef {
+		result += "&"
+	}
+	result += p.Name
+	if p.IsArray {
+		result += "[]"
+	}
+	return result
+}
+
+// Formats the signature, for example "void Print(Text msg)".
+func (s Signature) String() string {
+	params := []string{}
+	for _, param := range s.Params {
+		params = append(params, param.String())
+	}
+	return fmt.Sprintf("%s %s(%s)", s.ReturnType, s.Name, strings.Join(params, ", "))
+}
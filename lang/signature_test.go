@@ -0,0 +1,87 @@
+package lang_test
+
+import (
+	"testing"
+
+	"github.com/kelly-lin/12d-lang-server/lang"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSignature(t *testing.T) {
+	type TestCase struct {
+		Desc      string
+		Signature string
+		Want      lang.Signature
+		WantErr   bool
+	}
+	testCases := []TestCase{
+		{
+			Desc:      "no params",
+			Signature: "Integer Get_time()",
+			Want:      lang.Signature{ReturnType: "Integer", Name: "Get_time", Params: []lang.Param{}},
+		},
+		{
+			Desc:      "reference and array params",
+			Signature: "Integer Get_item(Dynamic_Text &items, Integer i, Real values[])",
+			Want: lang.Signature{
+				ReturnType: "Integer",
+				Name:       "Get_item",
+				Params: []lang.Param{
+					{Type: "Dynamic_Text", Name: "items", IsRef: true},
+					{Type: "Integer", Name: "i"},
+					{Type: "Real", Name: "values", IsArray: true},
+				},
+			},
+		},
+		{
+			Desc:      "params without spaces or names",
+			Signature: "Integer Calc(Element,Integer &mode)",
+			Want: lang.Signature{
+				ReturnType: "Integer",
+				Name:       "Calc",
+				Params: []lang.Param{
+					{Type: "Element"},
+					{Type: "Integer", Name: "mode", IsRef: true},
+				},
+			},
+		},
+		{
+			Desc:      "malformed param",
+			Signature: "Integer Set(Real fix_offset Model &model)",
+			WantErr:   true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.Desc, func(t *testing.T) {
+			assert := assert.New(t)
+			got, err := lang.ParseSignature(testCase.Signature)
+			if testCase.WantErr {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(testCase.Want, got)
+		})
+	}
+}
+
+func TestSignatureString(t *testing.T) {
+	signature := "Integer Get_item(Dynamic_Text &items, Integer, Real values[])"
+	got, err := lang.ParseSignature(signature)
+	assert.NoError(t, err)
+	assert.Equal(t, signature, got.String())
+}
+
+func TestGetLibSignaturesStatusCode(t *testing.T) {
+	assert := assert.New(t)
+	signatures, err := lang.GetLibSignatures("File_open")
+	assert.NoError(err)
+	for _, signature := range signatures {
+		assert.True(signature.ReturnsStatusCode, signature.String())
+	}
+	signatures, err = lang.GetLibSignatures("Text_length")
+	assert.NoError(err)
+	for _, signature := range signatures {
+		assert.False(signature.ReturnsStatusCode, signature.String())
+	}
+}
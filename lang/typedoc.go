@@ -0,0 +1,59 @@
+package lang
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Returns true if the provided name is a built in 12dpl type.
+func IsType(name string) bool {
+	for _, item := range TypeCompletionItems {
+		if item.Label == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Creates the contents of the read only document which describes the built in
+// type and the library functions which take or return the type. The document
+// is written in 12dpl so that clients can highlight it like any other source
+// file. Returns false if the name is not a built in type.
+func CreateTypeDoc(name string) (string, bool) {
+	if !IsType(name) {
+		return "", false
+	}
+	sb := strings.Builder{}
+	sb.WriteString("// Generated by 12d-lang-server, do not modify.\n")
+	sb.WriteString("//\n")
+	sb.WriteString(fmt.Sprintf("// Built in type %s.\n", name))
+	if aliases, ok := TypeAliases[name]; ok {
+		sb.WriteString(fmt.Sprintf("// Can be used as: %s.\n", strings.Join(aliases, ", ")))
+	}
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("%s;\n", name))
+
+	typePattern := regexp.MustCompile(fmt.Sprintf(`\b%s\b`, regexp.QuoteMeta(name)))
+	var signatures []string
+	for _, docs := range Lib {
+		for _, doc := range docs {
+			signature, err := GetSignature(doc)
+			if err != nil {
+				continue
+			}
+			if typePattern.MatchString(signature) {
+				signatures = append(signatures, signature)
+			}
+		}
+	}
+	if len(signatures) > 0 {
+		sort.Strings(signatures)
+		sb.WriteString("\n// Library functions.\n")
+		for _, signature := range signatures {
+			sb.WriteString(fmt.Sprintf("%s;\n", signature))
+		}
+	}
+	return sb.String(), true
+}
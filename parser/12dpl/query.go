@@ -169,6 +169,18 @@ func DebugNode(n *sitter.Node, sourceCode []byte) {
 // Finds the identifier located at the line and column number and returns the
 // name if it exists and an error when it does not.
 func FindIdentifierNode(node *sitter.Node, lineNum, colNum uint) (*sitter.Node, error) {
+	return findNodeAt(node, "identifier", lineNum, colNum)
+}
+
+// Finds the statement identifier, the name of a label in a labeled statement
+// or goto statement, located at the line and column number. Returns an error
+// when it does not exist.
+func FindStatementIdentifierNode(node *sitter.Node, lineNum, colNum uint) (*sitter.Node, error) {
+	return findNodeAt(node, "statement_identifier", lineNum, colNum)
+}
+
+// Finds the node of the node type located at the line and column number.
+func findNodeAt(node *sitter.Node, nodeType string, lineNum, colNum uint) (*sitter.Node, error) {
 	queue := NewQueue()
 	queue.Enqueue(node)
 	for queue.HasItems() {
@@ -176,10 +188,10 @@ func FindIdentifierNode(node *sitter.Node, lineNum, colNum uint) (*sitter.Node,
 		if err != nil {
 			break
 		}
-		isIdentifier := currentNode.Type() == "identifier"
+		isNodeType := currentNode.Type() == nodeType
 		isOnSameLine := uint(currentNode.StartPoint().Row) == lineNum && lineNum == uint(currentNode.EndPoint().Row)
 		isInsideColumnRange := uint(currentNode.StartPoint().Column) <= colNum && colNum <= uint(currentNode.EndPoint().Column)
-		if isIdentifier && isOnSameLine && isInsideColumnRange {
+		if isNodeType && isOnSameLine && isInsideColumnRange {
 			return currentNode, nil
 		}
 		// If we need more performance we might be able to improve this by doing
@@ -66,3 +66,19 @@ void main() {
 	assert.NoError(err)
 	assert.Equal(want, node.Content(sourceCode))
 }
+
+func TestFindStatementIdentifier(t *testing.T) {
+	assert := assert.New(t)
+	sourceCode := []byte(`void main() {
+    goto done;
+done:
+    return;
+}`)
+	n, err := sitter.ParseCtx(context.Background(), sourceCode, parser.GetLanguage())
+	assert.NoError(err)
+	node, err := parser.FindStatementIdentifierNode(n, 1, 10)
+	assert.NoError(err)
+	assert.Equal("done", node.Content(sourceCode))
+	_, err = parser.FindStatementIdentifierNode(n, 3, 6)
+	assert.ErrorIs(err, parser.ErrNoDefinition)
+}
@@ -41,11 +41,23 @@ const (
 	DiagnosticSeverityHint        uint = 4
 )
 
+const (
+	// Unused or unnecessary code, clients can render it faded out.
+	DiagnosticTagUnnecessary uint = 1
+	// Deprecated or obsolete code, clients can render it struck through.
+	DiagnosticTagDeprecated uint = 2
+)
+
 const (
 	DocumentDiagnosticReportKindFull      = "full"
 	DocumentDiagnosticReportKindUnchanged = "unchanged"
 )
 
+const (
+	CodeActionKindSource                = "source"
+	CodeActionKindSourceOrganizeImports = "source.organizeImports"
+)
+
 const (
 	TextDocumentSyncKindNone        uint = 0
 	TextDocumentSyncKindFull        uint = 1
@@ -159,6 +171,16 @@ type ResponseError struct {
 	Data    any    `json:"data,omitempty"`
 }
 
+type InitializeParams struct {
+	// User provided options for the server.
+	InitializationOptions json.RawMessage `json:"initializationOptions,omitempty"`
+}
+
+type DidChangeConfigurationParams struct {
+	// The changed settings.
+	Settings json.RawMessage `json:"settings"`
+}
+
 type InitializeResult struct {
 	Capabilities ServerCapabilities `json:"capabilities"`
 	ServerInfo   *ServerInfo        `json:"serverInfo,omitempty"`
@@ -183,20 +205,42 @@ type MarkupContent struct {
 }
 
 type ServerCapabilities struct {
-	CompletionProvider         *CompletionOptions `json:"completionProvider,omitempty"`
-	DefinitionProvider         *bool              `json:"definitionProvider,omitempty"`
-	DiagnosticProvider         *DiagnosticOptions `json:"diagnosticProvider"`
-	DocumentFormattingProvider *bool              `json:"documentFormattingProvider,omitempty"`
-	HoverProvider              bool               `json:"hoverProvider"`
-	ReferencesProvider         bool               `json:"referencesProvider"`
-	RenameProvider             bool               `json:"renameProvider"`
-	TextDocumentSync           *uint              `json:"textDocumentSync,omitempty"`
+	CodeActionProvider               *CodeActionOptions               `json:"codeActionProvider,omitempty"`
+	CompletionProvider               *CompletionOptions               `json:"completionProvider,omitempty"`
+	DefinitionProvider               *bool                            `json:"definitionProvider,omitempty"`
+	DiagnosticProvider               *DiagnosticOptions               `json:"diagnosticProvider"`
+	DocumentFormattingProvider       *bool                            `json:"documentFormattingProvider,omitempty"`
+	DocumentLinkProvider             *DocumentLinkOptions             `json:"documentLinkProvider,omitempty"`
+	DocumentOnTypeFormattingProvider *DocumentOnTypeFormattingOptions `json:"documentOnTypeFormattingProvider,omitempty"`
+	DocumentRangeFormattingProvider  *bool                            `json:"documentRangeFormattingProvider,omitempty"`
+	HoverProvider                    bool                             `json:"hoverProvider"`
+	ReferencesProvider               bool                             `json:"referencesProvider"`
+	RenameProvider                   bool                             `json:"renameProvider"`
+	TextDocumentSync                 *uint                            `json:"textDocumentSync,omitempty"`
+	TypeDefinitionProvider           bool                             `json:"typeDefinitionProvider"`
+}
+
+type CodeActionOptions struct {
+	// The kinds of code actions which the server may return.
+	CodeActionKinds []string `json:"codeActionKinds,omitempty"`
 }
 
 type CompletionOptions struct {
 	ResolveProvider *bool `json:"resolveProvider,omitempty"`
 }
 
+type DocumentOnTypeFormattingOptions struct {
+	// A character on which formatting should be triggered, like `{`.
+	FirstTriggerCharacter string `json:"firstTriggerCharacter"`
+	// More trigger characters.
+	MoreTriggerCharacter []string `json:"moreTriggerCharacter,omitempty"`
+}
+
+type DocumentLinkOptions struct {
+	// Document links have a resolve provider as well.
+	ResolveProvider bool `json:"resolveProvider"`
+}
+
 type DiagnosticOptions struct {
 	// Whether the language has inter file dependencies meaning that
 	// editing code in one file can result in a different diagnostic
@@ -227,6 +271,10 @@ type DefinitionParams struct {
 	TextDocumentPositionParams
 }
 
+type TypeDefinitionParams struct {
+	TextDocumentPositionParams
+}
+
 type TextDocumentPositionParams struct {
 	TextDocument TextDocumentIdentifier `json:"textDocument"`
 	Position     Position               `json:"position"`
@@ -306,6 +354,29 @@ type FormattingOptions struct {
 	TrimFinalNewlines *bool `json:"trimFinalNewlines,omitempty"`
 }
 
+type DocumentRangeFormattingParams struct {
+	// The document to format.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	// The range to format.
+	Range Range `json:"range"`
+	// The format options.
+	Options FormattingOptions `json:"options"`
+}
+
+type DocumentOnTypeFormattingParams struct {
+	// The document to format.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	// The position around which the on type formatting should happen. This is
+	// not necessarily the exact position where the character denoted by the
+	// property `ch` got typed.
+	Position Position `json:"position"`
+	// The character that has been typed that triggered the formatting on type
+	// request.
+	Ch string `json:"ch"`
+	// The formatting options.
+	Options FormattingOptions `json:"options"`
+}
+
 type TextEdit struct {
 	Range   Range  `json:"range"`
 	NewText string `json:"newText"`
@@ -330,6 +401,32 @@ type WorkspaceEdit struct {
 	Changes map[string][]TextEdit `json:"changes"`
 }
 
+type CodeActionParams struct {
+	// The document in which the command was invoked.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	// The range for which the command was invoked.
+	Range Range `json:"range"`
+	// Context carrying additional information.
+	Context CodeActionContext `json:"context"`
+}
+
+type CodeActionContext struct {
+	// The diagnostics which overlap the range.
+	Diagnostics []Diagnostic `json:"diagnostics"`
+	// Requested kinds of actions to return, actions which are not of these
+	// kinds are filtered out by the client anyway.
+	Only []string `json:"only,omitempty"`
+}
+
+type CodeAction struct {
+	// A short, human-readable, title for this code action.
+	Title string `json:"title"`
+	// The kind of the code action.
+	Kind string `json:"kind,omitempty"`
+	// The workspace edit this code action performs.
+	Edit *WorkspaceEdit `json:"edit,omitempty"`
+}
+
 type PublishDiagnosticsParams struct {
 	// The URI for which diagnostic information is reported.
 	URI string `json:"uri"`
@@ -347,6 +444,23 @@ type Diagnostic struct {
 	Source string `json:"source"`
 	// The diagnostic's message.
 	Message string `json:"message"`
+	// The diagnostic's code, which might appear in the user interface.
+	Code string `json:"code,omitempty"`
+	// Additional metadata about the diagnostic.
+	Tags []uint `json:"tags,omitempty"`
+	// An array of related diagnostic information, e.g. when symbol-names
+	// within a scope collide all definitions can be marked via this property.
+	RelatedInformation []DiagnosticRelatedInformation `json:"relatedInformation,omitempty"`
+}
+
+// Represents a related message and source code location for a diagnostic.
+// This should be used to point to code locations that cause or are related to
+// a diagnostics, e.g when duplicating a symbol in a scope.
+type DiagnosticRelatedInformation struct {
+	// The location of this related diagnostic information.
+	Location Location `json:"location"`
+	// The message of this related diagnostic information.
+	Message string `json:"message"`
 }
 
 type DocumentDiagnosticParams struct {
@@ -363,3 +477,19 @@ type FullDocumentDiagnosticReport struct {
 	Kind  string       `json:"kind"` // A full document diagnostic report.
 	Items []Diagnostic `json:"items"`
 }
+
+type DocumentLinkParams struct {
+	// The document to provide document links for.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+}
+
+// A document link is a range in a text document that links to an internal or
+// external resource, like another text document or a web site.
+type DocumentLink struct {
+	// The range this link applies to.
+	Range Range `json:"range"`
+	// The uri this link points to. If missing a resolve request is sent later.
+	Target *string `json:"target,omitempty"`
+	// The tooltip text when you hover over this link.
+	Tooltip string `json:"tooltip,omitempty"`
+}
@@ -0,0 +1,181 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/kelly-lin/12d-lang-server/protocol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Get the diagnostics of the arrays of the document, arrays declared with a
+// size less than 1 and constant indices which are out of bounds. 12d arrays
+// start at 1 so an index of 0 is always out of bounds, and loops which index
+// arrays with a variable starting at 0 are reported as well.
+func (s *Server) getArrayDiagnostics(uri string) []protocol.Diagnostic {
+	result := []protocol.Diagnostic{}
+	doc, ok := s.documents[uri]
+	if !ok {
+		return result
+	}
+	evaluator := newConstEvaluator(uri, s.documents, s.includesDir)
+	var visit func(node *sitter.Node)
+	visit = func(node *sitter.Node) {
+		if node.IsError() {
+			return
+		}
+		if !node.HasError() {
+			switch node.Type() {
+			case "array_declarator":
+				if diagnostic, ok := checkArraySize(node, doc.SourceCode, evaluator); ok {
+					result = append(result, diagnostic)
+				}
+
+			case "subscript_expression":
+				if diagnostic, ok := s.checkArrayIndex(node, uri, evaluator); ok {
+					result = append(result, diagnostic)
+				}
+
+			case "for_statement":
+				result = append(result, s.checkLoopIndices(node, uri, evaluator)...)
+			}
+		}
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			visit(node.NamedChild(i))
+		}
+	}
+	visit(doc.RootNode)
+	return result
+}
+
+// Returns a diagnostic if the array declarator node is declared with a
+// constant size less than 1.
+func checkArraySize(arrayDeclaratorNode *sitter.Node, sourceCode []byte, evaluator constEvaluator) (protocol.Diagnostic, bool) {
+	sizeNode := arrayDeclaratorNode.ChildByFieldName("size")
+	identifierNode := arrayDeclaratorNode.ChildByFieldName("identifier")
+	if sizeNode == nil || identifierNode == nil {
+		return protocol.Diagnostic{}, false
+	}
+	size, err := evaluator.eval(sizeNode)
+	if err != nil || size.exprType != integerType || size.integer >= 1 {
+		return protocol.Diagnostic{}, false
+	}
+	return protocol.Diagnostic{
+		Range:    getNodeRange(sizeNode),
+		Severity: protocol.DiagnosticSeverityError,
+		Source:   SourceName,
+		Message:  fmt.Sprintf(`Array "%s" must have a size of at least 1 but has a size of %d.`, identifierNode.Content(sourceCode), size.integer),
+	}, true
+}
+
+// Returns a diagnostic if the subscript expression node indexes an array with
+// a constant index which is less than 1 or greater than the constant size of
+// the array.
+func (s *Server) checkArrayIndex(subscriptNode *sitter.Node, uri string, evaluator constEvaluator) (protocol.Diagnostic, bool) {
+	argumentNode := subscriptNode.ChildByFieldName("argument")
+	indexNode := subscriptNode.ChildByFieldName("index")
+	if argumentNode == nil || indexNode == nil {
+		return protocol.Diagnostic{}, false
+	}
+	arrayDeclaratorNode, arrayURI, ok := s.findArrayDeclarator(argumentNode, uri)
+	if !ok {
+		return protocol.Diagnostic{}, false
+	}
+	index, err := evaluator.eval(indexNode)
+	if err != nil || index.exprType != integerType {
+		return protocol.Diagnostic{}, false
+	}
+	identifier := argumentNode.Content(evaluator.sourceCode)
+	if index.integer < 1 {
+		return protocol.Diagnostic{
+			Range:    getNodeRange(indexNode),
+			Severity: protocol.DiagnosticSeverityError,
+			Source:   SourceName,
+			Message:  fmt.Sprintf(`Index %d is out of bounds for array "%s", arrays start at index 1.`, index.integer, identifier),
+		}, true
+	}
+	sizeNode := arrayDeclaratorNode.ChildByFieldName("size")
+	if sizeNode == nil {
+		return protocol.Diagnostic{}, false
+	}
+	size, err := newConstEvaluator(arrayURI, s.documents, s.includesDir).eval(sizeNode)
+	if err != nil || size.exprType != integerType || size.integer < 1 || index.integer <= size.integer {
+		return protocol.Diagnostic{}, false
+	}
+	return protocol.Diagnostic{
+		Range:    getNodeRange(indexNode),
+		Severity: protocol.DiagnosticSeverityError,
+		Source:   SourceName,
+		Message:  fmt.Sprintf(`Index %d is out of bounds for array "%s" of size %d.`, index.integer, identifier, size.integer),
+	}, true
+}
+
+// Returns warnings for the subscripts in the body of the for statement node
+// which index an array with the loop variable when the loop variable is
+// declared starting at 0, such as "for (Integer i = 0; i < 10; i++)".
+func (s *Server) checkLoopIndices(forNode *sitter.Node, uri string, evaluator constEvaluator) []protocol.Diagnostic {
+	result := []protocol.Diagnostic{}
+	initializerNode := forNode.ChildByFieldName("initializer")
+	if initializerNode == nil || initializerNode.Type() != "declaration" {
+		return result
+	}
+	initDeclaratorNode := initializerNode.ChildByFieldName("declarator")
+	if initDeclaratorNode == nil || initDeclaratorNode.Type() != "init_declarator" {
+		return result
+	}
+	identifierNode := initDeclaratorNode.ChildByFieldName("declarator")
+	valueNode := initDeclaratorNode.ChildByFieldName("value")
+	if identifierNode == nil || valueNode == nil || identifierNode.Type() != "identifier" {
+		return result
+	}
+	if value, err := evaluator.eval(valueNode); err != nil || value.exprType != integerType || value.integer != 0 {
+		return result
+	}
+	bodyNode := forNode.NamedChild(int(forNode.NamedChildCount()) - 1)
+	if bodyNode == nil {
+		return result
+	}
+	identifier := identifierNode.Content(evaluator.sourceCode)
+	for _, subscriptNode := range getDescendantsOfType(bodyNode, "subscript_expression") {
+		argumentNode := subscriptNode.ChildByFieldName("argument")
+		indexNode := subscriptNode.ChildByFieldName("index")
+		if argumentNode == nil || indexNode == nil || indexNode.Type() != "identifier" || indexNode.Content(evaluator.sourceCode) != identifier {
+			continue
+		}
+		if _, _, ok := s.findArrayDeclarator(argumentNode, uri); !ok {
+			continue
+		}
+		result = append(result, protocol.Diagnostic{
+			Range:    getNodeRange(indexNode),
+			Severity: protocol.DiagnosticSeverityWarning,
+			Source:   SourceName,
+			Message:  fmt.Sprintf(`Loop variable "%s" starts at 0 and indexes array "%s", arrays start at index 1.`, identifier, argumentNode.Content(evaluator.sourceCode)),
+		})
+	}
+	return result
+}
+
+// Find the array declarator node which declares the array the identifier node
+// refers to and the uri of the document it is declared in. Returns false if the
+// identifier is not an array.
+func (s *Server) findArrayDeclarator(identifierNode *sitter.Node, uri string) (*sitter.Node, string, bool) {
+	if identifierNode.Type() != "identifier" {
+		return nil, "", false
+	}
+	def, err := findDefinition(identifierNode, identifierNode.Content(s.documents[uri].SourceCode), uri, s.documents, s.includesDir)
+	if err != nil || def.Node.Parent() == nil || def.Node.Parent().Type() != "array_declarator" {
+		return nil, "", false
+	}
+	return def.Node.Parent(), def.URI, true
+}
+
+// Get the nodes of the node type inside of the node, including the node.
+func getDescendantsOfType(node *sitter.Node, nodeType string) []*sitter.Node {
+	result := []*sitter.Node{}
+	if node.Type() == nodeType {
+		result = append(result, node)
+	}
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		result = append(result, getDescendantsOfType(node.NamedChild(i), nodeType)...)
+	}
+	return result
+}
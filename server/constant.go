@@ -0,0 +1,482 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/kelly-lin/12d-lang-server/protocol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// The maximum depth of "#define" constants referencing other constants which
+// are evaluated, constants which reference each other are not constant.
+const maxConstDepth = 32
+
+// The node is not a constant expression, for example it references a variable.
+var errNotConstant = errors.New("not a constant expression")
+
+// A constant expression which can be evaluated but whose value is wrong, for
+// example a division by zero.
+type constError struct {
+	uri     string
+	rng     protocol.Range
+	message string
+}
+
+func (e *constError) Error() string {
+	return e.message
+}
+
+// The value of a constant expression. Integers are 32 bit like the 12d
+// "Integer".
+type constValue struct {
+	exprType exprType
+	integer  int64
+	real     float64
+	text     string
+}
+
+func (v constValue) isZero() bool {
+	return v.exprType == integerType && v.integer == 0 || v.exprType == realType && v.real == 0
+}
+
+func (v constValue) toReal() float64 {
+	if v.exprType == integerType {
+		return float64(v.integer)
+	}
+	return v.real
+}
+
+// Returns the value as it would be written in 12dPL.
+func (v constValue) String() string {
+	switch v.exprType {
+	case integerType:
+		return strconv.FormatInt(v.integer, 10)
+	case realType:
+		result := strconv.FormatFloat(v.real, 'g', -1, 64)
+		if !strings.ContainsAny(result, ".eEI") {
+			result += ".0"
+		}
+		return result
+	}
+	return strconv.Quote(v.text)
+}
+
+// Evaluates the constant expressions of a document, literals, operators and
+// "#define" constants whose values are constant expressions.
+type constEvaluator struct {
+	uri         string
+	sourceCode  []byte
+	documents   map[string]Document
+	includesDir string
+	// Number of "#define" constants being evaluated which reference the
+	// constant being evaluated.
+	depth int
+}
+
+func newConstEvaluator(uri string, documents map[string]Document, includesDir string) constEvaluator {
+	return constEvaluator{
+		uri:         uri,
+		sourceCode:  documents[uri].SourceCode,
+		documents:   documents,
+		includesDir: includesDir,
+	}
+}
+
+func (e constEvaluator) newError(rng protocol.Range, message string) error {
+	return &constError{uri: e.uri, rng: rng, message: message}
+}
+
+// Evaluate the expression node. Returns errNotConstant if it is not a
+// constant expression and a *constError if it divides by zero or overflows.
+func (e constEvaluator) eval(node *sitter.Node) (constValue, error) {
+	switch node.Type() {
+	case "number_literal":
+		return e.evalNumberLiteral(node.Content(e.sourceCode), getNodeRange(node))
+
+	case "string_literal":
+		return evalStringLiteral(node.Content(e.sourceCode)), nil
+
+	case "concatenated_string":
+		result := constValue{exprType: textType}
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			value, err := e.eval(node.NamedChild(i))
+			if err != nil {
+				return constValue{}, err
+			}
+			result.text += value.text
+		}
+		return result, nil
+
+	case "parenthesized_expression":
+		if node.NamedChildCount() != 1 {
+			return constValue{}, errNotConstant
+		}
+		return e.eval(node.NamedChild(0))
+
+	case "identifier":
+		return e.evalIdentifier(node, node.Content(e.sourceCode))
+
+	case "unary_expression":
+		operatorNode := node.ChildByFieldName("operator")
+		argumentNode := node.ChildByFieldName("argument")
+		if operatorNode == nil || argumentNode == nil {
+			return constValue{}, errNotConstant
+		}
+		argument, err := e.eval(argumentNode)
+		if err != nil {
+			return constValue{}, err
+		}
+		return e.evalUnary(getNodeRange(node), operatorNode.Content(e.sourceCode), argument)
+
+	case "binary_expression":
+		operatorNode := node.ChildByFieldName("operator")
+		leftNode := node.ChildByFieldName("left")
+		rightNode := node.ChildByFieldName("right")
+		if operatorNode == nil || leftNode == nil || rightNode == nil {
+			return constValue{}, errNotConstant
+		}
+		left, err := e.eval(leftNode)
+		if err != nil {
+			return constValue{}, err
+		}
+		right, err := e.eval(rightNode)
+		if err != nil {
+			return constValue{}, err
+		}
+		return e.evalBinary(getNodeRange(node), operatorNode.Content(e.sourceCode), left, right)
+	}
+	return constValue{}, errNotConstant
+}
+
+func (e constEvaluator) evalNumberLiteral(literal string, rng protocol.Range) (constValue, error) {
+	if getNumberLiteralType(literal) == realType {
+		value, err := strconv.ParseFloat(literal, 64)
+		if err != nil {
+			return constValue{}, errNotConstant
+		}
+		return constValue{exprType: realType, real: value}, nil
+	}
+	value, err := strconv.ParseInt(literal, 0, 64)
+	if err != nil && !errors.Is(err, strconv.ErrRange) {
+		return constValue{}, errNotConstant
+	}
+	// Hex and octal literals such as 0xffffffff set the sign bit, decimal
+	// literals must fit without it.
+	maxValue := int64(math.MaxInt32)
+	if len(literal) > 1 && literal[0] == '0' {
+		maxValue = math.MaxUint32
+	}
+	if err != nil || value > maxValue {
+		return constValue{}, e.newError(rng, fmt.Sprintf(`Number %s does not fit in an "Integer".`, literal))
+	}
+	return constValue{exprType: integerType, integer: int64(int32(uint32(value)))}, nil
+}
+
+func evalStringLiteral(literal string) constValue {
+	text, err := strconv.Unquote(literal)
+	if err != nil {
+		text = strings.Trim(literal, `"`)
+	}
+	return constValue{exprType: textType, text: text}
+}
+
+// Evaluate the identifier, only "#define" constants are constant. The
+// identifier is resolved from the scope node.
+func (e constEvaluator) evalIdentifier(scopeNode *sitter.Node, identifier string) (constValue, error) {
+	if e.depth >= maxConstDepth {
+		return constValue{}, errNotConstant
+	}
+	def, err := findDefinition(scopeNode, identifier, e.uri, e.documents, e.includesDir)
+	if err != nil || !isPreprocDefName(def.Node) {
+		return constValue{}, errNotConstant
+	}
+	defEvaluator := newConstEvaluator(def.URI, e.documents, e.includesDir)
+	defEvaluator.depth = e.depth + 1
+	return defEvaluator.evalPreprocDef(def.Node)
+}
+
+// Evaluate the value of the "#define" whose name is the name node. The parser
+// only supports a literal as the value of a definition, and does not support
+// parenthesized sub expressions, so the value is parsed here.
+func (e constEvaluator) evalPreprocDef(nameNode *sitter.Node) (constValue, error) {
+	p := constParser{
+		evaluator: e,
+		nameNode:  nameNode,
+		value:     getPreprocDefValue(nameNode, e.sourceCode),
+		start:     protocol.Position{Line: uint(nameNode.EndPoint().Row), Character: uint(nameNode.EndPoint().Column)},
+	}
+	p.next()
+	if p.token == "" {
+		return constValue{}, errNotConstant
+	}
+	result, _, err := p.parseExpr(0)
+	if err != nil {
+		return constValue{}, err
+	}
+	if p.token != "" {
+		return constValue{}, errNotConstant
+	}
+	return result, nil
+}
+
+// Get the text of the value of the "#define" whose name is the name node. The
+// value ends at the end of the line unless the line ends with a "\" which
+// continues the value on the next line. The parser may take the lines after a
+// value it does not support into the definition node, so the node does not
+// tell where the value ends.
+func getPreprocDefValue(nameNode *sitter.Node, sourceCode []byte) string {
+	value := string(sourceCode[nameNode.EndByte():])
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\n' && !strings.HasSuffix(strings.TrimSuffix(value[:i], "\r"), "\\") {
+			return value[:i]
+		}
+	}
+	return value
+}
+
+// Returns true if the value of the "#define" whose name is the name node is a
+// single literal, for example "1" in "#define TRUE 1", rather than an
+// expression.
+func isPreprocDefLiteral(nameNode *sitter.Node) bool {
+	valueNode := nameNode.NextSibling()
+	if valueNode == nil && nameNode.Parent().IsError() {
+		valueNode = nameNode.Parent().NextSibling()
+	}
+	if valueNode == nil || valueNode.Type() != "preproc_arg" || valueNode.NamedChildCount() != 1 {
+		return false
+	}
+	literalType := valueNode.NamedChild(0).Type()
+	return literalType == "number_literal" || literalType == "string_literal"
+}
+
+func (e constEvaluator) evalUnary(rng protocol.Range, operator string, argument constValue) (constValue, error) {
+	switch {
+	case operator == "+" && argument.exprType.isNumeric():
+		return argument, nil
+
+	case operator == "-" && argument.exprType == realType:
+		return constValue{exprType: realType, real: -argument.real}, nil
+
+	case operator == "-" && argument.exprType == integerType:
+		return e.newInteger(rng, -argument.integer)
+
+	case operator == "~" && argument.exprType == integerType:
+		return constValue{exprType: integerType, integer: ^argument.integer}, nil
+
+	case operator == "!" && argument.exprType.isNumeric():
+		return newBoolValue(argument.isZero()), nil
+	}
+	return constValue{}, errNotConstant
+}
+
+func (e constEvaluator) evalBinary(rng protocol.Range, operator string, left, right constValue) (constValue, error) {
+	if left.exprType.isText() && right.exprType.isText() {
+		switch operator {
+		case "+":
+			return constValue{exprType: textType, text: left.text + right.text}, nil
+		case "==":
+			return newBoolValue(left.text == right.text), nil
+		case "!=":
+			return newBoolValue(left.text != right.text), nil
+		}
+		return constValue{}, errNotConstant
+	}
+	if !left.exprType.isNumeric() || !right.exprType.isNumeric() {
+		return constValue{}, errNotConstant
+	}
+
+	switch operator {
+	case "==":
+		return newBoolValue(left.toReal() == right.toReal()), nil
+	case "!=":
+		return newBoolValue(left.toReal() != right.toReal()), nil
+	case "<":
+		return newBoolValue(left.toReal() < right.toReal()), nil
+	case "<=":
+		return newBoolValue(left.toReal() <= right.toReal()), nil
+	case ">":
+		return newBoolValue(left.toReal() > right.toReal()), nil
+	case ">=":
+		return newBoolValue(left.toReal() >= right.toReal()), nil
+	case "&&":
+		return newBoolValue(!left.isZero() && !right.isZero()), nil
+	case "||":
+		return newBoolValue(!left.isZero() || !right.isZero()), nil
+	}
+	if (operator == "/" || operator == "%") && right.isZero() {
+		return constValue{}, e.newError(rng, "Division by zero.")
+	}
+
+	if left.exprType == realType || right.exprType == realType {
+		l, r := left.toReal(), right.toReal()
+		switch operator {
+		case "+":
+			return constValue{exprType: realType, real: l + r}, nil
+		case "-":
+			return constValue{exprType: realType, real: l - r}, nil
+		case "*":
+			return constValue{exprType: realType, real: l * r}, nil
+		case "/":
+			return constValue{exprType: realType, real: l / r}, nil
+		}
+		return constValue{}, errNotConstant
+	}
+
+	l, r := left.integer, right.integer
+	switch operator {
+	case "+":
+		return e.newInteger(rng, l+r)
+	case "-":
+		return e.newInteger(rng, l-r)
+	case "*":
+		return e.newInteger(rng, l*r)
+	case "/":
+		return e.newInteger(rng, l/r)
+	case "%":
+		return constValue{exprType: integerType, integer: l % r}, nil
+	case "&":
+		return constValue{exprType: integerType, integer: l & r}, nil
+	case "|":
+		return constValue{exprType: integerType, integer: l | r}, nil
+	case "^":
+		return constValue{exprType: integerType, integer: l ^ r}, nil
+	case "<<", ">>":
+		if r < 0 || r > 31 {
+			return constValue{}, e.newError(rng, fmt.Sprintf("Shift count %d is out of range, it must be between 0 and 31.", r))
+		}
+		// Shifts work on the bits of the integer, shifting into the sign bit
+		// is how the 12d headers build bit masks and is not an overflow.
+		if operator == "<<" {
+			return constValue{exprType: integerType, integer: int64(int32(uint32(l) << r))}, nil
+		}
+		return constValue{exprType: integerType, integer: int64(int32(l) >> r)}, nil
+	}
+	return constValue{}, errNotConstant
+}
+
+// Create the integer value, returns an error if it does not fit in 32 bits.
+func (e constEvaluator) newInteger(rng protocol.Range, value int64) (constValue, error) {
+	if value < math.MinInt32 || value > math.MaxInt32 {
+		return constValue{}, e.newError(rng, fmt.Sprintf(`Constant expression overflows "Integer", the result %d is out of range.`, value))
+	}
+	return constValue{exprType: integerType, integer: value}, nil
+}
+
+func newBoolValue(value bool) constValue {
+	if value {
+		return constValue{exprType: integerType, integer: 1}
+	}
+	return constValue{exprType: integerType, integer: 0}
+}
+
+// Get the warnings for the constant expressions of the document which divide
+// by zero or overflow, including the values of its "#define" constants.
+func (s *Server) getConstantDiagnostics(uri string) []protocol.Diagnostic {
+	result := []protocol.Diagnostic{}
+	doc, ok := s.documents[uri]
+	if !ok {
+		return result
+	}
+	evaluator := newConstEvaluator(uri, s.documents, s.includesDir)
+	newWarning := func(err *constError) protocol.Diagnostic {
+		return protocol.Diagnostic{
+			Range:    err.rng,
+			Severity: protocol.DiagnosticSeverityWarning,
+			Source:   SourceName,
+			Message:  err.message,
+		}
+	}
+	visitPreprocDef := func(nameNode *sitter.Node) {
+		var err *constError
+		// Only the errors in the value of this definition, errors in the
+		// definitions it references are reported on them.
+		startLine := uint(nameNode.StartPoint().Row)
+		endLine := startLine + uint(strings.Count(getPreprocDefValue(nameNode, doc.SourceCode), "\n"))
+		if _, evalErr := evaluator.evalPreprocDef(nameNode); errors.As(evalErr, &err) && err.uri == uri &&
+			err.rng.Start.Line >= startLine && err.rng.End.Line <= endLine {
+			result = append(result, newWarning(err))
+		}
+	}
+	var visit func(node *sitter.Node)
+	visit = func(node *sitter.Node) {
+		switch node.Type() {
+		case "preproc_def":
+			for _, nameNode := range getPreprocDefNameNodes(node) {
+				visitPreprocDef(nameNode)
+			}
+			return
+
+		case "ERROR":
+			// Definitions the parser could not parse, the nested error nodes
+			// are visited below.
+			for i := 0; i < int(node.NamedChildCount()); i++ {
+				if isPreprocDefName(node.NamedChild(i)) {
+					visitPreprocDef(node.NamedChild(i))
+				}
+			}
+
+		case "binary_expression", "unary_expression", "number_literal":
+			if node.HasError() {
+				break
+			}
+			var err *constError
+			if _, evalErr := evaluator.eval(node); errors.As(evalErr, &err) {
+				if err.uri == uri && err.rng == getNodeRange(node) {
+					result = append(result, newWarning(err))
+				}
+				break
+			}
+			// Division of a variable by zero.
+			operatorNode := node.ChildByFieldName("operator")
+			rightNode := node.ChildByFieldName("right")
+			if node.Type() != "binary_expression" || operatorNode == nil || rightNode == nil {
+				break
+			}
+			if operator := operatorNode.Content(doc.SourceCode); operator != "/" && operator != "%" {
+				break
+			}
+			if right, err := evaluator.eval(rightNode); err == nil && right.isZero() {
+				result = append(result, newWarning(&constError{uri: uri, rng: getNodeRange(node), message: "Division by zero."}))
+			}
+		}
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			visit(node.NamedChild(i))
+		}
+	}
+	visit(doc.RootNode)
+	return result
+}
+
+// Get the hover contents for the value of the constant expression at the
+// position, such as "1 << 4" when hovering over the operator. Literals on their
+// own are not shown.
+func getConstExprHoverContents(rootNode *sitter.Node, position protocol.Position, uri string, documents map[string]Document, includesDir string) []string {
+	point := sitter.Point{Row: uint32(position.Line), Column: uint32(position.Character)}
+	var exprNode *sitter.Node
+	for node := rootNode.NamedDescendantForPointRange(point, point); node != nil; node = node.Parent() {
+		switch node.Type() {
+		case "binary_expression", "unary_expression", "parenthesized_expression":
+			exprNode = node
+			continue
+		}
+		if exprNode != nil {
+			break
+		}
+		if node.Type() != "number_literal" && node.Type() != "string_literal" {
+			return []string{}
+		}
+	}
+	if exprNode == nil {
+		return []string{}
+	}
+	evaluator := newConstEvaluator(uri, documents, includesDir)
+	value, err := evaluator.eval(exprNode)
+	if err != nil {
+		return []string{}
+	}
+	return []string{createHoverDeclarationDocString(value.exprType.String(), value.String(), "", "constant")}
+}
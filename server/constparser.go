@@ -0,0 +1,211 @@
+package server
+
+import (
+	"strings"
+
+	"github.com/kelly-lin/12d-lang-server/protocol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Precedence of the binary operators, higher binds tighter.
+var binaryPrecedence = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"|":  3,
+	"^":  4,
+	"&":  5,
+	"==": 6, "!=": 6,
+	"<": 7, "<=": 7, ">": 7, ">=": 7,
+	"<<": 8, ">>": 8,
+	"+": 9, "-": 9,
+	"*": 10, "/": 10, "%": 10,
+}
+
+// Operators ordered so that the longest operator is matched first.
+var constOperators = []string{
+	"<<", ">>", "<=", ">=", "==", "!=", "&&", "||",
+	"+", "-", "*", "/", "%", "&", "|", "^", "~", "!", "<", ">", "(", ")",
+}
+
+// Parses and evaluates the value of a "#define" as a 12dPL expression.
+type constParser struct {
+	evaluator constEvaluator
+	// Name of the definition, identifiers in the value are resolved from it.
+	nameNode *sitter.Node
+	// Text of the value and the position of its start in the document.
+	value string
+	start protocol.Position
+	// The current token, empty at the end of the value, and its offset.
+	token      string
+	tokenStart int
+	// Offset of the end of the token before the current token.
+	prevEnd int
+}
+
+// Advance to the next token, skipping whitespace, comments and line
+// continuations.
+func (p *constParser) next() {
+	p.prevEnd = p.tokenStart + len(p.token)
+	pos := p.prevEnd
+	for pos < len(p.value) {
+		rest := p.value[pos:]
+		switch {
+		case strings.HasPrefix(rest, "\\\n"):
+			pos += 2
+		case strings.HasPrefix(rest, "\\\r\n"):
+			pos += 3
+		case rest[0] == ' ' || rest[0] == '\t' || rest[0] == '\r' || rest[0] == '\n':
+			pos++
+		case strings.HasPrefix(rest, "//"):
+			pos = len(p.value)
+		case strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest[2:], "*/")
+			if end == -1 {
+				pos = len(p.value)
+			} else {
+				pos += end + 4
+			}
+		default:
+			p.tokenStart = pos
+			p.token = rest[:getConstTokenLen(rest)]
+			return
+		}
+	}
+	p.tokenStart = pos
+	p.token = ""
+}
+
+// Get the length of the token at the start of the text.
+func getConstTokenLen(text string) int {
+	isIdentifierChar := func(c byte) bool {
+		return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+	}
+	switch c := text[0]; {
+	case c == '"':
+		for i := 1; i < len(text); i++ {
+			if text[i] == '\\' {
+				i++
+			} else if text[i] == '"' {
+				return i + 1
+			}
+		}
+		return len(text)
+
+	case c >= '0' && c <= '9' || c == '.' && len(text) > 1 && text[1] >= '0' && text[1] <= '9':
+		isHex := strings.HasPrefix(text, "0x") || strings.HasPrefix(text, "0X")
+		i := 1
+		for i < len(text) && (isIdentifierChar(text[i]) || text[i] == '.') {
+			// Signed exponents such as "1e-3".
+			if !isHex && (text[i] == 'e' || text[i] == 'E') && i+1 < len(text) && (text[i+1] == '-' || text[i+1] == '+') {
+				i++
+			}
+			i++
+		}
+		return i
+
+	case isIdentifierChar(c):
+		i := 1
+		for i < len(text) && isIdentifierChar(text[i]) {
+			i++
+		}
+		return i
+	}
+	for _, operator := range constOperators {
+		if strings.HasPrefix(text, operator) {
+			return len(operator)
+		}
+	}
+	return 1
+}
+
+// Get the position in the document of the offset in the value.
+func (p *constParser) position(offset int) protocol.Position {
+	text := p.value[:offset]
+	lines := strings.Count(text, "\n")
+	if lines == 0 {
+		return protocol.Position{Line: p.start.Line, Character: p.start.Character + uint(offset)}
+	}
+	return protocol.Position{Line: p.start.Line + uint(lines), Character: uint(len(text) - strings.LastIndex(text, "\n") - 1)}
+}
+
+// Get the range from the offset to the end of the last token parsed.
+func (p *constParser) rangeFrom(offset int) protocol.Range {
+	return protocol.Range{Start: p.position(offset), End: p.position(p.prevEnd)}
+}
+
+// Parse and evaluate the binary expression whose operators bind at least as
+// tight as the precedence. Returns the offset of the start of the expression.
+func (p *constParser) parseExpr(precedence int) (constValue, int, error) {
+	left, start, err := p.parseUnary()
+	if err != nil {
+		return constValue{}, start, err
+	}
+	for {
+		operatorPrecedence, ok := binaryPrecedence[p.token]
+		if !ok || operatorPrecedence < precedence {
+			return left, start, nil
+		}
+		operator := p.token
+		p.next()
+		right, _, err := p.parseExpr(operatorPrecedence + 1)
+		if err != nil {
+			return constValue{}, start, err
+		}
+		left, err = p.evaluator.evalBinary(p.rangeFrom(start), operator, left, right)
+		if err != nil {
+			return constValue{}, start, err
+		}
+	}
+}
+
+func (p *constParser) parseUnary() (constValue, int, error) {
+	start := p.tokenStart
+	switch p.token {
+	case "-", "+", "~", "!":
+		operator := p.token
+		p.next()
+		argument, _, err := p.parseUnary()
+		if err != nil {
+			return constValue{}, start, err
+		}
+		result, err := p.evaluator.evalUnary(p.rangeFrom(start), operator, argument)
+		return result, start, err
+
+	case "(":
+		p.next()
+		result, _, err := p.parseExpr(0)
+		if err != nil {
+			return constValue{}, start, err
+		}
+		if p.token != ")" {
+			return constValue{}, start, errNotConstant
+		}
+		p.next()
+		return result, start, nil
+
+	case "":
+		return constValue{}, start, errNotConstant
+	}
+
+	token := p.token
+	p.next()
+	switch c := token[0]; {
+	case c == '"':
+		// Adjacent strings are concatenated.
+		result := evalStringLiteral(token)
+		for strings.HasPrefix(p.token, `"`) {
+			result.text += evalStringLiteral(p.token).text
+			p.next()
+		}
+		return result, start, nil
+
+	case c >= '0' && c <= '9' || c == '.':
+		result, err := p.evaluator.evalNumberLiteral(token, p.rangeFrom(start))
+		return result, start, err
+
+	case c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+		result, err := p.evaluator.evalIdentifier(p.nameNode, token)
+		return result, start, err
+	}
+	return constValue{}, start, errNotConstant
+}
@@ -0,0 +1,263 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kelly-lin/12d-lang-server/lang"
+	parser "github.com/kelly-lin/12d-lang-server/parser/12dpl"
+	"github.com/kelly-lin/12d-lang-server/protocol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// A function definition and the document it is defined in.
+type funcDefinition struct {
+	uri            string
+	identifierNode *sitter.Node
+	// Types of the parameters, "Integer" or "Text[]" for an array.
+	paramTypes []string
+}
+
+// Get the diagnostics of the variables, parameters and functions of the
+// document which are declared more than once or shadow another declaration.
+// Each diagnostic has related information pointing at the earlier
+// declaration.
+func (s *Server) getDuplicateDiagnostics(uri string) []protocol.Diagnostic {
+	result := []protocol.Diagnostic{}
+	doc, ok := s.documents[uri]
+	if !ok {
+		return result
+	}
+	sourceCode := doc.SourceCode
+
+	// Variables declared twice in the same block, including the global
+	// variables of the document.
+	var visit func(node *sitter.Node)
+	visit = func(node *sitter.Node) {
+		if node.Type() == "compound_statement" || node.Type() == "source_file" {
+			declared := map[string]*sitter.Node{}
+			for i := 0; i < int(node.NamedChildCount()); i++ {
+				declarationNode := node.NamedChild(i)
+				if declarationNode.Type() != "declaration" {
+					continue
+				}
+				for _, identifierNode := range getDeclaratorIdentifierNodes(declarationNode) {
+					identifier := identifierNode.Content(sourceCode)
+					if prevNode, ok := declared[identifier]; ok {
+						result = append(result, newDuplicateDiagnostic(
+							identifierNode,
+							protocol.DiagnosticSeverityError,
+							fmt.Sprintf(`Variable "%s" is already declared in this scope.`, identifier),
+							uri,
+							prevNode,
+							fmt.Sprintf(`"%s" is first declared here.`, identifier),
+						))
+						continue
+					}
+					declared[identifier] = identifierNode
+				}
+			}
+		}
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			visit(node.NamedChild(i))
+		}
+	}
+	visit(doc.RootNode)
+
+	// Parameters declared twice and locals which shadow a parameter.
+	for i := 0; i < int(doc.RootNode.NamedChildCount()); i++ {
+		funcDefNode := doc.RootNode.NamedChild(i)
+		if funcDefNode.Type() != "function_definition" {
+			continue
+		}
+		paramsNode := getFuncDefParamsNode(funcDefNode)
+		bodyNode := funcDefNode.ChildByFieldName("body")
+		if paramsNode == nil || bodyNode == nil {
+			continue
+		}
+		params := map[string]*sitter.Node{}
+		for _, identifierNode := range getParamIdentifierNodes(paramsNode) {
+			identifier := identifierNode.Content(sourceCode)
+			if prevNode, ok := params[identifier]; ok {
+				result = append(result, newDuplicateDiagnostic(
+					identifierNode,
+					protocol.DiagnosticSeverityError,
+					fmt.Sprintf(`Parameter "%s" is already declared.`, identifier),
+					uri,
+					prevNode,
+					fmt.Sprintf(`"%s" is first declared here.`, identifier),
+				))
+				continue
+			}
+			params[identifier] = identifierNode
+		}
+		for _, identifierNode := range getLocalIdentifierNodes(bodyNode) {
+			identifier := identifierNode.Content(sourceCode)
+			if paramNode, ok := params[identifier]; ok {
+				result = append(result, newDuplicateDiagnostic(
+					identifierNode,
+					protocol.DiagnosticSeverityWarning,
+					fmt.Sprintf(`Variable "%s" shadows a parameter.`, identifier),
+					uri,
+					paramNode,
+					fmt.Sprintf(`Parameter "%s" is declared here.`, identifier),
+				))
+			}
+		}
+	}
+
+	// Functions with the same signature as a function defined earlier in the
+	// document, a function of an include or a library function.
+	includedFuncDefs := []funcDefinition{}
+	for _, includeURI := range s.getIncludedURIs(uri, map[string]bool{uri: true}) {
+		includedFuncDefs = append(includedFuncDefs, s.getFuncDefinitions(includeURI)...)
+	}
+	funcDefs := s.getFuncDefinitions(uri)
+	for idx, funcDef := range funcDefs {
+		identifier := funcDef.identifierNode.Content(sourceCode)
+		prevFuncDefs := append(append([]funcDefinition{}, includedFuncDefs...), funcDefs[:idx]...)
+		if prevFuncDef, ok := findSameSignature(funcDef, prevFuncDefs, s.documents); ok {
+			result = append(result, newDuplicateDiagnostic(
+				funcDef.identifierNode,
+				protocol.DiagnosticSeverityError,
+				fmt.Sprintf(`Function "%s" is already defined with the same parameter types.`, identifier),
+				prevFuncDef.uri,
+				prevFuncDef.identifierNode,
+				fmt.Sprintf(`"%s" is first defined here.`, identifier),
+			))
+			continue
+		}
+		if signature, ok := findLibSignature(identifier, funcDef.paramTypes); ok {
+			result = append(result, protocol.Diagnostic{
+				Range:    getNodeRange(funcDef.identifierNode),
+				Severity: protocol.DiagnosticSeverityWarning,
+				Source:   SourceName,
+				Message:  fmt.Sprintf(`Function "%s" shadows the library function "%s".`, identifier, signature),
+			})
+		}
+	}
+	return result
+}
+
+func newDuplicateDiagnostic(identifierNode *sitter.Node, severity uint, message, prevURI string, prevNode *sitter.Node, prevMessage string) protocol.Diagnostic {
+	return protocol.Diagnostic{
+		Range:    getNodeRange(identifierNode),
+		Severity: severity,
+		Source:   SourceName,
+		Message:  message,
+		RelatedInformation: []protocol.DiagnosticRelatedInformation{
+			{
+				Location: protocol.Location{URI: prevURI, Range: getNodeRange(prevNode)},
+				Message:  prevMessage,
+			},
+		},
+	}
+}
+
+// Get the identifier nodes of the parameters of the parameter list node.
+func getParamIdentifierNodes(paramsNode *sitter.Node) []*sitter.Node {
+	result := []*sitter.Node{}
+	for i := 0; i < int(paramsNode.NamedChildCount()); i++ {
+		paramNode := paramsNode.NamedChild(i)
+		if paramNode.Type() != "parameter_declaration" {
+			continue
+		}
+		if identifierNode := getDeclaratorIdentifierNode(paramNode.ChildByFieldName("declarator")); identifierNode != nil {
+			result = append(result, identifierNode)
+		}
+	}
+	return result
+}
+
+// Get the function definitions of the document described by uri.
+func (s *Server) getFuncDefinitions(uri string) []funcDefinition {
+	result := []funcDefinition{}
+	doc, ok := s.documents[uri]
+	if !ok {
+		return result
+	}
+	for i := 0; i < int(doc.RootNode.NamedChildCount()); i++ {
+		funcDefNode := doc.RootNode.NamedChild(i)
+		if funcDefNode.Type() != "function_definition" || funcDefNode.HasError() {
+			continue
+		}
+		identifierNode := getFuncDefIdentifierNode(funcDefNode)
+		paramsNode := getFuncDefParamsNode(funcDefNode)
+		if identifierNode == nil || paramsNode == nil {
+			continue
+		}
+		paramTypes := []string{}
+		for _, paramIdentifierNode := range getParamIdentifierNodes(paramsNode) {
+			paramType, err := getDefinitionType(paramIdentifierNode, doc.SourceCode)
+			if err != nil {
+				continue
+			}
+			paramTypes = append(paramTypes, paramType)
+		}
+		result = append(result, funcDefinition{uri: uri, identifierNode: identifierNode, paramTypes: paramTypes})
+	}
+	return result
+}
+
+// Get the uris of the documents which the document described by uri includes,
+// directly or through other includes, which have been loaded.
+func (s *Server) getIncludedURIs(uri string, visited map[string]bool) []string {
+	result := []string{}
+	doc, ok := s.documents[uri]
+	if !ok {
+		return result
+	}
+	includeNodes, err := parser.FindChildren(doc.RootNode, "preproc_include")
+	if err != nil {
+		return result
+	}
+	for _, includeNode := range includeNodes {
+		includeFilepath, ok := s.resolveInclude(includeNode, doc.SourceCode, uri)
+		if !ok {
+			continue
+		}
+		includeURI := protocol.URI(includeFilepath)
+		if _, ok := s.documents[includeURI]; !ok || visited[includeURI] {
+			continue
+		}
+		visited[includeURI] = true
+		result = append(result, includeURI)
+		result = append(result, s.getIncludedURIs(includeURI, visited)...)
+	}
+	return result
+}
+
+// Find the function definition with the same name and parameter types as the
+// function definition.
+func findSameSignature(funcDef funcDefinition, funcDefs []funcDefinition, documents map[string]Document) (funcDefinition, bool) {
+	identifier := funcDef.identifierNode.Content(documents[funcDef.uri].SourceCode)
+	for _, otherFuncDef := range funcDefs {
+		otherIdentifier := otherFuncDef.identifierNode.Content(documents[otherFuncDef.uri].SourceCode)
+		if otherIdentifier == identifier && isSameParamTypes(funcDef.paramTypes, otherFuncDef.paramTypes) {
+			return otherFuncDef, true
+		}
+	}
+	return funcDefinition{}, false
+}
+
+// Find the overload of the library function with the parameter types.
+func findLibSignature(identifier string, paramTypes []string) (lang.Signature, bool) {
+	signatures, err := lang.GetLibSignatures(identifier)
+	if err != nil {
+		return lang.Signature{}, false
+	}
+	for _, signature := range signatures {
+		libParamTypes := []string{}
+		for _, param := range signature.Params {
+			libParamTypes = append(libParamTypes, getParamType(param).String())
+		}
+		if isSameParamTypes(paramTypes, libParamTypes) {
+			return signature, true
+		}
+	}
+	return lang.Signature{}, false
+}
+
+func isSameParamTypes(paramTypes, otherParamTypes []string) bool {
+	return strings.Join(paramTypes, ",") == strings.Join(otherParamTypes, ",")
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/kelly-lin/12d-lang-server/protocol"
+)
+
+// Get the diagnostics of the "main" functions of the document. A macro, a
+// ".4dm" file, must define exactly one "void main()" to be run and headers,
+// ".h" files, must not define "main".
+func (s *Server) getEntryPointDiagnostics(uri string) []protocol.Diagnostic {
+	result := []protocol.Diagnostic{}
+	doc, ok := s.documents[uri]
+	if !ok {
+		return result
+	}
+	sourceCode := doc.SourceCode
+	mainFuncDefs := []funcDefinition{}
+	for _, funcDef := range s.getFuncDefinitions(uri) {
+		if funcDef.identifierNode.Content(sourceCode) == "main" {
+			mainFuncDefs = append(mainFuncDefs, funcDef)
+		}
+	}
+
+	switch filepath.Ext(uri) {
+	case ".h":
+		for _, funcDef := range mainFuncDefs {
+			result = append(result, protocol.Diagnostic{
+				Range:    getNodeRange(funcDef.identifierNode),
+				Severity: protocol.DiagnosticSeverityWarning,
+				Source:   SourceName,
+				Message:  `Header defines "main", "main" should only be defined in a macro.`,
+			})
+		}
+
+	case ".4dm":
+		// The definition of main may be missing because it could not be
+		// parsed.
+		if len(mainFuncDefs) == 0 && !doc.RootNode.HasError() {
+			result = append(result, protocol.Diagnostic{
+				Range:    protocol.Range{},
+				Severity: protocol.DiagnosticSeverityError,
+				Source:   SourceName,
+				Message:  `Macro does not define "void main()", the macro cannot be run.`,
+			})
+		}
+		for idx, funcDef := range mainFuncDefs {
+			funcDefNode := getEnclosingFuncDefNode(funcDef.identifierNode)
+			if typeNode := funcDefNode.ChildByFieldName("type"); typeNode != nil && typeNode.Content(sourceCode) != "void" {
+				result = append(result, protocol.Diagnostic{
+					Range:    getNodeRange(typeNode),
+					Severity: protocol.DiagnosticSeverityError,
+					Source:   SourceName,
+					Message:  fmt.Sprintf(`Function "main" must return "void" but returns "%s".`, typeNode.Content(sourceCode)),
+				})
+			}
+			if paramsNode := getFuncDefParamsNode(funcDefNode); len(getParamIdentifierNodes(paramsNode)) > 0 {
+				result = append(result, protocol.Diagnostic{
+					Range:    getNodeRange(paramsNode),
+					Severity: protocol.DiagnosticSeverityError,
+					Source:   SourceName,
+					Message:  `Function "main" must not have parameters.`,
+				})
+			}
+			// Definitions with the same parameters are reported as duplicate
+			// functions.
+			if idx == 0 {
+				continue
+			}
+			if _, ok := findSameSignature(funcDef, mainFuncDefs[:idx], s.documents); ok {
+				continue
+			}
+			result = append(result, newDuplicateDiagnostic(
+				funcDef.identifierNode,
+				protocol.DiagnosticSeverityError,
+				`Macro defines "main" more than once, a macro must define exactly one "main".`,
+				uri,
+				mainFuncDefs[0].identifierNode,
+				`"main" is first defined here.`,
+			))
+		}
+	}
+	return result
+}
@@ -0,0 +1,243 @@
+package server
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kelly-lin/12d-lang-server/protocol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Get the diagnostics of the return statements of the function definitions of
+// the document. Non void functions must return a value of their return type on
+// every path and void functions must not return a value.
+func (s *Server) getReturnDiagnostics(uri string) []protocol.Diagnostic {
+	result := []protocol.Diagnostic{}
+	doc, ok := s.documents[uri]
+	if !ok {
+		return result
+	}
+	checker := typeChecker{uri: uri, sourceCode: doc.SourceCode, documents: s.documents, includesDir: s.includesDir}
+	for i := 0; i < int(doc.RootNode.NamedChildCount()); i++ {
+		funcDefNode := doc.RootNode.NamedChild(i)
+		if funcDefNode.Type() != "function_definition" {
+			continue
+		}
+		result = append(result, checker.checkReturns(funcDefNode)...)
+	}
+	return result
+}
+
+// Check the return statements of the function definition node.
+func (c typeChecker) checkReturns(funcDefNode *sitter.Node) []protocol.Diagnostic {
+	result := []protocol.Diagnostic{}
+	declaratorNode := funcDefNode.ChildByFieldName("declarator")
+	bodyNode := funcDefNode.ChildByFieldName("body")
+	if declaratorNode == nil || declaratorNode.Type() != "function_declarator" || bodyNode == nil {
+		return result
+	}
+	identifierNode := declaratorNode.ChildByFieldName("declarator")
+	doc, err := getFuncDoc(funcDefNode, c.sourceCode)
+	if identifierNode == nil || err != nil {
+		return result
+	}
+	funcName := identifierNode.Content(c.sourceCode)
+	isVoid := doc.VarType == "void"
+	returnType := newExprType(doc.VarType)
+
+	for _, returnNode := range getReturnNodes(bodyNode) {
+		valueNode := returnNode.NamedChild(0)
+		switch {
+		case isVoid && valueNode != nil:
+			result = append(result, protocol.Diagnostic{
+				Range:    getNodeRange(valueNode),
+				Severity: protocol.DiagnosticSeverityError,
+				Source:   SourceName,
+				Message:  fmt.Sprintf(`Function "%s" is void and cannot return a value.`, funcName),
+			})
+
+		case !isVoid && valueNode == nil:
+			result = append(result, protocol.Diagnostic{
+				Range:    getNodeRange(returnNode),
+				Severity: protocol.DiagnosticSeverityError,
+				Source:   SourceName,
+				Message:  fmt.Sprintf(`Function "%s" must return a value of type "%s".`, funcName, doc.VarType),
+			})
+
+		case !isVoid && !returnNode.HasError():
+			if valueType := c.getType(valueNode); !isAssignable(valueType, returnType) {
+				result = append(result, protocol.Diagnostic{
+					Range:    getNodeRange(valueNode),
+					Severity: protocol.DiagnosticSeverityError,
+					Source:   SourceName,
+					Message:  fmt.Sprintf(`Type "%s" is not assignable to return type "%s".`, valueType, returnType),
+				})
+			}
+		}
+	}
+
+	// Functions with syntax errors are not checked since we cannot tell where
+	// their paths go.
+	if !isVoid && !bodyNode.HasError() && canCompleteNormally(bodyNode, c.sourceCode) {
+		result = append(result, protocol.Diagnostic{
+			Range:    getNodeRange(identifierNode),
+			Severity: protocol.DiagnosticSeverityError,
+			Source:   SourceName,
+			Message:  fmt.Sprintf(`Function "%s" does not return a value on all code paths.`, funcName),
+		})
+	}
+	return result
+}
+
+// Get the return statement nodes inside of the node.
+func getReturnNodes(node *sitter.Node) []*sitter.Node {
+	result := []*sitter.Node{}
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		childNode := node.NamedChild(i)
+		if childNode.Type() == "return_statement" {
+			result = append(result, childNode)
+			continue
+		}
+		result = append(result, getReturnNodes(childNode)...)
+	}
+	return result
+}
+
+// Returns true if execution can continue after the statement node, false if
+// every path through the statement returns or jumps away from it. Loops are
+// assumed to run any number of times unless their condition is a non zero
+// constant.
+func canCompleteNormally(node *sitter.Node, sourceCode []byte) bool {
+	switch node.Type() {
+	case "return_statement", "break_statement", "continue_statement", "goto_statement":
+		return false
+
+	case "compound_statement":
+		statementNodes := []*sitter.Node{}
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			statementNodes = append(statementNodes, node.NamedChild(i))
+		}
+		return canStatementsCompleteNormally(statementNodes, sourceCode)
+
+	case "labeled_statement":
+		statementNode := node.NamedChild(int(node.NamedChildCount()) - 1)
+		return statementNode == nil || statementNode.Type() == "statement_identifier" || canCompleteNormally(statementNode, sourceCode)
+
+	case "if_statement":
+		consequenceNode := node.ChildByFieldName("consequence")
+		alternativeNode := node.ChildByFieldName("alternative")
+		if consequenceNode == nil || alternativeNode == nil {
+			return true
+		}
+		return canCompleteNormally(consequenceNode, sourceCode) || canCompleteNormally(alternativeNode, sourceCode)
+
+	case "while_statement", "for_statement":
+		bodyNode := node.ChildByFieldName("body")
+		if bodyNode == nil {
+			bodyNode = node.NamedChild(int(node.NamedChildCount()) - 1)
+		}
+		conditionNode := node.ChildByFieldName("condition")
+		// A for loop without a condition, "for (;;)", loops forever.
+		isForever := node.Type() == "for_statement" && (conditionNode == nil || conditionNode.IsMissing())
+		return !isForever && !isConstantTrue(conditionNode, sourceCode) || bodyNode != nil && hasBreak(bodyNode)
+
+	case "switch_statement":
+		// Cases fall through to the next case, a switch without a default case
+		// or a break completes normally unless its last case does not.
+		bodyNode := node.ChildByFieldName("body")
+		if bodyNode == nil || hasBreak(bodyNode) {
+			return true
+		}
+		hasDefault := false
+		var lastCaseNode *sitter.Node
+		for i := 0; i < int(bodyNode.NamedChildCount()); i++ {
+			caseNode := bodyNode.NamedChild(i)
+			if caseNode.Type() != "case_statement" {
+				continue
+			}
+			lastCaseNode = caseNode
+			if caseNode.ChildByFieldName("value") == nil {
+				hasDefault = true
+			}
+		}
+		if !hasDefault || lastCaseNode == nil {
+			return true
+		}
+		return canStatementsCompleteNormally(getCaseStatementNodes(lastCaseNode), sourceCode)
+	}
+	return true
+}
+
+// Returns true if the execution can continue after the last of the statements
+// of a block. Statements after a label are reachable through a goto.
+func canStatementsCompleteNormally(statementNodes []*sitter.Node, sourceCode []byte) bool {
+	isReachable := true
+	for _, statementNode := range statementNodes {
+		if statementNode.Type() == "labeled_statement" {
+			isReachable = true
+		}
+		if isReachable && !canCompleteNormally(statementNode, sourceCode) {
+			isReachable = false
+		}
+	}
+	return isReachable
+}
+
+// Get the statement nodes of the case statement node, the statements follow
+// the ":" after the case value.
+func getCaseStatementNodes(caseNode *sitter.Node) []*sitter.Node {
+	result := []*sitter.Node{}
+	isStatement := false
+	for i := 0; i < int(caseNode.ChildCount()); i++ {
+		childNode := caseNode.Child(i)
+		if isStatement && childNode.IsNamed() && childNode.Type() != "comment" {
+			result = append(result, childNode)
+		}
+		isStatement = isStatement || childNode.Type() == ":"
+	}
+	return result
+}
+
+// Returns true if the condition node is a non zero number literal, optionally
+// in parentheses.
+func isConstantTrue(conditionNode *sitter.Node, sourceCode []byte) bool {
+	for conditionNode != nil && conditionNode.Type() == "parenthesized_expression" {
+		conditionNode = conditionNode.NamedChild(0)
+	}
+	if conditionNode == nil || conditionNode.Type() != "number_literal" {
+		return false
+	}
+	isZero, ok := isZeroLiteral(conditionNode.Content(sourceCode))
+	return ok && !isZero
+}
+
+// Returns true if the number literal text is zero, such as "0", "0x0" or
+// "0.0e0". Returns false for the second value if the literal is not a valid
+// number.
+func isZeroLiteral(literal string) (bool, bool) {
+	if getNumberLiteralType(literal) == realType {
+		value, err := strconv.ParseFloat(literal, 64)
+		return value == 0, err == nil
+	}
+	value, err := strconv.ParseInt(literal, 0, 64)
+	return value == 0, err == nil
+}
+
+// Returns true if the node contains a break statement which breaks out of the
+// loop or switch whose body is the node. Breaks inside of nested loops and
+// switches break out of those instead.
+func hasBreak(node *sitter.Node) bool {
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		childNode := node.NamedChild(i)
+		switch childNode.Type() {
+		case "break_statement":
+			return true
+		case "while_statement", "for_statement", "do_statement", "switch_statement":
+			continue
+		}
+		if hasBreak(childNode) {
+			return true
+		}
+	}
+	return false
+}
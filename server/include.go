@@ -0,0 +1,255 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/kelly-lin/12d-lang-server/format"
+	parser "github.com/kelly-lin/12d-lang-server/parser/12dpl"
+	"github.com/kelly-lin/12d-lang-server/protocol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Get the document links for the includes of the document described by uri
+// which can be resolved.
+func (s *Server) getDocumentLinks(uri string) []protocol.DocumentLink {
+	result := []protocol.DocumentLink{}
+	doc, ok := s.documents[uri]
+	if !ok {
+		return result
+	}
+	includeNodes, err := parser.FindChildren(doc.RootNode, "preproc_include")
+	if err != nil {
+		return result
+	}
+	for _, includeNode := range includeNodes {
+		includeFilepath, ok := s.resolveInclude(includeNode, doc.SourceCode, uri)
+		if !ok {
+			continue
+		}
+		target := protocol.URI(includeFilepath)
+		result = append(result, protocol.DocumentLink{
+			Range:   getIncludePathRange(includeNode.ChildByFieldName("path")),
+			Target:  &target,
+			Tooltip: includeFilepath,
+		})
+	}
+	return result
+}
+
+// Get the diagnostics for the includes of the document described by uri which
+// cannot be resolved.
+func (s *Server) getIncludeDiagnostics(uri string) []protocol.Diagnostic {
+	var result []protocol.Diagnostic
+	doc, ok := s.documents[uri]
+	if !ok {
+		return result
+	}
+	includeNodes, err := parser.FindChildren(doc.RootNode, "preproc_include")
+	if err != nil {
+		return result
+	}
+	for _, includeNode := range includeNodes {
+		pathNode := includeNode.ChildByFieldName("path")
+		if pathNode == nil {
+			continue
+		}
+		if includeFilepath, ok := s.resolveInclude(includeNode, doc.SourceCode, uri); !ok {
+			result = append(result, protocol.Diagnostic{
+				Range:    getIncludePathRange(pathNode),
+				Severity: protocol.DiagnosticSeverityError,
+				Source:   SourceName,
+				Message:  fmt.Sprintf(`Include file "%s" could not be resolved.`, includeFilepath),
+			})
+		}
+	}
+	return result
+}
+
+// Get the range of the include path excluding the surrounding quotes or angle
+// brackets.
+func getIncludePathRange(pathNode *sitter.Node) protocol.Range {
+	return protocol.Range{
+		Start: protocol.Position{
+			Line:      uint(pathNode.StartPoint().Row),
+			Character: uint(pathNode.StartPoint().Column) + 1,
+		},
+		End: protocol.Position{
+			Line:      uint(pathNode.EndPoint().Row),
+			Character: uint(pathNode.EndPoint().Column) - 1,
+		},
+	}
+}
+
+// Get what is known about the includes of the document described by uri for
+// organizing them. An include depends on the other includes which define the
+// names it references. An include is unused when none of the names it defines
+// are referenced by the document and no used include depends on it. Includes
+// which cannot be resolved, or which include headers that have not been
+// loaded, are never unused.
+func (s *Server) getIncludeInfo(uri string) format.IncludeInfo {
+	result := format.IncludeInfo{Unused: map[string]bool{}, Dependencies: map[string][]string{}}
+	doc, ok := s.documents[uri]
+	if !ok {
+		return result
+	}
+	includeNodes, err := parser.FindChildren(doc.RootNode, "preproc_include")
+	if err != nil {
+		return result
+	}
+	namesByPath := map[string]map[string]bool{}
+	referencedNamesByPath := map[string]map[string]bool{}
+	paths := []string{}
+	for _, includeNode := range includeNodes {
+		includeFilepath, ok := s.resolveInclude(includeNode, doc.SourceCode, uri)
+		if !ok {
+			continue
+		}
+		includeURI := protocol.URI(includeFilepath)
+		includeDoc, ok := s.documents[includeURI]
+		if !ok {
+			continue
+		}
+		names, ok := s.getDefinedNames(includeURI, map[string]bool{})
+		if !ok {
+			continue
+		}
+		path := format.GetIncludePath(includeNode, doc.SourceCode)
+		namesByPath[path] = names
+		referencedNamesByPath[path] = getReferencedNames(includeDoc.RootNode, includeDoc.SourceCode)
+		paths = append(paths, path)
+	}
+
+	for _, path := range paths {
+		for _, otherPath := range paths {
+			if otherPath == path {
+				continue
+			}
+			// Names which the include defines itself are not dependencies.
+			otherNames := map[string]bool{}
+			for name := range namesByPath[otherPath] {
+				if !namesByPath[path][name] {
+					otherNames[name] = true
+				}
+			}
+			if containsAny(referencedNamesByPath[path], otherNames) {
+				result.Dependencies[path] = append(result.Dependencies[path], otherPath)
+			}
+		}
+	}
+
+	// Includes which are depended on by used includes are used too.
+	referencedNames := getReferencedNames(doc.RootNode, doc.SourceCode)
+	isUsed := map[string]bool{}
+	var markUsed func(path string)
+	markUsed = func(path string) {
+		if isUsed[path] {
+			return
+		}
+		isUsed[path] = true
+		for _, dependency := range result.Dependencies[path] {
+			markUsed(dependency)
+		}
+	}
+	for _, path := range paths {
+		if containsAny(referencedNames, namesByPath[path]) {
+			markUsed(path)
+		}
+	}
+	for _, path := range paths {
+		if !isUsed[path] {
+			result.Unused[path] = true
+		}
+	}
+	return result
+}
+
+// Get the names of the functions, global variables and preprocessor
+// definitions defined by the document described by uri and the documents it
+// includes. Returns false if an include of the document has not been loaded.
+func (s *Server) getDefinedNames(uri string, visited map[string]bool) (map[string]bool, bool) {
+	result := map[string]bool{}
+	// Include cycles define nothing new.
+	if visited[uri] {
+		return result, true
+	}
+	visited[uri] = true
+	doc, ok := s.documents[uri]
+	if !ok {
+		return result, false
+	}
+	for i := 0; i < int(doc.RootNode.NamedChildCount()); i++ {
+		node := doc.RootNode.NamedChild(i)
+		switch node.Type() {
+		case "function_definition":
+			if identifierNode := getFuncDefIdentifierNode(node); identifierNode != nil {
+				result[identifierNode.Content(doc.SourceCode)] = true
+			}
+		case "preproc_def", "ERROR":
+			for _, nameNode := range getPreprocDefNameNodes(node) {
+				result[nameNode.Content(doc.SourceCode)] = true
+			}
+		case "declaration":
+			for _, identifierNode := range getDeclaratorIdentifierNodes(node) {
+				result[identifierNode.Content(doc.SourceCode)] = true
+			}
+		case "preproc_include":
+			includeFilepath, ok := s.resolveInclude(node, doc.SourceCode, uri)
+			if !ok {
+				return result, false
+			}
+			names, ok := s.getDefinedNames(protocol.URI(includeFilepath), visited)
+			if !ok {
+				return result, false
+			}
+			for name := range names {
+				result[name] = true
+			}
+		}
+	}
+	return result, true
+}
+
+// Get the identifier nodes of the declarators of the declaration node,
+// "a" and "b" in "Integer a, b = 1;".
+func getDeclaratorIdentifierNodes(declarationNode *sitter.Node) []*sitter.Node {
+	result := []*sitter.Node{}
+	typeNode := declarationNode.ChildByFieldName("type")
+	for i := 0; i < int(declarationNode.NamedChildCount()); i++ {
+		declaratorNode := declarationNode.NamedChild(i)
+		if typeNode != nil && declaratorNode.Equal(typeNode) {
+			continue
+		}
+		if identifierNode := getDeclaratorIdentifierNode(declaratorNode); identifierNode != nil {
+			result = append(result, identifierNode)
+		}
+	}
+	return result
+}
+
+// Get the names of the identifiers and types referenced in the node.
+func getReferencedNames(node *sitter.Node, sourceCode []byte) map[string]bool {
+	result := map[string]bool{}
+	stack := parser.NewStack()
+	stack.Push(node)
+	for stack.HasItems() {
+		currentNode, _ := stack.Pop()
+		switch currentNode.Type() {
+		case "identifier", "type_identifier":
+			result[currentNode.Content(sourceCode)] = true
+		}
+		for i := 0; i < int(currentNode.ChildCount()); i++ {
+			stack.Push(currentNode.Child(i))
+		}
+	}
+	return result
+}
+
+// Returns true if any of the names are in the set.
+func containsAny(set map[string]bool, names map[string]bool) bool {
+	for name := range names {
+		if set[name] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,227 @@
+package server
+
+import (
+	"fmt"
+
+	parser "github.com/kelly-lin/12d-lang-server/parser/12dpl"
+	"github.com/kelly-lin/12d-lang-server/protocol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Codes of the diagnostics of labels and unreachable statements.
+const (
+	DiagnosticCodeUnusedLabel     = "unused-label"
+	DiagnosticCodeUnreachableCode = "unreachable-code"
+)
+
+// Get the diagnostics of the labels and goto statements of the functions of
+// the document, labels which are defined twice, gotos to labels which are not
+// defined and labels which are never used, and the hints for statements which
+// can never be reached.
+func (s *Server) getLabelDiagnostics(uri string) []protocol.Diagnostic {
+	result := []protocol.Diagnostic{}
+	doc, ok := s.documents[uri]
+	if !ok {
+		return result
+	}
+	sourceCode := doc.SourceCode
+	for i := 0; i < int(doc.RootNode.NamedChildCount()); i++ {
+		funcDefNode := doc.RootNode.NamedChild(i)
+		if funcDefNode.Type() != "function_definition" {
+			continue
+		}
+		identifierNode := getFuncDefIdentifierNode(funcDefNode)
+		bodyNode := funcDefNode.ChildByFieldName("body")
+		if identifierNode == nil || bodyNode == nil || bodyNode.HasError() {
+			continue
+		}
+
+		labelNodes := map[string]*sitter.Node{}
+		for _, labelNode := range getLabelNodes(bodyNode) {
+			label := labelNode.Content(sourceCode)
+			if prevNode, ok := labelNodes[label]; ok {
+				result = append(result, newDuplicateDiagnostic(
+					labelNode,
+					protocol.DiagnosticSeverityError,
+					fmt.Sprintf(`Label "%s" is already defined in this function.`, label),
+					uri,
+					prevNode,
+					fmt.Sprintf(`"%s" is first defined here.`, label),
+				))
+				continue
+			}
+			labelNodes[label] = labelNode
+		}
+
+		isUsed := map[string]bool{}
+		for _, gotoLabelNode := range getGotoLabelNodes(bodyNode) {
+			label := gotoLabelNode.Content(sourceCode)
+			if _, ok := labelNodes[label]; !ok {
+				result = append(result, protocol.Diagnostic{
+					Range:    getNodeRange(gotoLabelNode),
+					Severity: protocol.DiagnosticSeverityError,
+					Source:   SourceName,
+					Message:  fmt.Sprintf(`Label "%s" is not defined in function "%s".`, label, identifierNode.Content(sourceCode)),
+				})
+				continue
+			}
+			isUsed[label] = true
+		}
+		for _, labelNode := range getLabelNodes(bodyNode) {
+			label := labelNode.Content(sourceCode)
+			if !isUsed[label] && labelNodes[label].Equal(labelNode) {
+				result = append(result, newUnusedDiagnostic(labelNode, DiagnosticCodeUnusedLabel, fmt.Sprintf(`Label "%s" is never used.`, label)))
+			}
+		}
+
+		result = append(result, getUnreachableDiagnostics(bodyNode, sourceCode)...)
+	}
+	return result
+}
+
+// Get the hints for the statements of the node which follow a statement that
+// cannot complete normally, such as a return, in the same block. Statements
+// after a label are reachable again through a goto. Each run of unreachable
+// statements is reported once.
+func getUnreachableDiagnostics(node *sitter.Node, sourceCode []byte) []protocol.Diagnostic {
+	result := []protocol.Diagnostic{}
+	if node.Type() == "compound_statement" || node.Type() == "case_statement" {
+		isReachable := true
+		var firstNode, lastNode *sitter.Node
+		report := func() {
+			if firstNode == nil {
+				return
+			}
+			result = append(result, protocol.Diagnostic{
+				Range: protocol.Range{
+					Start: getNodeRange(firstNode).Start,
+					End:   getNodeRange(lastNode).End,
+				},
+				Severity: protocol.DiagnosticSeverityHint,
+				Source:   SourceName,
+				Message:  "Unreachable code.",
+				Code:     DiagnosticCodeUnreachableCode,
+				Tags:     []uint{protocol.DiagnosticTagUnnecessary},
+			})
+			firstNode, lastNode = nil, nil
+		}
+		// The statements of a case follow the ":".
+		isStatement := node.Type() == "compound_statement"
+		for i := 0; i < int(node.ChildCount()); i++ {
+			childNode := node.Child(i)
+			if !isStatement {
+				isStatement = childNode.Type() == ":"
+				continue
+			}
+			if !childNode.IsNamed() || childNode.Type() == "comment" {
+				continue
+			}
+			if childNode.Type() == "labeled_statement" {
+				report()
+				isReachable = true
+			}
+			if !isReachable {
+				if firstNode == nil {
+					firstNode = childNode
+				}
+				lastNode = childNode
+				continue
+			}
+			if !canCompleteNormally(childNode, sourceCode) {
+				isReachable = false
+			}
+		}
+		report()
+	}
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		result = append(result, getUnreachableDiagnostics(node.NamedChild(i), sourceCode)...)
+	}
+	return result
+}
+
+// Get the statement identifier nodes of the labels defined in the node.
+func getLabelNodes(node *sitter.Node) []*sitter.Node {
+	return getStatementIdentifierNodes(node, "labeled_statement")
+}
+
+// Get the statement identifier nodes of the labels of the goto statements in
+// the node.
+func getGotoLabelNodes(node *sitter.Node) []*sitter.Node {
+	return getStatementIdentifierNodes(node, "goto_statement")
+}
+
+func getStatementIdentifierNodes(node *sitter.Node, statementType string) []*sitter.Node {
+	result := []*sitter.Node{}
+	if node.Type() == statementType {
+		if labelNode := node.ChildByFieldName("label"); labelNode != nil {
+			result = append(result, labelNode)
+		}
+		return result
+	}
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		result = append(result, getStatementIdentifierNodes(node.NamedChild(i), statementType)...)
+	}
+	return result
+}
+
+// Get the function definition node which the node is inside of.
+func getEnclosingFuncDefNode(node *sitter.Node) *sitter.Node {
+	for node != nil && node.Type() != "function_definition" {
+		node = node.Parent()
+	}
+	return node
+}
+
+// Find the statement identifier node of the label defined in the function
+// which the statement identifier node, of a label or a goto, refers to. Labels
+// are scoped to the function they are defined in.
+func findLabelDefinition(statementIdentifierNode *sitter.Node, sourceCode []byte) (*sitter.Node, bool) {
+	funcDefNode := getEnclosingFuncDefNode(statementIdentifierNode)
+	if funcDefNode == nil {
+		return nil, false
+	}
+	label := statementIdentifierNode.Content(sourceCode)
+	for _, labelNode := range getLabelNodes(funcDefNode) {
+		if labelNode.Content(sourceCode) == label {
+			return labelNode, true
+		}
+	}
+	return nil, false
+}
+
+// Get the statement identifier nodes of the goto statements in the function
+// which jump to the label.
+func getLabelReferenceNodes(labelNode *sitter.Node, sourceCode []byte) []*sitter.Node {
+	result := []*sitter.Node{}
+	funcDefNode := getEnclosingFuncDefNode(labelNode)
+	if funcDefNode == nil {
+		return result
+	}
+	label := labelNode.Content(sourceCode)
+	for _, gotoLabelNode := range getGotoLabelNodes(funcDefNode) {
+		if gotoLabelNode.Content(sourceCode) == label {
+			result = append(result, gotoLabelNode)
+		}
+	}
+	return result
+}
+
+// Get the locations of the gotos which jump to the label at the position of
+// the references request, including the label itself if the declaration is
+// requested. Returns false if there is no label at the position.
+func getLabelLocations(rootNode *sitter.Node, sourceCode []byte, params protocol.ReferenceParams) ([]protocol.Location, bool) {
+	statementIdentifierNode, err := parser.FindStatementIdentifierNode(rootNode, params.Position.Line, params.Position.Character)
+	if err != nil {
+		return nil, false
+	}
+	labelNode, ok := findLabelDefinition(statementIdentifierNode, sourceCode)
+	if !ok {
+		return nil, false
+	}
+	locations := []protocol.Location{}
+	if params.Context.IncludeDeclaration {
+		locations = append(locations, protocol.Location{URI: params.TextDocument.URI, Range: getNodeRange(labelNode)})
+	}
+	locations = append(locations, ToLocations(getLabelReferenceNodes(labelNode, sourceCode), params.TextDocument.URI)...)
+	return locations, true
+}
@@ -0,0 +1,149 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/kelly-lin/12d-lang-server/lang"
+	"github.com/kelly-lin/12d-lang-server/protocol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// A parameter of a function which arguments are passed to by reference.
+type refParam struct {
+	// Index of the parameter in the parameter list.
+	idx  int
+	name string
+}
+
+// Check that the arguments of the call which are passed to reference
+// parameters are variables, 12d cannot pass a literal, an expression or a
+// "#define" constant by reference. Returns a diagnostic for each argument
+// which is not a variable.
+func (c typeChecker) checkRefArgs(callNode *sitter.Node) []protocol.Diagnostic {
+	result := []protocol.Diagnostic{}
+	argsNode := callNode.ChildByFieldName("arguments")
+	if argsNode == nil {
+		return result
+	}
+	argNodes := getArgNodes(argsNode)
+	for _, param := range c.getRefParams(callNode) {
+		if param.idx >= len(argNodes) || c.isLvalue(argNodes[param.idx]) {
+			continue
+		}
+		result = append(result, protocol.Diagnostic{
+			Range:    getNodeRange(argNodes[param.idx]),
+			Severity: protocol.DiagnosticSeverityError,
+			Source:   SourceName,
+			Message:  fmt.Sprintf(`Argument passed to reference parameter "%s" must be a variable.`, param.name),
+		})
+	}
+	return result
+}
+
+// Get the reference parameters of the function called by the call node. The
+// parameters of a library function are only returned when they are passed by
+// reference in every overload which matches the arguments.
+func (c typeChecker) getRefParams(callNode *sitter.Node) []refParam {
+	result := []refParam{}
+	funcNode := callNode.ChildByFieldName("function")
+	argsNode := callNode.ChildByFieldName("arguments")
+	if funcNode == nil || argsNode == nil || funcNode.Type() != "identifier" {
+		return result
+	}
+	identifier := funcNode.Content(c.sourceCode)
+	if def, err := findDefinition(funcNode, identifier, c.uri, c.documents, c.includesDir); err == nil {
+		if def.Node.Parent() == nil || def.Node.Parent().Type() != "function_declarator" {
+			return result
+		}
+		paramsNode := def.Node.Parent().ChildByFieldName("parameters")
+		if paramsNode == nil {
+			return result
+		}
+		defDoc, ok := c.documents[def.URI]
+		if !ok {
+			return result
+		}
+		paramIdx := 0
+		for i := 0; i < int(paramsNode.NamedChildCount()); i++ {
+			paramNode := paramsNode.NamedChild(i)
+			if paramNode.Type() != "parameter_declaration" {
+				continue
+			}
+			declaratorNode := paramNode.ChildByFieldName("declarator")
+			if declaratorNode != nil && declaratorNode.Type() == "pointer_declarator" {
+				result = append(result, refParam{idx: paramIdx, name: getDeclaratorName(declaratorNode, defDoc.SourceCode)})
+			}
+			paramIdx++
+		}
+		return result
+	}
+
+	signatures, err := lang.GetLibSignatures(identifier)
+	if err != nil {
+		return result
+	}
+	argTypes := c.getArgTypes(argsNode)
+	matches := []lang.Signature{}
+	for _, signature := range signatures {
+		if matchOverload(signature, argTypes).isMatch() {
+			matches = append(matches, signature)
+		}
+	}
+	if len(matches) == 0 {
+		return result
+	}
+	for idx, param := range matches[0].Params {
+		isRef := true
+		for _, signature := range matches {
+			isRef = isRef && signature.Params[idx].IsRef
+		}
+		if isRef {
+			result = append(result, refParam{idx: idx, name: param.Name})
+		}
+	}
+	return result
+}
+
+// Get the name of the identifier declared by the declarator node, for example
+// "items" for the declarator "&items[]".
+func getDeclaratorName(declaratorNode *sitter.Node, sourceCode []byte) string {
+	identifierNode := getDeclaratorIdentifierNode(declaratorNode)
+	if identifierNode == nil {
+		return ""
+	}
+	return identifierNode.Content(sourceCode)
+}
+
+// Get the identifier node declared by the declarator node, array declarators
+// name their identifier "identifier" instead of "declarator".
+func getDeclaratorIdentifierNode(declaratorNode *sitter.Node) *sitter.Node {
+	for declaratorNode != nil && declaratorNode.Type() != "identifier" {
+		if identifierNode := declaratorNode.ChildByFieldName("identifier"); identifierNode != nil {
+			declaratorNode = identifierNode
+			continue
+		}
+		declaratorNode = declaratorNode.ChildByFieldName("declarator")
+	}
+	return declaratorNode
+}
+
+// Returns true if the expression node is a variable or an element of an array
+// which can be passed by reference. Identifiers which are not defined are
+// assumed to be variables, they are reported as undefined.
+func (c typeChecker) isLvalue(node *sitter.Node) bool {
+	switch node.Type() {
+	case "identifier":
+		def, err := findDefinition(node, node.Content(c.sourceCode), c.uri, c.documents, c.includesDir)
+		if err != nil {
+			return true
+		}
+		return !isPreprocDefName(def.Node) && (def.Node.Parent() == nil || def.Node.Parent().Type() != "function_declarator")
+
+	case "subscript_expression":
+		return true
+
+	case "parenthesized_expression":
+		return node.NamedChildCount() == 1 && c.isLvalue(node.NamedChild(0))
+	}
+	return false
+}
@@ -0,0 +1,198 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kelly-lin/12d-lang-server/lang"
+	"github.com/kelly-lin/12d-lang-server/protocol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// The maximum number of overloads listed in the diagnostic of a call which
+// matches no overload.
+const maxClosestOverloads = 3
+
+// How the arguments of a call match the parameters of a library function
+// overload.
+type overloadMatch struct {
+	signature lang.Signature
+	argCount  int
+	// Index of the first argument whose type is not assignable to its
+	// parameter, -1 if all of the arguments are assignable.
+	mismatchIdx int
+	// Number of arguments which are assignable to their parameters.
+	matchCount int
+}
+
+// Returns true if the call can call the overload.
+func (m overloadMatch) isMatch() bool {
+	return m.argCount == len(m.signature.Params) && m.mismatchIdx == -1
+}
+
+// Match the argument types of a call with the parameters of the overload.
+// Arguments of an unknown type match any parameter.
+func matchOverload(signature lang.Signature, argTypes []exprType) overloadMatch {
+	result := overloadMatch{signature: signature, argCount: len(argTypes), mismatchIdx: -1}
+	for idx, argType := range argTypes {
+		if idx >= len(signature.Params) {
+			break
+		}
+		if isAssignable(argType, getParamType(signature.Params[idx])) {
+			result.matchCount++
+			continue
+		}
+		if result.mismatchIdx == -1 {
+			result.mismatchIdx = idx
+		}
+	}
+	return result
+}
+
+// Get the type of the library function parameter. Unlike the declared types
+// of variables the parameter types are always known, a few of the types used
+// by the library are missing from the built in types.
+func getParamType(param lang.Param) exprType {
+	return exprType{name: param.Type, isArray: param.IsArray}
+}
+
+// Get the argument nodes of the argument list node.
+func getArgNodes(argsNode *sitter.Node) []*sitter.Node {
+	result := []*sitter.Node{}
+	for i := 0; i < int(argsNode.NamedChildCount()); i++ {
+		if argNode := argsNode.NamedChild(i); argNode.Type() != "comment" {
+			result = append(result, argNode)
+		}
+	}
+	return result
+}
+
+// Get the types of the arguments of the argument list node.
+func (c typeChecker) getArgTypes(argsNode *sitter.Node) []exprType {
+	result := []exprType{}
+	for _, argNode := range getArgNodes(argsNode) {
+		result = append(result, c.getType(argNode))
+	}
+	return result
+}
+
+// Check that the call of a library function matches one of its overloads,
+// returns false if the call is not a call to a library function or it matches
+// an overload.
+func (c typeChecker) checkCall(callNode *sitter.Node) (protocol.Diagnostic, bool) {
+	funcNode := callNode.ChildByFieldName("function")
+	argsNode := callNode.ChildByFieldName("arguments")
+	if funcNode == nil || argsNode == nil || funcNode.Type() != "identifier" {
+		return protocol.Diagnostic{}, false
+	}
+	identifier := funcNode.Content(c.sourceCode)
+	// User defined functions shadow the library functions.
+	if _, err := findDefinition(funcNode, identifier, c.uri, c.documents, c.includesDir); err == nil {
+		return protocol.Diagnostic{}, false
+	}
+	signatures, err := lang.GetLibSignatures(identifier)
+	if err != nil {
+		return protocol.Diagnostic{}, false
+	}
+	argTypes := c.getArgTypes(argsNode)
+	matches := []overloadMatch{}
+	for _, signature := range signatures {
+		match := matchOverload(signature, argTypes)
+		if match.isMatch() {
+			return protocol.Diagnostic{}, false
+		}
+		matches = append(matches, match)
+	}
+	sortClosestOverloads(matches)
+
+	var lines []string
+	if len(signatures) == 1 {
+		lines = append(lines, fmt.Sprintf(`Arguments do not match "%s".`, identifier))
+	} else {
+		lines = append(lines, fmt.Sprintf(`No overload of "%s" matches the arguments, closest overloads:`, identifier))
+	}
+	for idx, match := range matches {
+		if idx == maxClosestOverloads {
+			break
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", match.signature, getOverloadMismatchDesc(match, argTypes)))
+	}
+
+	// Point at the argument which failed to match the closest overload, or the
+	// whole argument list when the number of arguments is wrong.
+	rangeNode := argsNode
+	if closest := matches[0]; closest.argCount == len(closest.signature.Params) {
+		rangeNode = getArgNodes(argsNode)[closest.mismatchIdx]
+	}
+	return protocol.Diagnostic{
+		Range:    getNodeRange(rangeNode),
+		Severity: protocol.DiagnosticSeverityError,
+		Source:   SourceName,
+		Message:  strings.Join(lines, "\n"),
+	}, true
+}
+
+// Sort the overloads which do not match a call from the closest to the
+// furthest. Overloads with the same number of parameters as there are
+// arguments are the closest, followed by the overloads which the most
+// arguments match and the overloads with the nearest number of parameters.
+func sortClosestOverloads(matches []overloadMatch) {
+	getCountDiff := func(match overloadMatch) int {
+		diff := match.argCount - len(match.signature.Params)
+		if diff < 0 {
+			return -diff
+		}
+		return diff
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		iDiff := getCountDiff(matches[i])
+		jDiff := getCountDiff(matches[j])
+		if (iDiff == 0) != (jDiff == 0) {
+			return iDiff == 0
+		}
+		if matches[i].matchCount != matches[j].matchCount {
+			return matches[i].matchCount > matches[j].matchCount
+		}
+		return iDiff < jDiff
+	})
+}
+
+// Describe why the arguments do not match the overload.
+func getOverloadMismatchDesc(match overloadMatch, argTypes []exprType) string {
+	if match.argCount != len(match.signature.Params) {
+		return fmt.Sprintf("expected %d arguments but got %d.", len(match.signature.Params), match.argCount)
+	}
+	return fmt.Sprintf(
+		`argument %d of type "%s" is not assignable to parameter "%s".`,
+		match.mismatchIdx+1,
+		argTypes[match.mismatchIdx],
+		match.signature.Params[match.mismatchIdx],
+	)
+}
+
+// Filters the library items so that it matches argument list described by the
+// function that the identifier node is referring to.
+func filterLibItems(identifierNode *sitter.Node, libItems []string, uri string, documents map[string]Document, includesDir string) []string {
+	result := []string{}
+	doc, ok := documents[uri]
+	if !ok {
+		return result
+	}
+	argsNode := identifierNode.Parent().ChildByFieldName("arguments")
+	if argsNode == nil {
+		return result
+	}
+	checker := typeChecker{uri: uri, sourceCode: doc.SourceCode, documents: documents, includesDir: includesDir}
+	argTypes := checker.getArgTypes(argsNode)
+	for _, item := range libItems {
+		signature, err := lang.GetLibSignature(item)
+		if err != nil {
+			continue
+		}
+		if matchOverload(signature, argTypes).isMatch() {
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -61,6 +62,7 @@ func NewServer(
 		includesDir:                includesDir,
 		includesResolver:           includesResolver,
 		enableExperimentalFeatures: enableExperimentalFeatures,
+		typeDocsDir:                filepath.Join(os.TempDir(), "12dls", "types"),
 	}
 	if builtInCompletions != nil {
 		s.builtInCompletions = *builtInCompletions
@@ -108,6 +110,16 @@ type Server struct {
 	logger                     func(msg string)
 	includesResolver           IncludesResolver
 	enableExperimentalFeatures bool
+	// Directory where the read only documents for built in types are
+	// generated.
+	typeDocsDir string
+	settings    Settings
+}
+
+// Sets the directory where the read only documents for built in types are
+// generated, defaults to a directory in the temporary directory of the system.
+func (s *Server) SetTypeDocsDir(dir string) {
+	s.typeDocsDir = dir
 }
 
 // Serve reads JSONRPC from the reader, processes the message and responds by
@@ -199,6 +211,13 @@ func (s *Server) handleMessage(msg protocol.RequestMessage) (protocol.ResponseMe
 	}
 	switch msg.Method {
 	case "initialize":
+		var params protocol.InitializeParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return protocol.ResponseMessage{}, 0, err
+		}
+		if err := s.setSettings(params.InitializationOptions); err != nil {
+			return protocol.ResponseMessage{}, 0, err
+		}
 		result := protocol.InitializeResult{
 			Capabilities: newServerCapabilities(s.enableExperimentalFeatures),
 		}
@@ -247,18 +266,19 @@ func (s *Server) handleMessage(msg protocol.RequestMessage) (protocol.ResponseMe
 		}
 		rootNode := doc.RootNode
 		sourceCode := doc.SourceCode
+		var contents []string
 		identifierNode, err := pl12d.FindIdentifierNode(rootNode, params.Position.Line, params.Position.Character)
-		if err != nil {
-			return newNullResponseMessage(msg.ID), len(protocol.NullResult), err
-		}
-		identifier := identifierNode.Content(sourceCode)
 		if errors.Is(err, pl12d.ErrNoDefinition) {
-			return newNullResponseMessage(msg.ID), len(protocol.NullResult), nil
-		}
-		if err != nil {
+			contents = getConstExprHoverContents(rootNode, params.Position, params.TextDocument.URI, s.documents, s.includesDir)
+			if len(contents) == 0 {
+				return newNullResponseMessage(msg.ID), len(protocol.NullResult), err
+			}
+		} else if err != nil {
 			return newNullResponseMessage(msg.ID), len(protocol.NullResult), err
+		} else {
+			identifier := identifierNode.Content(sourceCode)
+			contents = getHoverContents(identifierNode, identifier, params.TextDocument.URI, s.documents, s.includesDir)
 		}
-		contents := getHoverContents(identifierNode, identifier, params.TextDocument.URI, s.documents, s.includesDir)
 		if len(contents) == 0 {
 			return newNullResponseMessage(msg.ID), len(protocol.NullResult), nil
 		}
@@ -361,8 +381,15 @@ func (s *Server) handleMessage(msg protocol.RequestMessage) (protocol.ResponseMe
 			}
 		}
 
+		items = append(items, s.getIncludeDiagnostics(params.TextDocument.URI)...)
+
 		identifierNodes := getIdentifierNodes(doc.RootNode)
 		for _, identifierNode := range identifierNodes {
+			// Calls of library functions are checked against their overloads
+			// by the type checker.
+			if isLibFuncCall(identifierNode, doc.SourceCode) {
+				continue
+			}
 			if _, err := findDefinition(
 				identifierNode,
 				identifierNode.Content(doc.SourceCode),
@@ -390,6 +417,18 @@ func (s *Server) handleMessage(msg protocol.RequestMessage) (protocol.ResponseMe
 			}
 		}
 
+		items = append(items, s.getTypeDiagnostics(params.TextDocument.URI)...)
+		items = append(items, s.getReturnDiagnostics(params.TextDocument.URI)...)
+		items = append(items, s.getDuplicateDiagnostics(params.TextDocument.URI)...)
+		items = append(items, s.getUnusedDiagnostics(params.TextDocument.URI)...)
+		items = append(items, s.getLabelDiagnostics(params.TextDocument.URI)...)
+		items = append(items, s.getSwitchDiagnostics(params.TextDocument.URI)...)
+		items = append(items, s.getConstantDiagnostics(params.TextDocument.URI)...)
+		items = append(items, s.getArrayDiagnostics(params.TextDocument.URI)...)
+		items = append(items, s.getWidgetDiagnostics(params.TextDocument.URI)...)
+		items = append(items, s.getEntryPointDiagnostics(params.TextDocument.URI)...)
+		items = append(items, s.getStatusCodeDiagnostics(params.TextDocument.URI)...)
+
 		report := protocol.DocumentDiagnosticReport{
 			FullDocumentDiagnosticReport: protocol.FullDocumentDiagnosticReport{
 				Kind:  protocol.DocumentDiagnosticReportKindFull,
@@ -416,11 +455,49 @@ func (s *Server) handleMessage(msg protocol.RequestMessage) (protocol.ResponseMe
 		if !ok {
 			return newNullResponseMessage(msg.ID), len(protocol.NullResult), errors.New("source node not found")
 		}
-		edits := []protocol.TextEdit{}
-		edits = append(edits, format.GetIndentationEdits(doc.RootNode)...)
-		edits = append(edits, format.GetTrailingWhitespaceEdits(doc.SourceCode)...)
-		edits = append(edits, format.GetFuncDefEdits(doc.RootNode)...)
-		edits = append(edits, format.GetCallExpressionEdits(doc.RootNode, doc.SourceCode)...)
+		edits := format.GetEdits(doc.RootNode, doc.SourceCode, s.getFormattingOptions(params.TextDocument.URI, params.Options))
+		editsBytes, err := json.Marshal(edits)
+		if err != nil {
+			return protocol.ResponseMessage{}, 0, err
+		}
+		return protocol.ResponseMessage{
+				ID:     msg.ID,
+				Result: json.RawMessage(editsBytes),
+			},
+			len(editsBytes),
+			nil
+
+	case "textDocument/rangeFormatting":
+		var params protocol.DocumentRangeFormattingParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return protocol.ResponseMessage{}, 0, err
+		}
+		doc, ok := s.documents[params.TextDocument.URI]
+		if !ok {
+			return newNullResponseMessage(msg.ID), len(protocol.NullResult), errors.New("source node not found")
+		}
+		edits := format.GetRangeEdits(doc.RootNode, doc.SourceCode, params.Range, s.getFormattingOptions(params.TextDocument.URI, params.Options))
+		editsBytes, err := json.Marshal(edits)
+		if err != nil {
+			return protocol.ResponseMessage{}, 0, err
+		}
+		return protocol.ResponseMessage{
+				ID:     msg.ID,
+				Result: json.RawMessage(editsBytes),
+			},
+			len(editsBytes),
+			nil
+
+	case "textDocument/onTypeFormatting":
+		var params protocol.DocumentOnTypeFormattingParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return protocol.ResponseMessage{}, 0, err
+		}
+		doc, ok := s.documents[params.TextDocument.URI]
+		if !ok {
+			return newNullResponseMessage(msg.ID), len(protocol.NullResult), errors.New("source node not found")
+		}
+		edits := format.GetOnTypeEdits(doc.RootNode, doc.SourceCode, params.Position, params.Ch, s.getFormattingOptions(params.TextDocument.URI, params.Options))
 		editsBytes, err := json.Marshal(edits)
 		if err != nil {
 			return protocol.ResponseMessage{}, 0, err
@@ -432,6 +509,26 @@ func (s *Server) handleMessage(msg protocol.RequestMessage) (protocol.ResponseMe
 			len(editsBytes),
 			nil
 
+	case "textDocument/documentLink":
+		var params protocol.DocumentLinkParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return protocol.ResponseMessage{}, 0, err
+		}
+		if _, ok := s.documents[params.TextDocument.URI]; !ok {
+			return newNullResponseMessage(msg.ID), len(protocol.NullResult), errors.New("source node not found")
+		}
+		links := s.getDocumentLinks(params.TextDocument.URI)
+		linksBytes, err := json.Marshal(links)
+		if err != nil {
+			return protocol.ResponseMessage{}, 0, err
+		}
+		return protocol.ResponseMessage{
+				ID:     msg.ID,
+				Result: json.RawMessage(linksBytes),
+			},
+			len(linksBytes),
+			nil
+
 	case "textDocument/definition":
 		var params protocol.DefinitionParams
 		if err := json.Unmarshal(msg.Params, &params); err != nil {
@@ -444,21 +541,67 @@ func (s *Server) handleMessage(msg protocol.RequestMessage) (protocol.ResponseMe
 		rootNode := doc.RootNode
 		sourceCode := doc.SourceCode
 		identifierNode, err := pl12d.FindIdentifierNode(rootNode, params.Position.Line, params.Position.Character)
+		if errors.Is(err, pl12d.ErrNoDefinition) {
+			// Labels are statement identifiers, not identifiers.
+			statementIdentifierNode, err := pl12d.FindStatementIdentifierNode(rootNode, params.Position.Line, params.Position.Character)
+			if err != nil {
+				return newNullResponseMessage(msg.ID), len(protocol.NullResult), nil
+			}
+			labelNode, ok := findLabelDefinition(statementIdentifierNode, sourceCode)
+			if !ok {
+				return newNullResponseMessage(msg.ID), len(protocol.NullResult), nil
+			}
+			identifierNode = labelNode
+		} else if err != nil {
+			return newNullResponseMessage(msg.ID), len(protocol.NullResult), err
+		}
+		location := protocol.Location{
+			URI:   params.TextDocument.URI,
+			Range: getNodeRange(identifierNode),
+		}
+		if identifierNode.Type() == "identifier" {
+			identifier := identifierNode.Content(sourceCode)
+			def, err := findDefinition(identifierNode, identifier, params.TextDocument.URI, s.documents, s.includesDir)
+			if err != nil {
+				return newNullResponseMessage(msg.ID), len(protocol.NullResult), nil
+			}
+			location = protocol.Location{
+				URI:   def.URI,
+				Range: ToProtocolRange(def.Range),
+			}
+		}
+		locationBytes, err := json.Marshal(location)
+		if err != nil {
+			return protocol.ResponseMessage{}, 0, err
+		}
+		return protocol.ResponseMessage{
+				ID:     msg.ID,
+				Result: json.RawMessage(locationBytes),
+			},
+			len(locationBytes),
+			nil
+
+	case "textDocument/typeDefinition":
+		var params protocol.TypeDefinitionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return protocol.ResponseMessage{}, 0, err
+		}
+		doc, ok := s.documents[params.TextDocument.URI]
+		if !ok {
+			return newNullResponseMessage(msg.ID), len(protocol.NullResult), errors.New("source node not found")
+		}
+		identifierNode, err := pl12d.FindIdentifierNode(doc.RootNode, params.Position.Line, params.Position.Character)
 		if errors.Is(err, pl12d.ErrNoDefinition) {
 			return newNullResponseMessage(msg.ID), len(protocol.NullResult), nil
 		}
 		if err != nil {
 			return newNullResponseMessage(msg.ID), len(protocol.NullResult), err
 		}
-		identifier := identifierNode.Content(sourceCode)
-		def, err := findDefinition(identifierNode, identifier, params.TextDocument.URI, s.documents, s.includesDir)
+		identifier := identifierNode.Content(doc.SourceCode)
+		location, err := s.findTypeDefinition(identifierNode, identifier, params.TextDocument.URI)
 		if err != nil {
 			return newNullResponseMessage(msg.ID), len(protocol.NullResult), nil
 		}
-		location := protocol.Location{
-			URI:   def.URI,
-			Range: ToProtocolRange(def.Range),
-		}
 		locationBytes, err := json.Marshal(location)
 		if err != nil {
 			return protocol.ResponseMessage{}, 0, err
@@ -482,6 +625,20 @@ func (s *Server) handleMessage(msg protocol.RequestMessage) (protocol.ResponseMe
 		rootNode := doc.RootNode
 		sourceCode := doc.SourceCode
 		identifierNode, err := pl12d.FindIdentifierNode(rootNode, params.Position.Line, params.Position.Character)
+		if errors.Is(err, pl12d.ErrNoDefinition) {
+			if locations, ok := getLabelLocations(rootNode, sourceCode, params); ok {
+				locationsBytes, err := json.Marshal(locations)
+				if err != nil {
+					return protocol.ResponseMessage{}, 0, err
+				}
+				return protocol.ResponseMessage{
+						ID:     msg.ID,
+						Result: json.RawMessage(locationsBytes),
+					},
+					len(locationsBytes),
+					nil
+			}
+		}
 		if err != nil {
 			return newNullResponseMessage(msg.ID), len(protocol.NullResult), err
 		}
@@ -569,14 +726,75 @@ func (s *Server) handleMessage(msg protocol.RequestMessage) (protocol.ResponseMe
 			len(editsBytes),
 			nil
 
+	case "textDocument/codeAction":
+		var params protocol.CodeActionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return protocol.ResponseMessage{}, 0, err
+		}
+		doc, ok := s.documents[params.TextDocument.URI]
+		if !ok {
+			return newNullResponseMessage(msg.ID), len(protocol.NullResult), errors.New("source node not found")
+		}
+		codeActions := []protocol.CodeAction{}
+		if isCodeActionKindRequested(params.Context.Only, protocol.CodeActionKindSourceOrganizeImports) {
+			opts := s.getFormattingOptions(params.TextDocument.URI, protocol.FormattingOptions{})
+			if !opts.OrganizeIncludes {
+				opts.IncludeInfo = s.getIncludeInfo(params.TextDocument.URI)
+			}
+			if edits := format.GetOrganizeEdits(doc.RootNode, doc.SourceCode, opts); len(edits) > 0 {
+				codeActions = append(codeActions, protocol.CodeAction{
+					Title: "Organize includes",
+					Kind:  protocol.CodeActionKindSourceOrganizeImports,
+					Edit: &protocol.WorkspaceEdit{
+						Changes: map[string][]protocol.TextEdit{params.TextDocument.URI: edits},
+					},
+				})
+			}
+		}
+		codeActionsBytes, err := json.Marshal(codeActions)
+		if err != nil {
+			return protocol.ResponseMessage{}, 0, err
+		}
+		return protocol.ResponseMessage{
+				ID:     msg.ID,
+				Result: json.RawMessage(codeActionsBytes),
+			},
+			len(codeActionsBytes),
+			nil
+
 	case "initialized":
 		return protocol.ResponseMessage{}, 0, nil
 
+	case "workspace/didChangeConfiguration":
+		var params protocol.DidChangeConfigurationParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return protocol.ResponseMessage{}, 0, err
+		}
+		if err := s.setSettings(params.Settings); err != nil {
+			return protocol.ResponseMessage{}, 0, err
+		}
+		return protocol.ResponseMessage{}, 0, nil
+
 	default:
 		return protocol.ResponseMessage{}, 0, ErrUnhandledMethod
 	}
 }
 
+// Returns true if code actions of the kind are requested by the only filter of
+// a code action request. All kinds are requested when the filter is empty, a
+// kind is requested when it is or is a sub kind of a requested kind.
+func isCodeActionKindRequested(only []string, kind string) bool {
+	if len(only) == 0 {
+		return true
+	}
+	for _, requestedKind := range only {
+		if kind == requestedKind || strings.HasPrefix(kind, requestedKind+".") {
+			return true
+		}
+	}
+	return false
+}
+
 // Traverse up the tree and find the node which represents the scope of the
 // provided identifier node.
 func getScopeNode(identifierNode *sitter.Node) *sitter.Node {
@@ -678,6 +896,17 @@ func getSyntaxErrorNodes(node *sitter.Node) []*sitter.Node {
 	return result
 }
 
+// Returns true if the identifier node is the function of a call to a library
+// function.
+func isLibFuncCall(identifierNode *sitter.Node, sourceCode []byte) bool {
+	callNode := identifierNode.Parent()
+	if callNode == nil || callNode.Type() != "call_expression" {
+		return false
+	}
+	_, ok := lang.Lib[identifierNode.Content(sourceCode)]
+	return ok
+}
+
 // Search get all child identifier nodes of node.
 func getIdentifierNodes(node *sitter.Node) []*sitter.Node {
 	var result []*sitter.Node
@@ -706,36 +935,49 @@ func (s *Server) setDocument(uri string, content string) error {
 	s.documents[uri] = Document{RootNode: rootNode, SourceCode: sourceCode}
 	ext := filepath.Ext(uri)
 	if ext == ".4dm" {
-		includesDir := s.includesDir
-		if includesDir == SourceFileDirToken {
-			includesDir = filepath.Dir(protocol.Filepath(uri))
-		}
-		if err := s.parseIncludes(rootNode, sourceCode, includesDir); err != nil {
-			return err
-		}
+		s.parseIncludes(rootNode, sourceCode, uri)
 	}
 	return nil
 }
 
-func (s *Server) parseIncludes(rootNode *sitter.Node, sourceCode []byte, includesDir string) error {
+// Parse and store the documents of the includes in the document described by
+// uri. Includes which cannot be resolved are skipped, they are reported as
+// diagnostics instead.
+func (s *Server) parseIncludes(rootNode *sitter.Node, sourceCode []byte, uri string) {
 	includeNodes, err := parser.FindChildren(rootNode, "preproc_include")
 	if err != nil {
-		return err
+		return
 	}
 	for _, includeNode := range includeNodes {
-		includePath := includeNode.ChildByFieldName("path").Child(1).Content(sourceCode)
-		fullIncludePath := filepath.Join(includesDir, includePath)
-		if !s.includesResolver.Exists(fullIncludePath) {
-			return fmt.Errorf("file %s does not exist", fullIncludePath)
+		fullIncludePath, ok := s.resolveInclude(includeNode, sourceCode, uri)
+		if !ok {
+			continue
 		}
-		// TODO: how to handle this error?
-		contents, _ := s.includesResolver.Read(fullIncludePath)
 		resolvedURI := protocol.URI(fullIncludePath)
-		if _, ok := s.documents[resolvedURI]; !ok {
-			_ = s.setDocument(resolvedURI, string(contents))
+		if _, ok := s.documents[resolvedURI]; ok {
+			continue
 		}
+		contents, err := s.includesResolver.Read(fullIncludePath)
+		if err != nil {
+			s.logger(fmt.Sprintf("could not read include file %s: %s\n", fullIncludePath, err))
+			continue
+		}
+		_ = s.setDocument(resolvedURI, string(contents))
 	}
-	return nil
+}
+
+// Resolves the filepath of the include node in the document described by uri.
+// Returns the filepath and true if the include file exists, false otherwise.
+func (s *Server) resolveInclude(includeNode *sitter.Node, sourceCode []byte, uri string) (string, bool) {
+	pathNode := includeNode.ChildByFieldName("path")
+	if pathNode == nil {
+		return "", false
+	}
+	includeFilepath := getIncludeFilepath(pathNode, sourceCode, uri, s.includesDir)
+	if s.includesResolver == nil || !s.includesResolver.Exists(includeFilepath) {
+		return includeFilepath, false
+	}
+	return includeFilepath, true
 }
 
 // Gets the completion items for the node given by position.
@@ -971,6 +1213,19 @@ func getHoverContents(identifierNode *sitter.Node, identifier string, uri string
 		}
 	}
 
+	if isPreprocDefName(node) {
+		lineStart := bytes.LastIndexByte(sourceCode[:node.StartByte()], '\n') + 1
+		signature := strings.TrimSpace(string(sourceCode[lineStart:node.EndByte()]) + getPreprocDefValue(node, sourceCode))
+		desc := ""
+		// Show the value of definitions whose value is not a literal.
+		evaluator := newConstEvaluator(def.URI, documents, includesDir)
+		if value, err := evaluator.evalPreprocDef(node); err == nil && !isPreprocDefLiteral(node) {
+			desc = fmt.Sprintf("Value: `%s`", value)
+		}
+		result = append(result, protocol.CreateDocMarkdownString(signature, desc))
+		return result
+	}
+
 	nodeType, err := getDefinitionType(node, sourceCode)
 	if err != nil {
 		return []string{}
@@ -986,10 +1241,6 @@ func getHoverContents(identifierNode *sitter.Node, identifier string, uri string
 		}
 		result = append(result, createHoverDeclarationDocString(nodeType, identifier, "", prefix))
 
-	case "preproc_def":
-		signature := strings.TrimSpace(node.Parent().Content(sourceCode))
-		result = append(result, protocol.CreateDocMarkdownString(signature, ""))
-
 	default:
 		result = append(result, createHoverDeclarationDocString(nodeType, hoverIdentifier, "", prefix))
 	}
@@ -1304,176 +1555,6 @@ func createHoverDeclarationDocString(varType, identifier, desc, prefix string) s
 	return protocol.CreateDocMarkdownString(fmt.Sprintf("%s %s", varType, identifier), desc)
 }
 
-// Filters the library items so that it matches argument list described by the
-// function that the identifier node is referring to.
-func filterLibItems(identifierNode *sitter.Node, libItems []string, uri string, documents map[string]Document, includesDir string) []string {
-	doc, ok := documents[uri]
-	if !ok {
-		return []string{}
-	}
-	sourceCode := doc.SourceCode
-
-	getArgumentTypes := func(argsNode *sitter.Node) []string {
-		var types []string
-		for i := 0; i < int(argsNode.ChildCount()); i++ {
-			argIdentifierNode := argsNode.Child(i)
-			if argIdentifierNode == nil {
-				continue
-			}
-			switch argIdentifierNode.Type() {
-			case "identifier":
-				def, err := findDefinition(argIdentifierNode, argIdentifierNode.Content(sourceCode), uri, documents, includesDir)
-				if err != nil {
-					continue
-				}
-				nodeType, err := getDefinitionType(def.Node, sourceCode)
-				if err != nil {
-					continue
-				}
-				types = append(types, nodeType)
-
-			case "string_literal":
-				types = append(types, "Text")
-
-			case "number_literal":
-				types = append(types, "Integer")
-
-			case "subscript_expression":
-				subscriptArgumentIdentifierNode := argIdentifierNode.ChildByFieldName("argument")
-				if subscriptArgumentIdentifierNode == nil {
-					break
-				}
-				def, err := findDefinition(subscriptArgumentIdentifierNode, subscriptArgumentIdentifierNode.Content(sourceCode), uri, documents, includesDir)
-				if err != nil {
-					break
-				}
-				varType, err := getDefinitionType(def.Node, sourceCode)
-				if err != nil {
-					break
-				}
-				// The argument identifier node is a subscript expression node,
-				// which means we want the type base type and not the array
-				// type.
-				varType = strings.TrimSuffix(varType, "[]")
-				types = append(types, varType)
-
-			case "binary_expression":
-				expressionNode := argIdentifierNode
-				// Binary expressions are recursive, we need to traverse down
-				// to the leaf of the binary expression tree.
-				for expressionNode.ChildByFieldName("left") != nil {
-					expressionNode = expressionNode.ChildByFieldName("left")
-				}
-				switch expressionNode.Type() {
-				case "identifier":
-					def, err := findDefinition(expressionNode, expressionNode.Content(sourceCode), uri, documents, includesDir)
-					if err != nil {
-						break
-					}
-					varType, err := getDefinitionType(def.Node, sourceCode)
-					if err != nil {
-						break
-					}
-					types = append(types, varType)
-
-				case "number_literal":
-					types = append(types, "Integer")
-
-				case "string_literal":
-					types = append(types, "Text")
-				}
-
-			case "call_expression":
-				funcIdentifierNode := argIdentifierNode.ChildByFieldName("function")
-				if funcIdentifierNode == nil {
-					break
-				}
-				if def, err := findDefinition(funcIdentifierNode, funcIdentifierNode.Content(sourceCode), uri, documents, includesDir); err == nil {
-					varType, err := getDefinitionType(def.Node, sourceCode)
-					if err != nil {
-						break
-					}
-					types = append(types, varType)
-					break
-				}
-				libItems, ok := lang.Lib[funcIdentifierNode.Content(sourceCode)]
-				if !ok || len(libItems) == 0 {
-					break
-				}
-				returnType, err := lang.GetReturnType(libItems[0])
-				if err != nil {
-					break
-				}
-				types = append(types, returnType)
-			}
-		}
-		return types
-	}
-
-	var result []string
-	callExpressionNode := identifierNode.Parent()
-	argsNode := callExpressionNode.ChildByFieldName("arguments")
-	if argsNode == nil {
-		return []string{}
-	}
-	funcIdentifier := identifierNode.Content(sourceCode)
-	types := getArgumentTypes(argsNode)
-	signaturePattern := ""
-	// This matches "Type (&?)Identifier".
-	// basePattern := `%s\s*&?\w+`
-	isArrayType := func(t string) bool {
-		return strings.HasSuffix(t, "[]")
-	}
-	for idx, t := range types {
-		if alias, ok := lang.TypeAliases[t]; ok {
-			for _, a := range alias {
-				t = fmt.Sprintf("%s|%s", t, a)
-			}
-			t = fmt.Sprintf("(?:%s)", t)
-		}
-		if idx == 0 {
-			if isArrayType(t) {
-				signaturePattern = fmt.Sprintf(`%s\s*&?\w+\[\]`, strings.TrimSuffix(t, "[]"))
-				continue
-			}
-			signaturePattern = fmt.Sprintf(`%s\s*&?\w+`, t)
-			continue
-		}
-
-		if isArrayType(t) {
-			signaturePattern = fmt.Sprintf(`%s,\s*%s\s*&?\w+\[\]`, signaturePattern, strings.TrimSuffix(t, "[]"))
-			continue
-		} else {
-			signaturePattern = fmt.Sprintf(`%s,\s*%s\s*&?\w+`, signaturePattern, t)
-		}
-	}
-	codeBlockStartPattern := `12dpl\n\w+\s*`
-	codeBlockEndPattern := `\n` + "\\`\\`\\`"
-	// This creates the pattern:
-	// ```12dpl\\n\w+\s*
-	// |             |identifier
-	// |             |      |
-	// |             |      |signature pattern
-	// |             |      |       |
-	// |             |      |       | \\n```
-	// |             |      |       ||     |
-	// ```12dpl\nvoid Print(Text msg)\n```\n---\nPrint the Text msg to the Output Window.
-	//
-	// This is a bit of a hack to make sure we are matching the signature and
-	// not anything in the description, as there are some lib items which
-	// reference other library items by function signature. We should really
-	// restructure the library item struct so that it has a description,
-	// signature and doc field.
-	signaturePattern = fmt.Sprintf(`%s%s\(%s\)%s`, codeBlockStartPattern, funcIdentifier, signaturePattern, codeBlockEndPattern)
-	for _, item := range libItems {
-		if matched, _ := regexp.MatchString(signaturePattern, item); matched {
-			result = append(result, item)
-		}
-		continue
-	}
-	return result
-}
-
 type Document struct {
 	// Root of the parsed nodes for the document.
 	RootNode *sitter.Node
@@ -1520,10 +1601,11 @@ func findDefinition(startNode *sitter.Node, identifier string, uri string, docum
 
 		for i := 0; i < int(currentNode.ChildCount()); i++ {
 			currentChildNode := currentNode.Child(i)
-			if currentChildNode.Type() == "preproc_def" {
-				identifierDeclarationNode := currentChildNode.ChildByFieldName("name")
-				if identifierDeclarationNode != nil && identifierDeclarationNode.Content(sourceCode) == identifier {
-					return Definition{Range: pl12d.NewParserRange(identifierDeclarationNode), Node: identifierDeclarationNode, URI: uri}, nil
+			if currentChildNode.Type() == "preproc_def" || currentChildNode.IsError() {
+				for _, identifierDeclarationNode := range getPreprocDefNameNodes(currentChildNode) {
+					if identifierDeclarationNode.Content(sourceCode) == identifier {
+						return Definition{Range: pl12d.NewParserRange(identifierDeclarationNode), Node: identifierDeclarationNode, URI: uri}, nil
+					}
 				}
 			}
 			if currentChildNode.Type() == "preproc_include" {
@@ -1569,6 +1651,49 @@ func findDefinition(startNode *sitter.Node, identifier string, uri string, docum
 	return Definition{}, errors.New("parent function definition not found")
 }
 
+// Returns true if the node is the name identifier node of a preprocessor
+// definition, the identifier after "#define". The parser does not support
+// definitions with an identifier as the value, for example "#define Handle
+// Integer" is parsed as:
+//
+//	(preproc_def (ERROR (identifier)) name: (identifier))
+//
+// where the name field is "Integer", so the name is the identifier starting
+// the error node after "#define".
+func isPreprocDefName(node *sitter.Node) bool {
+	if node.Type() != "identifier" {
+		return false
+	}
+	prevNode := node.PrevSibling()
+	if prevNode == nil && node.Parent() != nil && node.Parent().IsError() {
+		prevNode = node.Parent().PrevSibling()
+	}
+	return prevNode != nil && prevNode.Type() == "#define"
+}
+
+// Get the name identifier nodes of the definitions in the preprocessor
+// definition or error node. The parser does not support parenthesized sub
+// expressions in the value of a definition, such a definition can take the
+// definitions on the lines after it into an error node, for example
+// "#define A (1)" followed by "#define B 2" is parsed as:
+//
+//	(preproc_def name: (identifier) (ERROR (preproc_arg) (identifier)) value: (preproc_arg))
+//
+// where "B" is the identifier in the error node.
+func getPreprocDefNameNodes(node *sitter.Node) []*sitter.Node {
+	result := []*sitter.Node{}
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		childNode := node.NamedChild(i)
+		if isPreprocDefName(childNode) {
+			result = append(result, childNode)
+		}
+		if childNode.IsError() {
+			result = append(result, getPreprocDefNameNodes(childNode)...)
+		}
+	}
+	return result
+}
+
 // Get the full filepath of the include file described by path node.
 func getIncludeFilepath(pathNode *sitter.Node, sourceCode []byte, uri, includesDir string) string {
 	pathQuoted := pathNode.Content(sourceCode)
@@ -1720,18 +1845,28 @@ func newServerCapabilities(enableExperimentalFeatures bool) protocol.ServerCapab
 		CompletionProvider: &protocol.CompletionOptions{
 			ResolveProvider: &resolveProvider,
 		},
-		DefinitionProvider: &definitionProvider,
-		HoverProvider:      true,
-		ReferencesProvider: true,
-		RenameProvider:     true,
-		TextDocumentSync:   &textDocumentSyncKind,
+		DefinitionProvider:     &definitionProvider,
+		DocumentLinkProvider:   &protocol.DocumentLinkOptions{ResolveProvider: false},
+		HoverProvider:          true,
+		ReferencesProvider:     true,
+		RenameProvider:         true,
+		TextDocumentSync:       &textDocumentSyncKind,
+		TypeDefinitionProvider: true,
 	}
 	if enableExperimentalFeatures {
 		result.DiagnosticProvider = &protocol.DiagnosticOptions{
 			InterFileDependencies: true,
 			WorkspaceDiagnostics:  true,
 		}
+		result.CodeActionProvider = &protocol.CodeActionOptions{
+			CodeActionKinds: []string{protocol.CodeActionKindSourceOrganizeImports},
+		}
 		result.DocumentFormattingProvider = &documentFormattingProvider
+		result.DocumentRangeFormattingProvider = &documentFormattingProvider
+		result.DocumentOnTypeFormattingProvider = &protocol.DocumentOnTypeFormattingOptions{
+			FirstTriggerCharacter: "}",
+			MoreTriggerCharacter:  []string{";", "\n"},
+		}
 	}
 	return result
 }
@@ -704,26 +704,26 @@ Integer subject) {}`,
 					},
 				},
 			},
-			// {
-			// 	Desc: "func def - parameter list - param indentation - remove indent first param",
-			// 	SourceCode: `Integer Identity(
-			//      Integer subject) {}`,
-			// 	Want: []protocol.TextEdit{
-			// 		{
-			// 			Range: protocol.Range{
-			// 				Start: protocol.Position{
-			// 					Line:      1,
-			// 					Character: 0,
-			// 				},
-			// 				End: protocol.Position{
-			// 					Line:      1,
-			// 					Character: 8,
-			// 				},
-			// 			},
-			// 			NewText: "    ",
-			// 		},
-			// 	},
-			// },
+			{
+				Desc: "func def - parameter list - param indentation - remove indent first param",
+				SourceCode: `Integer Identity(
+        Integer subject) {}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{
+								Line:      1,
+								Character: 0,
+							},
+							End: protocol.Position{
+								Line:      1,
+								Character: 8,
+							},
+						},
+						NewText: "    ",
+					},
+				},
+			},
 			{
 				Desc: "func def - parameter list - param indentation - insert indent first param - multiple param",
 				SourceCode: `Integer Add(
@@ -954,30 +954,311 @@ Integer b) {}`,
 					},
 				},
 			},
-			// {
-			// 	Desc:       "func def - parameter list - empty param list on same line should join",
-			// 	SourceCode: `void Null(   ) {}`,
-			// 	Want: []protocol.TextEdit{
-			// 		{
-			// 			Range: protocol.Range{
-			// 				Start: protocol.Position{
-			// 					Line:      0,
-			// 					Character: 10,
-			// 				},
-			// 				End: protocol.Position{
-			// 					Line:      0,
-			// 					Character: 13,
-			// 				},
-			// 			},
-			// 			NewText: "",
-			// 		},
-			// 	},
-			// },
-			// TODO: Multiple var declaration spacing. "Integer a,b,c;"
-			// TODO: Assignment spacing. "Integer a=1;"
-			// TODO: Expressions spacing. "Integer a=1+1;"
-			// TODO: Statement expressions. "if(a);"
-			// TODO: else if getting removed:
+			{
+				Desc:       "func def - parameter list - empty param list on same line should join",
+				SourceCode: `void Null(   ) {}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{
+								Line:      0,
+								Character: 10,
+							},
+							End: protocol.Position{
+								Line:      0,
+								Character: 13,
+							},
+						},
+						NewText: "",
+					},
+				},
+			},
+			{
+				Desc: "declaration - multiple declarators - insert space after separator",
+				SourceCode: `void main() {
+    Integer a,b;
+}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{
+								Line:      1,
+								Character: 14,
+							},
+							End: protocol.Position{
+								Line:      1,
+								Character: 14,
+							},
+						},
+						NewText: " ",
+					},
+				},
+			},
+			{
+				Desc: "statements - split statements on the same line",
+				SourceCode: `void main() {
+    Integer a; Integer b;
+}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{
+								Line:      1,
+								Character: 14,
+							},
+							End: protocol.Position{
+								Line:      1,
+								Character: 15,
+							},
+						},
+						NewText: "\n    ",
+					},
+				},
+			},
+			{
+				Desc: "statements - body of if without block is indented",
+				SourceCode: `void main() {
+    if (1)
+    Foo();
+}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{
+								Line:      2,
+								Character: 0,
+							},
+							End: protocol.Position{
+								Line:      2,
+								Character: 4,
+							},
+						},
+						NewText: "        ",
+					},
+				},
+			},
+			{
+				Desc: "comments - trailing comment stays on line",
+				SourceCode: `void main() {
+    Integer a; // The answer.
+}`,
+				Want: []protocol.TextEdit{},
+			},
+			{
+				Desc: "blank lines - collapse multiple blank lines",
+				SourceCode: `void Foo() {}
+
+
+
+void main() {}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{
+								Line:      0,
+								Character: 13,
+							},
+							End: protocol.Position{
+								Line:      4,
+								Character: 0,
+							},
+						},
+						NewText: "\n\n",
+					},
+				},
+			},
+			{
+				Desc: "syntax errors - statement with error is left as is",
+				SourceCode: `void main() {
+    Real r  =  1.5e3;
+}`,
+				Want: []protocol.TextEdit{},
+			},
+			{
+				Desc: "expressions - spaces around binary and assignment operators",
+				SourceCode: `void main() {
+    a=b+1;
+}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 5},
+							End:   protocol.Position{Line: 1, Character: 5},
+						},
+						NewText: " ",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 6},
+							End:   protocol.Position{Line: 1, Character: 6},
+						},
+						NewText: " ",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 7},
+							End:   protocol.Position{Line: 1, Character: 7},
+						},
+						NewText: " ",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 8},
+							End:   protocol.Position{Line: 1, Character: 8},
+						},
+						NewText: " ",
+					},
+				},
+			},
+			{
+				Desc: "expressions - unary and update operators hug their operand",
+				SourceCode: `void main() {
+    i ++;
+    a = - b;
+}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 5},
+							End:   protocol.Position{Line: 1, Character: 6},
+						},
+						NewText: "",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 2, Character: 9},
+							End:   protocol.Position{Line: 2, Character: 10},
+						},
+						NewText: "",
+					},
+				},
+			},
+			{
+				Desc: "expressions - trim space inside condition parentheses",
+				SourceCode: `void main() {
+    if ( a ) {
+    }
+}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 8},
+							End:   protocol.Position{Line: 1, Character: 9},
+						},
+						NewText: "",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 10},
+							End:   protocol.Position{Line: 1, Character: 11},
+						},
+						NewText: "",
+					},
+				},
+			},
+			{
+				Desc: "switch - case labels are indented and statements are on their own lines",
+				SourceCode: `void main() {
+    switch (a) {
+    case 1: foo(); break;
+    }
+}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 2, Character: 0},
+							End:   protocol.Position{Line: 2, Character: 4},
+						},
+						NewText: "        ",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 2, Character: 11},
+							End:   protocol.Position{Line: 2, Character: 12},
+						},
+						NewText: "\n            ",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 2, Character: 18},
+							End:   protocol.Position{Line: 2, Character: 19},
+						},
+						NewText: "\n            ",
+					},
+				},
+			},
+			{
+				Desc: "switch - break after the block of a case is on the line of the closing brace",
+				SourceCode: `void main() {
+    switch (a) {
+        case 1: {
+            foo();
+        }
+        break;
+    }
+}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 4, Character: 9},
+							End:   protocol.Position{Line: 5, Character: 8},
+						},
+						NewText: " ",
+					},
+				},
+			},
+			{
+				Desc: "labels - outdented one level",
+				SourceCode: `void main() {
+    start:
+    foo();
+}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 0},
+							End:   protocol.Position{Line: 1, Character: 4},
+						},
+						NewText: "",
+					},
+				},
+			},
+			{
+				Desc: "if - else if is joined",
+				SourceCode: `void main() {
+    if (a) {
+    } else
+    if (b) {
+    }
+}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 2, Character: 10},
+							End:   protocol.Position{Line: 3, Character: 4},
+						},
+						NewText: " ",
+					},
+				},
+			},
+			{
+				Desc: "do while - while is on the line of the closing brace",
+				SourceCode: `void main() {
+    do {
+        foo();
+    }
+    while (a < 3);
+}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 3, Character: 5},
+							End:   protocol.Position{Line: 4, Character: 4},
+						},
+						NewText: " ",
+					},
+				},
+			},
 		}
 		for _, testCase := range testCases {
 			t.Run(testCase.Desc, func(t *testing.T) {
@@ -992,7 +1273,7 @@ Integer b) {}`,
 				_, err = in.Writer.Write([]byte(server.ToProtocolMessage(didOpenMsgBytes)))
 				assert.NoError(err)
 
-				msgBytes, err := newFormattingRequestMessageBytes(id, "file:///12d/proj/main.4dm")
+				msgBytes, err := newFormattingRequestMessageBytes(id, "file:///12d/proj/main.4dm", protocol.FormattingOptions{TabSize: 4, InsertSpaces: true})
 				assert.NoError(err)
 				_, err = in.Writer.Write([]byte(server.ToProtocolMessage(msgBytes)))
 				assert.NoError(err)
@@ -1297,13 +1578,965 @@ void main() {
 		}
 	})
 
-	t.Run("textDocument/definition", func(t *testing.T) {
+	t.Run("textDocument/formatting options", func(t *testing.T) {
+		enabled := true
+		disabled := false
 		type TestCase struct {
-			Desc        string
-			SourceCode  string
-			IncludesDir string
-			Pos         protocol.Position
-			Want        protocol.ResponseMessage
+			Desc       string
+			SourceCode string
+			Options    protocol.FormattingOptions
+			Want       []protocol.TextEdit
+		}
+		testCases := []TestCase{
+			{
+				Desc: "indent with tabs",
+				SourceCode: `void main() {
+Integer a = 1;
+}`,
+				Options: protocol.FormattingOptions{TabSize: 4, InsertSpaces: false},
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 0},
+							End:   protocol.Position{Line: 1, Character: 0},
+						},
+						NewText: "\t",
+					},
+				},
+			},
+			{
+				Desc: "indent with spaces replaces tabs",
+				SourceCode: `void main() {
+	Integer a = 1;
+}`,
+				Options: protocol.FormattingOptions{TabSize: 2, InsertSpaces: true},
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 0},
+							End:   protocol.Position{Line: 1, Character: 1},
+						},
+						NewText: "  ",
+					},
+				},
+			},
+			{
+				Desc: "no edit when indented with tabs",
+				SourceCode: `void main() {
+	if (1) {
+		Integer a = 1;
+	}
+}`,
+				Options: protocol.FormattingOptions{TabSize: 4, InsertSpaces: false},
+				Want:    []protocol.TextEdit{},
+			},
+			{
+				Desc:       "trim trailing tabs",
+				SourceCode: "void main() {} \t",
+				Options:    protocol.FormattingOptions{TabSize: 4, InsertSpaces: true},
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 0, Character: 14},
+							End:   protocol.Position{Line: 0, Character: 16},
+						},
+						NewText: "",
+					},
+				},
+			},
+			{
+				Desc:       "do not trim trailing whitespace",
+				SourceCode: "void main() {}  ",
+				Options:    protocol.FormattingOptions{TabSize: 4, InsertSpaces: true, TrimTrailingWhitespace: &disabled},
+				Want:       []protocol.TextEdit{},
+			},
+			{
+				Desc:       "insert final newline",
+				SourceCode: "void main() {}",
+				Options:    protocol.FormattingOptions{TabSize: 4, InsertSpaces: true, InsertFinalNewline: &enabled},
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 0, Character: 14},
+							End:   protocol.Position{Line: 0, Character: 14},
+						},
+						NewText: "\n",
+					},
+				},
+			},
+			{
+				Desc:       "no edit when final newline exists",
+				SourceCode: "void main() {}\n",
+				Options:    protocol.FormattingOptions{TabSize: 4, InsertSpaces: true, InsertFinalNewline: &enabled, TrimFinalNewlines: &enabled},
+				Want:       []protocol.TextEdit{},
+			},
+			{
+				Desc:       "trim final newlines",
+				SourceCode: "void main() {}\n  \n\n",
+				Options:    protocol.FormattingOptions{TabSize: 4, InsertSpaces: true, TrimFinalNewlines: &enabled},
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 0, Character: 14},
+							End:   protocol.Position{Line: 3, Character: 0},
+						},
+						NewText: "\n",
+					},
+				},
+			},
+			{
+				Desc:       "crlf - trim trailing whitespace before carriage return",
+				Options:    protocol.FormattingOptions{TabSize: 4, InsertSpaces: true},
+				SourceCode: "void main() {  \r\n}",
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 0, Character: 13},
+							End:   protocol.Position{Line: 0, Character: 15},
+						},
+						NewText: "",
+					},
+				},
+			},
+			{
+				Desc:       "crlf - indentation",
+				Options:    protocol.FormattingOptions{TabSize: 4, InsertSpaces: true},
+				SourceCode: "void main() {\r\nInteger a;\r\n}",
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 0},
+							End:   protocol.Position{Line: 1, Character: 0},
+						},
+						NewText: "    ",
+					},
+				},
+			},
+			{
+				Desc:       "crlf - inserted line breaks use crlf",
+				Options:    protocol.FormattingOptions{TabSize: 4, InsertSpaces: true},
+				SourceCode: "void main() {\r\n    Integer a; Integer b;\r\n}",
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 14},
+							End:   protocol.Position{Line: 1, Character: 15},
+						},
+						NewText: "\r\n    ",
+					},
+				},
+			},
+		}
+		for _, testCase := range testCases {
+			t.Run(testCase.Desc, func(t *testing.T) {
+				defer goleak.VerifyNone(t)
+				assert := assert.New(t)
+				in, out, cleanUp := startServer("", nil, nil, nil)
+				defer cleanUp()
+
+				var id int64 = 1
+				didOpenMsgBytes, err := newDidOpenRequestMessageBytes(id, "file:///12d/proj/main.4dm", testCase.SourceCode)
+				assert.NoError(err)
+				_, err = in.Writer.Write([]byte(server.ToProtocolMessage(didOpenMsgBytes)))
+				assert.NoError(err)
+
+				msgBytes, err := newFormattingRequestMessageBytes(id, "file:///12d/proj/main.4dm", testCase.Options)
+				assert.NoError(err)
+				_, err = in.Writer.Write([]byte(server.ToProtocolMessage(msgBytes)))
+				assert.NoError(err)
+
+				got, err := getReponseMessage(out.Reader)
+				assert.NoError(err)
+				assert.Equal(int64(1), got.ID)
+				var gotUnmarshalled []protocol.TextEdit
+				err = json.Unmarshal(got.Result, &gotUnmarshalled)
+				assert.NoError(err)
+				assert.Equal(testCase.Want, gotUnmarshalled)
+			})
+		}
+	})
+
+	t.Run("textDocument/formatting style", func(t *testing.T) {
+		type TestCase struct {
+			Desc       string
+			Settings   string
+			SourceCode string
+			Want       []protocol.TextEdit
+		}
+		testCases := []TestCase{
+			{
+				Desc:     "k&r - func brace on same line",
+				Settings: `{"formatting": {"style": "k&r"}}`,
+				SourceCode: `void main()
+{
+}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 0, Character: 11},
+							End:   protocol.Position{Line: 1, Character: 0},
+						},
+						NewText: " ",
+					},
+				},
+			},
+			{
+				Desc:     "k&r - cuddle else",
+				Settings: `{"formatting": {"style": "k&r"}}`,
+				SourceCode: `void main() {
+    if(1) {
+    }
+    else {
+    }
+}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 6},
+							End:   protocol.Position{Line: 1, Character: 6},
+						},
+						NewText: " ",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 2, Character: 5},
+							End:   protocol.Position{Line: 3, Character: 4},
+						},
+						NewText: " ",
+					},
+				},
+			},
+			{
+				Desc:     "k&r - spaces around operators",
+				Settings: `{"formatting": {"style": "k&r"}}`,
+				SourceCode: `void main() {
+    Integer a=1+2;
+}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 13},
+							End:   protocol.Position{Line: 1, Character: 13},
+						},
+						NewText: " ",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 14},
+							End:   protocol.Position{Line: 1, Character: 14},
+						},
+						NewText: " ",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 15},
+							End:   protocol.Position{Line: 1, Character: 15},
+						},
+						NewText: " ",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 16},
+							End:   protocol.Position{Line: 1, Character: 16},
+						},
+						NewText: " ",
+					},
+				},
+			},
+			{
+				Desc:     "allman - braces and else on new line",
+				Settings: `{"formatting": {"style": "allman"}}`,
+				SourceCode: `void main() {
+    if (1) {
+    } else {
+    }
+}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 0, Character: 11},
+							End:   protocol.Position{Line: 0, Character: 12},
+						},
+						NewText: "\n",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 10},
+							End:   protocol.Position{Line: 1, Character: 11},
+						},
+						NewText: "\n    ",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 2, Character: 5},
+							End:   protocol.Position{Line: 2, Character: 6},
+						},
+						NewText: "\n    ",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 2, Character: 10},
+							End:   protocol.Position{Line: 2, Character: 11},
+						},
+						NewText: "\n    ",
+					},
+				},
+			},
+			{
+				Desc:     "allman - spaces around operators",
+				Settings: `{"formatting": {"style": "allman"}}`,
+				SourceCode: `void main()
+{
+    Integer a=1+2;
+}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 2, Character: 13},
+							End:   protocol.Position{Line: 2, Character: 13},
+						},
+						NewText: " ",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 2, Character: 14},
+							End:   protocol.Position{Line: 2, Character: 14},
+						},
+						NewText: " ",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 2, Character: 15},
+							End:   protocol.Position{Line: 2, Character: 15},
+						},
+						NewText: " ",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 2, Character: 16},
+							End:   protocol.Position{Line: 2, Character: 16},
+						},
+						NewText: " ",
+					},
+				},
+			},
+			{
+				Desc:     "allman - do while and case block braces on new line",
+				Settings: `{"formatting": {"style": "allman"}}`,
+				SourceCode: `void main()
+{
+    Integer i = 0;
+    do {
+        i++;
+    } while (i < 3);
+    switch (i)
+    {
+        case 1: {
+            Print(i);
+        } break;
+    }
+}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 3, Character: 6},
+							End:   protocol.Position{Line: 3, Character: 7},
+						},
+						NewText: "\n    ",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 8, Character: 15},
+							End:   protocol.Position{Line: 8, Character: 16},
+						},
+						NewText: "\n        ",
+					},
+				},
+			},
+			{
+				Desc:     "12d-stock - func brace after banner comments",
+				Settings: `{"formatting": {"style": "12d-stock"}}`,
+				SourceCode: `Integer Is_rgb(Integer colour)
+// --------------------------------------------------------------------------
+{
+    if (colour) {
+        return 1;
+    }
+    return 0;
+}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 3, Character: 6},
+							End:   protocol.Position{Line: 3, Character: 7},
+						},
+						NewText: "",
+					},
+				},
+			},
+			{
+				Desc:     "12d-stock - no spaces around operators",
+				Settings: `{"formatting": {"style": "12d-stock"}}`,
+				SourceCode: `void main()
+{
+    Integer a = 1 + 2, b = a - -1;
+}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 2, Character: 13},
+							End:   protocol.Position{Line: 2, Character: 14},
+						},
+						NewText: "",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 2, Character: 15},
+							End:   protocol.Position{Line: 2, Character: 16},
+						},
+						NewText: "",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 2, Character: 17},
+							End:   protocol.Position{Line: 2, Character: 18},
+						},
+						NewText: "",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 2, Character: 19},
+							End:   protocol.Position{Line: 2, Character: 20},
+						},
+						NewText: "",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 2, Character: 24},
+							End:   protocol.Position{Line: 2, Character: 25},
+						},
+						NewText: "",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 2, Character: 26},
+							End:   protocol.Position{Line: 2, Character: 27},
+						},
+						NewText: "",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 2, Character: 28},
+							End:   protocol.Position{Line: 2, Character: 29},
+						},
+						NewText: "",
+					},
+				},
+			},
+			{
+				Desc:     "max line width - wrap call arguments",
+				Settings: `{"formatting": {"maxLineWidth": 30}}`,
+				SourceCode: `void main() {
+    Foo(aaaaaaaa, bbbbbbbb, cccccccc);
+}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 8},
+							End:   protocol.Position{Line: 1, Character: 8},
+						},
+						NewText: "\n        ",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 17},
+							End:   protocol.Position{Line: 1, Character: 18},
+						},
+						NewText: "\n        ",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 27},
+							End:   protocol.Position{Line: 1, Character: 28},
+						},
+						NewText: "\n        ",
+					},
+				},
+			},
+			{
+				Desc:       "max line width - wrap parameters",
+				Settings:   `{"formatting": {"maxLineWidth": 30}}`,
+				SourceCode: `void Foo(Integer aaaa, Integer bbbb) {}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 0, Character: 9},
+							End:   protocol.Position{Line: 0, Character: 9},
+						},
+						NewText: "\n    ",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 0, Character: 22},
+							End:   protocol.Position{Line: 0, Character: 23},
+						},
+						NewText: "\n    ",
+					},
+				},
+			},
+			{
+				Desc:     "max line width - join wrapped list which fits",
+				Settings: `{"formatting": {"maxLineWidth": 80}}`,
+				SourceCode: `void main() {
+    Foo(a,
+        b);
+}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 10},
+							End:   protocol.Position{Line: 2, Character: 8},
+						},
+						NewText: " ",
+					},
+				},
+			},
+			{
+				Desc:     "max line width - wrapped list is re-flowed one item per line",
+				Settings: `{"formatting": {"maxLineWidth": 30}}`,
+				SourceCode: `void main() {
+    Foo(aaaaaaaa, bbbbbbbb,
+        cccccccc);
+}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 8},
+							End:   protocol.Position{Line: 1, Character: 8},
+						},
+						NewText: "\n        ",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 17},
+							End:   protocol.Position{Line: 1, Character: 18},
+						},
+						NewText: "\n        ",
+					},
+				},
+			},
+			{
+				Desc:     "align consecutive - declarations and trailing comments",
+				Settings: `{"formatting": {"alignConsecutive": true}}`,
+				SourceCode: `void main() {
+    Integer a = 1; // One.
+    Real bb = 2; // Two.
+
+    Text c = "c";
+}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 13},
+							End:   protocol.Position{Line: 1, Character: 14},
+						},
+						NewText: "  ",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 2, Character: 8},
+							End:   protocol.Position{Line: 2, Character: 9},
+						},
+						NewText: "    ",
+					},
+				},
+			},
+			{
+				Desc:     "align consecutive - comment on own line breaks run",
+				Settings: `{"formatting": {"alignConsecutive": true}}`,
+				SourceCode: `void main() {
+    Integer a = 1;
+    // Comment.
+    Real bb = 2;
+}`,
+				Want: []protocol.TextEdit{},
+			},
+			{
+				Desc:     "unknown style keeps braces",
+				Settings: `{"formatting": {"style": "gnu"}}`,
+				SourceCode: `void main()
+{
+}`,
+				Want: []protocol.TextEdit{},
+			},
+			{
+				Desc:     "organize includes - sort and remove duplicates",
+				Settings: `{"formatting": {"organizeIncludes": true}}`,
+				SourceCode: `#include "strings.h"
+#include "set_ups.h"
+#include "strings.h"
+void main() {}`,
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 0, Character: 0},
+							End:   protocol.Position{Line: 2, Character: 20},
+						},
+						NewText: "#include \"set_ups.h\"\n#include \"strings.h\"",
+					},
+				},
+			},
+			{
+				Desc:       "line ending - normalize to lf",
+				Settings:   `{"formatting": {"lineEnding": "lf"}}`,
+				SourceCode: "void main() {\r\n    Integer a;\n}",
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 0, Character: 13},
+							End:   protocol.Position{Line: 1, Character: 0},
+						},
+						NewText: "\n",
+					},
+				},
+			},
+			{
+				Desc:       "line ending - normalize to crlf",
+				Settings:   `{"formatting": {"lineEnding": "crlf"}}`,
+				SourceCode: "void main() {\n    /* multi\n    line */\r\n}",
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 0, Character: 13},
+							End:   protocol.Position{Line: 1, Character: 0},
+						},
+						NewText: "\r\n",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 12},
+							End:   protocol.Position{Line: 2, Character: 0},
+						},
+						NewText: "\r\n",
+					},
+				},
+			},
+			{
+				Desc:       "line ending - unknown line ending is ignored",
+				Settings:   `{"formatting": {"lineEnding": "cr"}}`,
+				SourceCode: "void main() {\r\n    Integer a;\n}",
+				Want:       []protocol.TextEdit{},
+			},
+		}
+		for _, testCase := range testCases {
+			t.Run(testCase.Desc, func(t *testing.T) {
+				defer goleak.VerifyNone(t)
+				assert := assert.New(t)
+				in, out, cleanUp := startServer("", nil, nil, nil)
+				defer cleanUp()
+
+				var id int64 = 1
+				configMsgBytes, err := newDidChangeConfigurationRequestMessageBytes(id, testCase.Settings)
+				assert.NoError(err)
+				_, err = in.Writer.Write([]byte(server.ToProtocolMessage(configMsgBytes)))
+				assert.NoError(err)
+
+				didOpenMsgBytes, err := newDidOpenRequestMessageBytes(id, "file:///12d/proj/main.4dm", testCase.SourceCode)
+				assert.NoError(err)
+				_, err = in.Writer.Write([]byte(server.ToProtocolMessage(didOpenMsgBytes)))
+				assert.NoError(err)
+
+				msgBytes, err := newFormattingRequestMessageBytes(id, "file:///12d/proj/main.4dm", protocol.FormattingOptions{TabSize: 4, InsertSpaces: true})
+				assert.NoError(err)
+				_, err = in.Writer.Write([]byte(server.ToProtocolMessage(msgBytes)))
+				assert.NoError(err)
+
+				got, err := getReponseMessage(out.Reader)
+				assert.NoError(err)
+				assert.Equal(int64(1), got.ID)
+				var gotUnmarshalled []protocol.TextEdit
+				err = json.Unmarshal(got.Result, &gotUnmarshalled)
+				assert.NoError(err)
+				assert.Equal(testCase.Want, gotUnmarshalled)
+			})
+		}
+	})
+
+	t.Run("initialize - style from initialization options", func(t *testing.T) {
+		defer goleak.VerifyNone(t)
+		assert := assert.New(t)
+		in, out, cleanUp := startServer("", nil, nil, nil)
+		defer cleanUp()
+
+		var id int64 = 1
+		initializeMsgBytes, err := newInitializeRequestMessageBytes(id, `{"formatting": {"style": "allman"}}`)
+		assert.NoError(err)
+		_, err = in.Writer.Write([]byte(server.ToProtocolMessage(initializeMsgBytes)))
+		assert.NoError(err)
+		_, err = getReponseMessage(out.Reader)
+		assert.NoError(err)
+
+		didOpenMsgBytes, err := newDidOpenRequestMessageBytes(id, "file:///12d/proj/main.4dm", "void main() {}")
+		assert.NoError(err)
+		_, err = in.Writer.Write([]byte(server.ToProtocolMessage(didOpenMsgBytes)))
+		assert.NoError(err)
+
+		id++
+		msgBytes, err := newFormattingRequestMessageBytes(id, "file:///12d/proj/main.4dm", protocol.FormattingOptions{TabSize: 4, InsertSpaces: true})
+		assert.NoError(err)
+		_, err = in.Writer.Write([]byte(server.ToProtocolMessage(msgBytes)))
+		assert.NoError(err)
+
+		got, err := getReponseMessage(out.Reader)
+		assert.NoError(err)
+		assert.Equal(id, got.ID)
+		var gotUnmarshalled []protocol.TextEdit
+		err = json.Unmarshal(got.Result, &gotUnmarshalled)
+		assert.NoError(err)
+		assert.Equal(
+			[]protocol.TextEdit{
+				{
+					Range: protocol.Range{
+						Start: protocol.Position{Line: 0, Character: 11},
+						End:   protocol.Position{Line: 0, Character: 12},
+					},
+					NewText: "\n",
+				},
+			},
+			gotUnmarshalled,
+		)
+	})
+
+	t.Run("textDocument/rangeFormatting", func(t *testing.T) {
+		type TestCase struct {
+			Desc       string
+			SourceCode string
+			Range      protocol.Range
+			Want       []protocol.TextEdit
+		}
+		testCases := []TestCase{
+			{
+				Desc: "only formats lines in range",
+				SourceCode: `void main() {
+Integer a = 1;
+Integer b = 2;
+}`,
+				Range: protocol.Range{
+					Start: protocol.Position{Line: 2, Character: 0},
+					End:   protocol.Position{Line: 2, Character: 14},
+				},
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 2, Character: 0},
+							End:   protocol.Position{Line: 2, Character: 0},
+						},
+						NewText: "    ",
+					},
+				},
+			},
+			{
+				Desc: "expands range to enclosing statement",
+				SourceCode: `void main() {
+Integer a =
+    1;
+Integer b = 2;
+}`,
+				Range: protocol.Range{
+					Start: protocol.Position{Line: 2, Character: 0},
+					End:   protocol.Position{Line: 2, Character: 6},
+				},
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 0},
+							End:   protocol.Position{Line: 1, Character: 0},
+						},
+						NewText: "    ",
+					},
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 2, Character: 0},
+							End:   protocol.Position{Line: 2, Character: 4},
+						},
+						NewText: "        ",
+					},
+				},
+			},
+			{
+				Desc: "no edits outside of range",
+				SourceCode: `void main() {
+Integer a = 1;
+    Integer b = 2;
+}`,
+				Range: protocol.Range{
+					Start: protocol.Position{Line: 2, Character: 0},
+					End:   protocol.Position{Line: 2, Character: 18},
+				},
+				Want: []protocol.TextEdit{},
+			},
+		}
+		for _, testCase := range testCases {
+			t.Run(testCase.Desc, func(t *testing.T) {
+				defer goleak.VerifyNone(t)
+				assert := assert.New(t)
+				in, out, cleanUp := startServer("", nil, nil, nil)
+				defer cleanUp()
+
+				var id int64 = 1
+				didOpenMsgBytes, err := newDidOpenRequestMessageBytes(id, "file:///12d/proj/main.4dm", testCase.SourceCode)
+				assert.NoError(err)
+				_, err = in.Writer.Write([]byte(server.ToProtocolMessage(didOpenMsgBytes)))
+				assert.NoError(err)
+
+				msgBytes, err := newRangeFormattingRequestMessageBytes(id, "file:///12d/proj/main.4dm", testCase.Range)
+				assert.NoError(err)
+				_, err = in.Writer.Write([]byte(server.ToProtocolMessage(msgBytes)))
+				assert.NoError(err)
+
+				got, err := getReponseMessage(out.Reader)
+				assert.NoError(err)
+				assert.Equal(int64(1), got.ID)
+				var gotUnmarshalled []protocol.TextEdit
+				err = json.Unmarshal(got.Result, &gotUnmarshalled)
+				assert.NoError(err)
+				assert.Equal(testCase.Want, gotUnmarshalled)
+			})
+		}
+	})
+
+	t.Run("textDocument/onTypeFormatting", func(t *testing.T) {
+		type TestCase struct {
+			Desc       string
+			SourceCode string
+			Position   protocol.Position
+			Ch         string
+			Want       []protocol.TextEdit
+		}
+		testCases := []TestCase{
+			{
+				Desc: "semi colon re-indents block",
+				SourceCode: `void main() {
+Integer a = 1;
+}`,
+				Position: protocol.Position{Line: 1, Character: 14},
+				Ch:       ";",
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 1, Character: 0},
+							End:   protocol.Position{Line: 1, Character: 0},
+						},
+						NewText: "    ",
+					},
+				},
+			},
+			{
+				Desc: "newline indents blank line to block level",
+				SourceCode: `void main() {
+    if (1) {
+
+    }
+}`,
+				Position: protocol.Position{Line: 2, Character: 0},
+				Ch:       "\n",
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 2, Character: 0},
+							End:   protocol.Position{Line: 2, Character: 0},
+						},
+						NewText: "        ",
+					},
+				},
+			},
+			{
+				Desc: "newline replaces whitespace on blank line",
+				SourceCode: `void main() {
+    if (1) {
+  
+    }
+}`,
+				Position: protocol.Position{Line: 2, Character: 2},
+				Ch:       "\n",
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 2, Character: 0},
+							End:   protocol.Position{Line: 2, Character: 2},
+						},
+						NewText: "        ",
+					},
+				},
+			},
+			{
+				Desc:       "newline on crlf blank line keeps carriage return",
+				SourceCode: "void main() {\r\n    if (1) {\r\n  \r\n    }\r\n}",
+				Position:   protocol.Position{Line: 2, Character: 2},
+				Ch:         "\n",
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 2, Character: 0},
+							End:   protocol.Position{Line: 2, Character: 2},
+						},
+						NewText: "        ",
+					},
+				},
+			},
+			{
+				Desc: "closing brace of function re-indents function",
+				SourceCode: `  void main() {
+}`,
+				Position: protocol.Position{Line: 1, Character: 1},
+				Ch:       "}",
+				Want: []protocol.TextEdit{
+					{
+						Range: protocol.Range{
+							Start: protocol.Position{Line: 0, Character: 0},
+							End:   protocol.Position{Line: 0, Character: 2},
+						},
+						NewText: "",
+					},
+				},
+			},
+		}
+		for _, testCase := range testCases {
+			t.Run(testCase.Desc, func(t *testing.T) {
+				defer goleak.VerifyNone(t)
+				assert := assert.New(t)
+				in, out, cleanUp := startServer("", nil, nil, nil)
+				defer cleanUp()
+
+				var id int64 = 1
+				didOpenMsgBytes, err := newDidOpenRequestMessageBytes(id, "file:///12d/proj/main.4dm", testCase.SourceCode)
+				assert.NoError(err)
+				_, err = in.Writer.Write([]byte(server.ToProtocolMessage(didOpenMsgBytes)))
+				assert.NoError(err)
+
+				msgBytes, err := newOnTypeFormattingRequestMessageBytes(id, "file:///12d/proj/main.4dm", testCase.Position, testCase.Ch)
+				assert.NoError(err)
+				_, err = in.Writer.Write([]byte(server.ToProtocolMessage(msgBytes)))
+				assert.NoError(err)
+
+				got, err := getReponseMessage(out.Reader)
+				assert.NoError(err)
+				assert.Equal(int64(1), got.ID)
+				var gotUnmarshalled []protocol.TextEdit
+				err = json.Unmarshal(got.Result, &gotUnmarshalled)
+				assert.NoError(err)
+				assert.Equal(testCase.Want, gotUnmarshalled)
+			})
+		}
+	})
+
+	t.Run("textDocument/definition", func(t *testing.T) {
+		type TestCase struct {
+			Desc        string
+			SourceCode  string
+			IncludesDir string
+			Pos         protocol.Position
+			Want        protocol.ResponseMessage
 		}
 		testCases := []TestCase{
 			{
@@ -1312,734 +2545,2259 @@ void main() {
     return augend + addend;
 }
 
-void main() {
-    Integer result = Add(1, 2);
+void main() {
+    Integer result = Add(1, 2);
+}`,
+				Pos: protocol.Position{Line: 5, Character: 21},
+				Want: mustNewLocationResponseMessage(
+					"file:///12d/proj/main.4dm",
+					protocol.Position{Line: 0, Character: 8},
+					protocol.Position{Line: 0, Character: 11},
+				),
+			},
+			{
+				Desc: "func parameter identifier",
+				SourceCode: `Integer Add(Integer augend, Integer addend) {
+	Text foo = "\"hello\""
+    return augend + addend;
+}
+
+void main() {
+    Integer augend = 1;
+    Integer addend = 1;
+}`,
+				Pos: protocol.Position{Line: 2, Character: 11},
+				Want: mustNewLocationResponseMessage(
+					"file:///12d/proj/main.4dm",
+					protocol.Position{Line: 0, Character: 20},
+					protocol.Position{Line: 0, Character: 26},
+				),
+			},
+			{
+				Desc: "func parameter identifier in multiline parameter list",
+				SourceCode: `Integer Add(
+    Integer augend,
+    Integer addend
+) {
+    return augend + addend;
+}`,
+				Pos: protocol.Position{Line: 4, Character: 11},
+				Want: mustNewLocationResponseMessage(
+					"file:///12d/proj/main.4dm",
+					protocol.Position{Line: 1, Character: 12},
+					protocol.Position{Line: 1, Character: 18},
+				),
+			},
+			{
+				Desc: "func pointer parameter",
+				SourceCode: `void SetABC(Dynamic_Text &dt) {
+    Set_item(dt, 1, "abc");
+}`,
+				Pos: protocol.Position{Line: 1, Character: 13},
+				Want: mustNewLocationResponseMessage(
+					"file:///12d/proj/main.4dm",
+					protocol.Position{Line: 0, Character: 26},
+					protocol.Position{Line: 0, Character: 28},
+				),
+			},
+			{
+				Desc: "binary expression local variable",
+				SourceCode: `Integer Add_one(Integer addend) {
+    Integer augend = 1;
+    return augend + addend;
+}`,
+				Pos: protocol.Position{Line: 2, Character: 11},
+				Want: mustNewLocationResponseMessage(
+					"file:///12d/proj/main.4dm",
+					protocol.Position{Line: 1, Character: 12},
+					protocol.Position{Line: 1, Character: 18},
+				),
+			},
+			{
+				Desc: "local variable",
+				SourceCode: `Integer One() {
+    Integer result = 1;
+    return result;
+}`,
+				Pos: protocol.Position{Line: 2, Character: 11},
+				Want: mustNewLocationResponseMessage(
+					"file:///12d/proj/main.4dm",
+					protocol.Position{Line: 1, Character: 12},
+					protocol.Position{Line: 1, Character: 18},
+				),
+			},
+			{
+				Desc: "local variable inside for loop",
+				SourceCode: `Integer Looper() {
+    for (Integer i = 1; i <= 10; i++) {
+        Integer j = i;
+    }
+}`,
+				Pos: protocol.Position{Line: 2, Character: 20},
+				Want: mustNewLocationResponseMessage(
+					"file:///12d/proj/main.4dm",
+					protocol.Position{Line: 1, Character: 17},
+					protocol.Position{Line: 1, Character: 18},
+				),
+			},
+			{
+				Desc: "local variable outside for loop",
+				SourceCode: `Integer Looper() {
+    Integer k = 1;
+    for (Integer i = 1; i <= 10; i++) {
+        Integer j = k;
+    }
+}`,
+				Pos: protocol.Position{Line: 3, Character: 20},
+				Want: mustNewLocationResponseMessage(
+					"file:///12d/proj/main.4dm",
+					protocol.Position{Line: 1, Character: 12},
+					protocol.Position{Line: 1, Character: 13},
+				),
+			},
+			{
+				Desc: "local variable inside for loop",
+				SourceCode: `Integer Looper() {
+    for (Integer i = 1; i <= 10; i++) {
+        Integer k = 1;
+        Integer j = k;
+    }
+}`,
+				Pos: protocol.Position{Line: 3, Character: 20},
+				Want: mustNewLocationResponseMessage(
+					"file:///12d/proj/main.4dm",
+					protocol.Position{Line: 2, Character: 16},
+					protocol.Position{Line: 2, Character: 17},
+				),
+			},
+			{
+				Desc: "declaration without initialisation",
+				SourceCode: `void Validate_source_box(Source_Box source_box) {
+    Dynamic_Element elts;
+    Validate(source_box, elts);
+}`,
+				Pos: protocol.Position{Line: 2, Character: 25},
+				Want: mustNewLocationResponseMessage(
+					"file:///12d/proj/main.4dm",
+					protocol.Position{Line: 1, Character: 20},
+					protocol.Position{Line: 1, Character: 24},
+				),
+			},
+			{
+				Desc: "preproc definition",
+				SourceCode: `#define DEBUG 1
+
+void main() {
+    if (DEBUG) {
+        Print("debug");
+    }
+}`,
+				Pos: protocol.Position{Line: 3, Character: 8},
+				Want: mustNewLocationResponseMessage(
+					"file:///12d/proj/main.4dm",
+					protocol.Position{Line: 0, Character: 8},
+					protocol.Position{Line: 0, Character: 13},
+				),
+			},
+			{
+				Desc: "global scope",
+				SourceCode: `{
+    Integer AREA_CODE = 10;
+}
+
+void main() {
+    Integer result = AREA_CODE + 1;
+}`,
+				Pos: protocol.Position{Line: 5, Character: 21},
+				Want: mustNewLocationResponseMessage(
+					"file:///12d/proj/main.4dm",
+					protocol.Position{Line: 1, Character: 12},
+					protocol.Position{Line: 1, Character: 21},
+				),
+			},
+			{
+				Desc: "multiple declaration - first var with initialisation",
+				SourceCode: `void main() {
+    Real x = 1, y;
+    Point pt;
+    Set_x(pt, x);
+    Set_y(pt, y);
+}`,
+				Pos: protocol.Position{Line: 3, Character: 14},
+				Want: mustNewLocationResponseMessage(
+					"file:///12d/proj/main.4dm",
+					protocol.Position{Line: 1, Character: 9},
+					protocol.Position{Line: 1, Character: 10},
+				),
+			},
+			{
+				Desc: "multiple declaration - second var without initialisation",
+				SourceCode: `void main() {
+    Real x = 1, y;
+    Point pt;
+    Set_x(pt, x);
+    Set_y(pt, y);
+}`,
+				Pos: protocol.Position{Line: 4, Character: 14},
+				Want: mustNewLocationResponseMessage(
+					"file:///12d/proj/main.4dm",
+					protocol.Position{Line: 1, Character: 16},
+					protocol.Position{Line: 1, Character: 17},
+				),
+			},
+			{
+				Desc: "declaration in include file",
+				SourceCode: `#include "set_ups.h"
+
+void main() {
+    Integer mode = ALL_WIDGETS_OWN_WIDTH;
+}`,
+				Pos:         protocol.Position{Line: 3, Character: 19},
+				IncludesDir: includesDir,
+				Want: mustNewLocationResponseMessage(
+					protocol.URI(filepath.Join(includesDir, "set_ups.h")),
+					protocol.Position{Line: 0, Character: 8},
+					protocol.Position{Line: 0, Character: 29},
+				),
+			},
+			{
+				Desc: "declaration in include file with include dirs as current dir",
+				SourceCode: `#include "lib.h"
+
+void main() {
+    Integer hello = WORLD;
+}`,
+				Pos:         protocol.Position{Line: 3, Character: 20},
+				IncludesDir: server.SourceFileDirToken,
+				Want: mustNewLocationResponseMessage(
+					protocol.URI("/12d/proj/lib.h"),
+					protocol.Position{Line: 0, Character: 8},
+					protocol.Position{Line: 0, Character: 13},
+				),
+			},
+			{
+				Desc: "declaration in include file with include dirs as current dir - relative path",
+				SourceCode: `#include "../set_ups.h"
+
+void main() {
+    Integer hello = TRUE;
+}`,
+				Pos:         protocol.Position{Line: 3, Character: 20},
+				IncludesDir: server.SourceFileDirToken,
+				Want: mustNewLocationResponseMessage(
+					protocol.URI("/12d/set_ups.h"),
+					protocol.Position{Line: 1, Character: 8},
+					protocol.Position{Line: 1, Character: 12},
+				),
+			},
+			{
+				Desc: "preproc definition after include file",
+				SourceCode: `#include "set_ups.h"
+#define HELLO "hello"
+
+void main() {
+    Text hello = HELLO;
+}`,
+				Pos:         protocol.Position{Line: 4, Character: 17},
+				IncludesDir: includesDir,
+				Want: mustNewLocationResponseMessage(
+					"file:///12d/proj/main.4dm",
+					protocol.Position{Line: 1, Character: 8},
+					protocol.Position{Line: 1, Character: 13},
+				),
+			},
+			{
+				Desc: "preproc definition before include file",
+				SourceCode: `#define HELLO "hello"
+#include "set_ups.h"
+
+void main() {
+    Text hello = HELLO;
+}`,
+				Pos:         protocol.Position{Line: 4, Character: 17},
+				IncludesDir: includesDir,
+				Want: mustNewLocationResponseMessage(
+					"file:///12d/proj/main.4dm",
+					protocol.Position{Line: 0, Character: 8},
+					protocol.Position{Line: 0, Character: 13},
+				),
+			},
+			{
+				Desc: "preproc definition as array index",
+				SourceCode: `#define SIZE 1
+
+void main() {
+    Integer list[SIZE];
+}`,
+				Pos: protocol.Position{Line: 3, Character: 17},
+				Want: mustNewLocationResponseMessage(
+					"file:///12d/proj/main.4dm",
+					protocol.Position{Line: 0, Character: 8},
+					protocol.Position{Line: 0, Character: 12},
+				),
+			},
+			{
+				Desc: "goto label",
+				SourceCode: `void main() {
+    goto done;
+done:
+    return;
+}`,
+				Pos: protocol.Position{Line: 1, Character: 10},
+				Want: mustNewLocationResponseMessage(
+					"file:///12d/proj/main.4dm",
+					protocol.Position{Line: 2, Character: 0},
+					protocol.Position{Line: 2, Character: 4},
+				),
+			},
+			// TODO: func overloads.
+		}
+		for _, testCase := range testCases {
+			t.Run(testCase.Desc, func(t *testing.T) {
+				defer goleak.VerifyNone(t)
+				assert := assert.New(t)
+				logger, err := newLogger()
+				assert.NoError(err)
+				in, out, cleanUp := startServer(testCase.IncludesDir, langCompletions, mockIncludesResolver, logger)
+				defer cleanUp()
+
+				var id int64 = 1
+				didOpenMsgBytes, err := newDidOpenRequestMessageBytes(id, "file:///12d/proj/main.4dm", testCase.SourceCode)
+				assert.NoError(err)
+				_, err = in.Writer.Write([]byte(server.ToProtocolMessage(didOpenMsgBytes)))
+				assert.NoError(err)
+
+				definitionMsgBytes, err := newDefinitionRequestMessageBytes(id, "file:///12d/proj/main.4dm", testCase.Pos)
+				assert.NoError(err)
+				_, err = in.Writer.Write([]byte(server.ToProtocolMessage(definitionMsgBytes)))
+				assert.NoError(err)
+
+				got, err := getReponseMessage(out.Reader)
+				assert.NoError(err)
+				assertResponseMessageEqual(t, testCase.Want, got)
+			})
+		}
+	})
+
+	t.Run("textDocument/typeDefinition", func(t *testing.T) {
+		typeDocsDir := t.TempDir()
+		typeDocURI := func(typeName string) string {
+			return protocol.URI(filepath.Join(typeDocsDir, typeName+".h"))
+		}
+		type TestCase struct {
+			Desc        string
+			SourceCode  string
+			IncludesDir string
+			Pos         protocol.Position
+			Want        protocol.ResponseMessage
+		}
+		testCases := []TestCase{
+			{
+				Desc: "local variable of define aliased type",
+				SourceCode: `#define Handle Integer
+
+void main() {
+    Handle h;
+    Print(h);
+}`,
+				Pos: protocol.Position{Line: 4, Character: 10},
+				Want: mustNewLocationResponseMessage(
+					"file:///12d/proj/main.4dm",
+					protocol.Position{Line: 0, Character: 8},
+					protocol.Position{Line: 0, Character: 14},
+				),
+			},
+			{
+				Desc: "local variable of built in type",
+				SourceCode: `void main() {
+    Integer_Box box;
+    Print(box);
+}`,
+				Pos: protocol.Position{Line: 2, Character: 10},
+				Want: mustNewLocationResponseMessage(
+					typeDocURI("Integer_Box"),
+					protocol.Position{Line: 5, Character: 0},
+					protocol.Position{Line: 5, Character: 11},
+				),
+			},
+			{
+				Desc: "array parameter of built in type",
+				SourceCode: `void Foo(Text &lines[]) {
+    Print(lines[1]);
+}`,
+				Pos: protocol.Position{Line: 1, Character: 10},
+				Want: mustNewLocationResponseMessage(
+					typeDocURI("Text"),
+					protocol.Position{Line: 4, Character: 0},
+					protocol.Position{Line: 4, Character: 4},
+				),
+			},
+			{
+				Desc: "undefined identifier",
+				SourceCode: `void main() {
+    Print(box);
+}`,
+				Pos:  protocol.Position{Line: 1, Character: 10},
+				Want: newNullResponseMessage(1),
+			},
+		}
+		for _, testCase := range testCases {
+			t.Run(testCase.Desc, func(t *testing.T) {
+				defer goleak.VerifyNone(t)
+				assert := assert.New(t)
+				logger, err := newLogger()
+				assert.NoError(err)
+				serv := server.NewServer(testCase.IncludesDir, langCompletions, mockIncludesResolver, true, logger)
+				serv.SetTypeDocsDir(typeDocsDir)
+				in, out, cleanUp := serveServer(&serv, logger)
+				defer cleanUp()
+
+				var id int64 = 1
+				didOpenMsgBytes, err := newDidOpenRequestMessageBytes(id, "file:///12d/proj/main.4dm", testCase.SourceCode)
+				assert.NoError(err)
+				_, err = in.Writer.Write([]byte(server.ToProtocolMessage(didOpenMsgBytes)))
+				assert.NoError(err)
+
+				reqMsgBytes, err := newTypeDefinitionRequestMessageBytes(id, "file:///12d/proj/main.4dm", testCase.Pos)
+				assert.NoError(err)
+				_, err = in.Writer.Write([]byte(server.ToProtocolMessage(reqMsgBytes)))
+				assert.NoError(err)
+
+				got, err := getReponseMessage(out.Reader)
+				assert.NoError(err)
+				assertResponseMessageEqual(t, testCase.Want, got)
+			})
+		}
+
+		t.Run("stale document is regenerated read only", func(t *testing.T) {
+			defer goleak.VerifyNone(t)
+			assert := assert.New(t)
+			staleDocsDir := t.TempDir()
+			docFilepath := filepath.Join(staleDocsDir, "Integer_Box.h")
+			assert.NoError(os.WriteFile(docFilepath, []byte("stale"), 0444))
+			serv := server.NewServer("", langCompletions, mockIncludesResolver, true, nil)
+			serv.SetTypeDocsDir(staleDocsDir)
+			in, out, cleanUp := serveServer(&serv, nil)
+			defer cleanUp()
+
+			var id int64 = 1
+			didOpenMsgBytes, err := newDidOpenRequestMessageBytes(id, "file:///12d/proj/main.4dm", `void main() {
+    Integer_Box box;
+    Print(box);
+}`)
+			assert.NoError(err)
+			_, err = in.Writer.Write([]byte(server.ToProtocolMessage(didOpenMsgBytes)))
+			assert.NoError(err)
+
+			reqMsgBytes, err := newTypeDefinitionRequestMessageBytes(id, "file:///12d/proj/main.4dm", protocol.Position{Line: 2, Character: 10})
+			assert.NoError(err)
+			_, err = in.Writer.Write([]byte(server.ToProtocolMessage(reqMsgBytes)))
+			assert.NoError(err)
+
+			got, err := getReponseMessage(out.Reader)
+			assert.NoError(err)
+			assertResponseMessageEqual(t, mustNewLocationResponseMessage(
+				protocol.URI(docFilepath),
+				protocol.Position{Line: 5, Character: 0},
+				protocol.Position{Line: 5, Character: 11},
+			), got)
+			contents, err := os.ReadFile(docFilepath)
+			assert.NoError(err)
+			assert.Contains(string(contents), "Integer_Box;")
+			info, err := os.Stat(docFilepath)
+			assert.NoError(err)
+			assert.Equal(os.FileMode(0444), info.Mode().Perm())
+		})
+	})
+
+	t.Run("textDocument/references", func(t *testing.T) {
+		type TestCase struct {
+			Desc               string
+			SourceCode         string
+			IncludesDir        string
+			IncludeDeclaration bool
+			Pos                protocol.Position
+			Want               protocol.ResponseMessage
+		}
+		testCases := []TestCase{
+			{
+				Desc: "local var - include declaration",
+				SourceCode: `void main() {
+    Integer a = 1;
+    Integer result = a;
+}`,
+				Pos:                protocol.Position{Line: 2, Character: 21},
+				IncludeDeclaration: true,
+				Want: mustNewLocationsResponseMessage(
+					[]protocol.Location{
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 1, Character: 12},
+								End:   protocol.Position{Line: 1, Character: 13},
+							},
+						},
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 2, Character: 21},
+								End:   protocol.Position{Line: 2, Character: 22},
+							},
+						},
+					},
+				),
+			},
+			{
+				Desc: "local var - no include declaration",
+				SourceCode: `void main() {
+    Integer a = 1;
+    Integer result = a;
+}`,
+				Pos:                protocol.Position{Line: 2, Character: 21},
+				IncludeDeclaration: false,
+				Want: mustNewLocationsResponseMessage(
+					[]protocol.Location{
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 2, Character: 21},
+								End:   protocol.Position{Line: 2, Character: 22},
+							},
+						},
+					},
+				),
+			},
+			{
+				Desc: "user defined func - include declaration",
+				SourceCode: `Integer One() {
+    return 1;
+}
+
+void main() {
+    Integer one = One();
+}`,
+				Pos:                protocol.Position{Line: 5, Character: 18},
+				IncludeDeclaration: true,
+				Want: mustNewLocationsResponseMessage(
+					[]protocol.Location{
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 0, Character: 8},
+								End:   protocol.Position{Line: 0, Character: 11},
+							},
+						},
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 5, Character: 18},
+								End:   protocol.Position{Line: 5, Character: 21},
+							},
+						},
+					},
+				),
+			},
+			{
+				Desc: "user defined func - no include declaration",
+				SourceCode: `Integer One() {
+    return 1;
+}
+
+void main() {
+    Integer one = One();
+}`,
+				Pos:                protocol.Position{Line: 5, Character: 18},
+				IncludeDeclaration: false,
+				Want: mustNewLocationsResponseMessage(
+					[]protocol.Location{
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 5, Character: 18},
+								End:   protocol.Position{Line: 5, Character: 21},
+							},
+						},
+					},
+				),
+			},
+			{
+				Desc: "user defined func - param - include declaration",
+				SourceCode: `Integer Identity(Integer subject) {
+    return subject;
+}`,
+				Pos:                protocol.Position{Line: 1, Character: 11},
+				IncludeDeclaration: true,
+				Want: mustNewLocationsResponseMessage(
+					[]protocol.Location{
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 0, Character: 25},
+								End:   protocol.Position{Line: 0, Character: 32},
+							},
+						},
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 1, Character: 11},
+								End:   protocol.Position{Line: 1, Character: 18},
+							},
+						},
+					},
+				),
+			},
+			{
+				Desc: "user defined func - param - no include declaration",
+				SourceCode: `Integer Identity(Integer subject) {
+    return subject;
+}`,
+				Pos:                protocol.Position{Line: 1, Character: 11},
+				IncludeDeclaration: false,
+				Want: mustNewLocationsResponseMessage(
+					[]protocol.Location{
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 1, Character: 11},
+								End:   protocol.Position{Line: 1, Character: 18},
+							},
+						},
+					},
+				),
+			},
+			{
+				Desc: "user defined func - param in scope - include declaration",
+				SourceCode: `Integer Identity(Integer subject) {
+    return subject;
+}
+
+void main() {
+    Integer subject = 1;
+	Integer result = subject + 1;
+}`,
+				Pos:                protocol.Position{Line: 1, Character: 11},
+				IncludeDeclaration: true,
+				Want: mustNewLocationsResponseMessage(
+					[]protocol.Location{
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 0, Character: 25},
+								End:   protocol.Position{Line: 0, Character: 32},
+							},
+						},
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 1, Character: 11},
+								End:   protocol.Position{Line: 1, Character: 18},
+							},
+						},
+					},
+				),
+			},
+			{
+				Desc: "user defined func - param in scope - include declaration",
+				SourceCode: `Integer Identity(Integer subject) {
+    return subject;
+}
+
+void main() {
+    Integer subject = 1;
+    Integer result = subject + 1;
+}`,
+				Pos:                protocol.Position{Line: 6, Character: 21},
+				IncludeDeclaration: true,
+				Want: mustNewLocationsResponseMessage(
+					[]protocol.Location{
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 5, Character: 12},
+								End:   protocol.Position{Line: 5, Character: 19},
+							},
+						},
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 6, Character: 21},
+								End:   protocol.Position{Line: 6, Character: 28},
+							},
+						},
+					},
+				),
+			},
+			{
+				Desc: "user defined func - param in scope (reference) - include declaration",
+				SourceCode: `Integer Identity(Integer &subject) {
+    return subject;
+}
+
+void main() {
+    Integer subject = 1;
+    Integer result = subject + 1;
+}`,
+				Pos:                protocol.Position{Line: 1, Character: 11},
+				IncludeDeclaration: true,
+				Want: mustNewLocationsResponseMessage(
+					[]protocol.Location{
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 0, Character: 26},
+								End:   protocol.Position{Line: 0, Character: 33},
+							},
+						},
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 1, Character: 11},
+								End:   protocol.Position{Line: 1, Character: 18},
+							},
+						},
+					},
+				),
+			},
+			{
+				Desc: "global param - consumption reference request - include declaration",
+				SourceCode: `{
+    Integer ONE = 1;
+}
+
+Integer AddOne(Integer subject) {
+    return subject + ONE;
+}`,
+				Pos:                protocol.Position{Line: 5, Character: 21},
+				IncludeDeclaration: true,
+				Want: mustNewLocationsResponseMessage(
+					[]protocol.Location{
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 1, Character: 12},
+								End:   protocol.Position{Line: 1, Character: 15},
+							},
+						},
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 5, Character: 21},
+								End:   protocol.Position{Line: 5, Character: 24},
+							},
+						},
+					},
+				),
+			},
+			{
+				Desc: "global param - definition reference request - include declaration",
+				SourceCode: `{
+    Integer ONE = 1;
+}
+
+Integer AddOne(Integer subject) {
+    return subject + ONE;
+}`,
+				Pos:                protocol.Position{Line: 1, Character: 12},
+				IncludeDeclaration: true,
+				Want: mustNewLocationsResponseMessage(
+					[]protocol.Location{
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 1, Character: 12},
+								End:   protocol.Position{Line: 1, Character: 15},
+							},
+						},
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 5, Character: 21},
+								End:   protocol.Position{Line: 5, Character: 24},
+							},
+						},
+					},
+				),
+			},
+			{
+				Desc: "global param - no include declaration",
+				SourceCode: `{
+    Integer ONE = 1;
+}
+
+Integer AddOne(Integer subject) {
+    return subject + ONE;
+}`,
+				Pos:                protocol.Position{Line: 5, Character: 21},
+				IncludeDeclaration: false,
+				Want: mustNewLocationsResponseMessage(
+					[]protocol.Location{
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 5, Character: 21},
+								End:   protocol.Position{Line: 5, Character: 24},
+							},
+						},
+					},
+				),
+			},
+			{
+				Desc: "preproc def - include declaration",
+				SourceCode: `#define ONE 1
+
+Integer AddOne(Integer subject) {
+    return subject + ONE;
+}`,
+				Pos:                protocol.Position{Line: 3, Character: 21},
+				IncludeDeclaration: true,
+				Want: mustNewLocationsResponseMessage(
+					[]protocol.Location{
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 0, Character: 8},
+								End:   protocol.Position{Line: 0, Character: 11},
+							},
+						},
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 3, Character: 21},
+								End:   protocol.Position{Line: 3, Character: 24},
+							},
+						},
+					},
+				),
+			},
+			{
+				Desc: "preproc def - no include declaration",
+				SourceCode: `#define ONE 1
+
+Integer AddOne(Integer subject) {
+    return subject + ONE;
+}`,
+				Pos:                protocol.Position{Line: 3, Character: 21},
+				IncludeDeclaration: false,
+				Want: mustNewLocationsResponseMessage(
+					[]protocol.Location{
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 3, Character: 21},
+								End:   protocol.Position{Line: 3, Character: 24},
+							},
+						},
+					},
+				),
+			},
+			{
+				Desc: "lib func",
+				SourceCode: `void main() {
+    Print("hello");
+    Print("world");
+}`,
+				Pos:                protocol.Position{Line: 2, Character: 4},
+				IncludeDeclaration: false,
+				Want: mustNewLocationsResponseMessage(
+					[]protocol.Location{
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 2, Character: 4},
+								End:   protocol.Position{Line: 2, Character: 9},
+							},
+						},
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 1, Character: 4},
+								End:   protocol.Position{Line: 1, Character: 9},
+							},
+						},
+					},
+				),
+			},
+			{
+				Desc: "label - include declaration",
+				SourceCode: `void main() {
+    goto done;
+    goto done;
+done:
+    return;
+}`,
+				Pos:                protocol.Position{Line: 3, Character: 1},
+				IncludeDeclaration: true,
+				Want: mustNewLocationsResponseMessage(
+					[]protocol.Location{
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 3, Character: 0},
+								End:   protocol.Position{Line: 3, Character: 4},
+							},
+						},
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 1, Character: 9},
+								End:   protocol.Position{Line: 1, Character: 13},
+							},
+						},
+						{
+							URI: "file:///12d/proj/main.4dm",
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 2, Character: 9},
+								End:   protocol.Position{Line: 2, Character: 13},
+							},
+						},
+					},
+				),
+			},
+			// TODO: references in include files.
+		}
+		for _, testCase := range testCases {
+			t.Run(testCase.Desc, func(t *testing.T) {
+				defer goleak.VerifyNone(t)
+				assert := assert.New(t)
+				logger, err := newLogger()
+				assert.NoError(err)
+				in, out, cleanUp := startServer(testCase.IncludesDir, langCompletions, nil, logger)
+				defer cleanUp()
+
+				var id int64 = 1
+				didOpenMsgBytes, err := newDidOpenRequestMessageBytes(id, "file:///12d/proj/main.4dm", testCase.SourceCode)
+				assert.NoError(err)
+				_, err = in.Writer.Write([]byte(server.ToProtocolMessage(didOpenMsgBytes)))
+				assert.NoError(err)
+
+				reqMsgBytes, err := newReferencesRequestMessageBytes(id, "file:///12d/proj/main.4dm", testCase.Pos, testCase.IncludeDeclaration)
+				assert.NoError(err)
+				_, err = in.Writer.Write([]byte(server.ToProtocolMessage(reqMsgBytes)))
+				assert.NoError(err)
+
+				got, err := getReponseMessage(out.Reader)
+				assert.NoError(err)
+				assertResponseMessageEqual(t, testCase.Want, got)
+			})
+		}
+	})
+
+	t.Run("textDocument/diagnostic", func(t *testing.T) {
+		mustNewDiagnosticResponseMessage := func(start, end protocol.Position, severity uint, errMsg string) protocol.ResponseMessage {
+			report := protocol.DocumentDiagnosticReport{
+				FullDocumentDiagnosticReport: protocol.FullDocumentDiagnosticReport{
+					Kind: protocol.DocumentDiagnosticReportKindFull,
+					Items: []protocol.Diagnostic{
+						{
+							Range:    protocol.Range{Start: start, End: end},
+							Severity: severity,
+							Source:   "12d-lang-server",
+							Message:  errMsg,
+						},
+					},
+				},
+			}
+
+			msg, err := newDiagnosticsResponseMessage(1, report)
+			require.NoError(t, err)
+			return msg
+		}
+		mustNewDiagnosticsResponseMessage := func(items ...protocol.Diagnostic) protocol.ResponseMessage {
+			report := protocol.DocumentDiagnosticReport{
+				FullDocumentDiagnosticReport: protocol.FullDocumentDiagnosticReport{
+					Kind:  protocol.DocumentDiagnosticReportKindFull,
+					Items: items,
+				},
+			}
+			msg, err := newDiagnosticsResponseMessage(1, report)
+			require.NoError(t, err)
+			return msg
+		}
+		newUnusedDiagnostic := func(start, end protocol.Position, code, message string) protocol.Diagnostic {
+			return protocol.Diagnostic{
+				Range:    protocol.Range{Start: start, End: end},
+				Severity: protocol.DiagnosticSeverityHint,
+				Source:   "12d-lang-server",
+				Message:  message,
+				Code:     code,
+				Tags:     []uint{protocol.DiagnosticTagUnnecessary},
+			}
+		}
+		mustNewEmptyDiagnosticResponseMessage := func() protocol.ResponseMessage {
+			report := protocol.DocumentDiagnosticReport{
+				FullDocumentDiagnosticReport: protocol.FullDocumentDiagnosticReport{
+					Kind:  protocol.DocumentDiagnosticReportKindFull,
+					Items: []protocol.Diagnostic{},
+				},
+			}
+			msg, err := newDiagnosticsResponseMessage(1, report)
+			require.NoError(t, err)
+			return msg
+		}
+
+		type TestCase struct {
+			Desc       string
+			SourceCode string
+			// URI of the document, "file:///12d/proj/main.4dm" when empty.
+			URI         string
+			IncludesDir string
+			Settings    string
+			Report      protocol.DocumentDiagnosticReport
+			Want        protocol.ResponseMessage
+		}
+		testCases := []TestCase{
+			{
+				Desc: "incomplete declaration - missing semi colon",
+				SourceCode: `void main() {
+    Integer a = 1
 }`,
-				Pos: protocol.Position{Line: 5, Character: 21},
-				Want: mustNewLocationResponseMessage(
-					"file:///12d/proj/main.4dm",
-					protocol.Position{Line: 0, Character: 8},
-					protocol.Position{Line: 0, Character: 11},
+
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 1, Character: 17},
+					protocol.Position{Line: 1, Character: 17},
+					protocol.DiagnosticSeverityError,
+					"Expected \";\".",
 				),
 			},
 			{
-				Desc: "func parameter identifier",
-				SourceCode: `Integer Add(Integer augend, Integer addend) {
-	Text foo = "\"hello\""
-    return augend + addend;
+				Desc: "incomplete declaration - multi-declaration missing semi colon",
+				SourceCode: `void main() {
+    Integer a = 1, b = 2
+}`,
+
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 1, Character: 24},
+					protocol.Position{Line: 1, Character: 24},
+					protocol.DiagnosticSeverityError,
+					"Expected \";\".",
+				),
+			},
+			{
+				Desc: "incomplete declaration - missing expr",
+				SourceCode: `void main() {
+    Integer a = ;
+}`,
+
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 1, Character: 16},
+					protocol.Position{Line: 1, Character: 17},
+					protocol.DiagnosticSeverityError,
+					"Expected expression.",
+				),
+			},
+			{
+				Desc: "undeclared var",
+				SourceCode: `void main() {
+    Integer a = b;
+    Print(a);
+}`,
+
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 1, Character: 16},
+					protocol.Position{Line: 1, Character: 17},
+					protocol.DiagnosticSeverityError,
+					"Identifier \"b\" is undefined.",
+				),
+			},
+			{
+				Desc: "unresolved include",
+				SourceCode: `#include "missing.h"
+#include "set_ups.h"
+
+void main() {}`,
+				IncludesDir: includesDir,
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 0, Character: 10},
+					protocol.Position{Line: 0, Character: 19},
+					protocol.DiagnosticSeverityError,
+					"Include file \"/12d/missing.h\" could not be resolved.",
+				),
+			},
+			{
+				Desc: "type check - Text initializer assigned to Integer",
+				SourceCode: `void main() {
+    Integer a = "one";
+    Print(a);
+}`,
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 1, Character: 16},
+					protocol.Position{Line: 1, Character: 21},
+					protocol.DiagnosticSeverityError,
+					"Type \"Text\" is not assignable to type \"Integer\".",
+				),
+			},
+			{
+				Desc: "type check - user function return type assigned to Integer",
+				SourceCode: `Text GetName() {
+    return "name";
 }
 
 void main() {
-    Integer augend = 1;
-    Integer addend = 1;
+    Integer a;
+    a = GetName();
 }`,
-				Pos: protocol.Position{Line: 2, Character: 11},
-				Want: mustNewLocationResponseMessage(
-					"file:///12d/proj/main.4dm",
-					protocol.Position{Line: 0, Character: 20},
-					protocol.Position{Line: 0, Character: 26},
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 6, Character: 8},
+					protocol.Position{Line: 6, Character: 17},
+					protocol.DiagnosticSeverityError,
+					"Type \"Text\" is not assignable to type \"Integer\".",
 				),
 			},
 			{
-				Desc: "func parameter identifier in multiline parameter list",
-				SourceCode: `Integer Add(
-    Integer augend,
-    Integer addend
-) {
-    return augend + addend;
+				Desc: "type check - array element assigned to Text",
+				SourceCode: `void main() {
+    Real values[10];
+    Text a = values[1];
+    Print(a);
 }`,
-				Pos: protocol.Position{Line: 4, Character: 11},
-				Want: mustNewLocationResponseMessage(
-					"file:///12d/proj/main.4dm",
-					protocol.Position{Line: 1, Character: 12},
-					protocol.Position{Line: 1, Character: 18},
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 2, Character: 13},
+					protocol.Position{Line: 2, Character: 22},
+					protocol.DiagnosticSeverityError,
+					"Type \"Real\" is not assignable to type \"Text\".",
 				),
 			},
 			{
-				Desc: "func pointer parameter",
-				SourceCode: `void SetABC(Dynamic_Text &dt) {
-    Set_item(dt, 1, "abc");
+				Desc: "type check - Widget assigned to widget subtype",
+				SourceCode: `void main() {
+    Widget widget;
+    Button button = widget;
+    Set_width_in_chars(button, 10);
 }`,
-				Pos: protocol.Position{Line: 1, Character: 13},
-				Want: mustNewLocationResponseMessage(
-					"file:///12d/proj/main.4dm",
-					protocol.Position{Line: 0, Character: 26},
-					protocol.Position{Line: 0, Character: 28},
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 2, Character: 20},
+					protocol.Position{Line: 2, Character: 26},
+					protocol.DiagnosticSeverityError,
+					"Type \"Widget\" is not assignable to type \"Button\".",
 				),
 			},
 			{
-				Desc: "binary expression local variable",
-				SourceCode: `Integer Add_one(Integer addend) {
-    Integer augend = 1;
-    return augend + addend;
+				Desc: "type check - binary operator operand mismatch",
+				SourceCode: `void main() {
+    Text a = "one";
+    Integer b = 1;
+    Integer c = a - b;
+    Print(c);
 }`,
-				Pos: protocol.Position{Line: 2, Character: 11},
-				Want: mustNewLocationResponseMessage(
-					"file:///12d/proj/main.4dm",
-					protocol.Position{Line: 1, Character: 12},
-					protocol.Position{Line: 1, Character: 18},
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 3, Character: 18},
+					protocol.Position{Line: 3, Character: 19},
+					protocol.DiagnosticSeverityError,
+					"Operator \"-\" cannot be applied to types \"Text\" and \"Integer\".",
 				),
 			},
 			{
-				Desc: "local variable",
-				SourceCode: `Integer One() {
-    Integer result = 1;
-    return result;
+				Desc: "type check - compound assignment operand mismatch",
+				SourceCode: `void main() {
+    Text a = "one";
+    a += 1;
 }`,
-				Pos: protocol.Position{Line: 2, Character: 11},
-				Want: mustNewLocationResponseMessage(
-					"file:///12d/proj/main.4dm",
-					protocol.Position{Line: 1, Character: 12},
-					protocol.Position{Line: 1, Character: 18},
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 2, Character: 6},
+					protocol.Position{Line: 2, Character: 8},
+					protocol.DiagnosticSeverityError,
+					"Operator \"+=\" cannot be applied to types \"Text\" and \"Integer\".",
+				),
+			},
+			{
+				Desc: "type check - unary operator operand mismatch",
+				SourceCode: `void main() {
+    Text a = "one";
+    a++;
+}`,
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 2, Character: 5},
+					protocol.Position{Line: 2, Character: 7},
+					protocol.DiagnosticSeverityError,
+					"Operator \"++\" cannot be applied to type \"Text\".",
+				),
+			},
+			{
+				Desc: "type check - mismatch in define from include",
+				SourceCode: `#include "set_ups.h"
+
+void main() {
+    Text a = TRUE;
+    Print(a);
+}`,
+				IncludesDir: includesDir,
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 3, Character: 13},
+					protocol.Position{Line: 3, Character: 17},
+					protocol.DiagnosticSeverityError,
+					"Type \"Integer\" is not assignable to type \"Text\".",
 				),
 			},
 			{
-				Desc: "local variable inside for loop",
-				SourceCode: `Integer Looper() {
-    for (Integer i = 1; i <= 10; i++) {
-        Integer j = i;
-    }
-}`,
-				Pos: protocol.Position{Line: 2, Character: 20},
-				Want: mustNewLocationResponseMessage(
-					"file:///12d/proj/main.4dm",
-					protocol.Position{Line: 1, Character: 17},
-					protocol.Position{Line: 1, Character: 18},
-				),
+				Desc: "type check - valid conversions",
+				SourceCode: `void main() {
+    Integer a = 1;
+    Real b = a + 1.5;
+    a = b * 2;
+    Text c = "a" + "b";
+    Button button;
+    Widget widget = button;
+    Integer d = c == "ab" && a < b;
+    Set_width_in_chars(widget, d);
+}`,
+				Want: mustNewEmptyDiagnosticResponseMessage(),
 			},
 			{
-				Desc: "local variable outside for loop",
-				SourceCode: `Integer Looper() {
-    Integer k = 1;
-    for (Integer i = 1; i <= 10; i++) {
-        Integer j = k;
-    }
+				Desc: "type check - unknown types are not reported",
+				SourceCode: `void main() {
+    Vector3 b;
+    Vector3 c = b + b;
+    Text d = Unknown_function();
+    b = c;
+    Print(d);
 }`,
-				Pos: protocol.Position{Line: 3, Character: 20},
-				Want: mustNewLocationResponseMessage(
-					"file:///12d/proj/main.4dm",
-					protocol.Position{Line: 1, Character: 12},
-					protocol.Position{Line: 1, Character: 13},
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 3, Character: 13},
+					protocol.Position{Line: 3, Character: 29},
+					protocol.DiagnosticSeverityError,
+					"Identifier \"Unknown_function\" is undefined.",
 				),
 			},
 			{
-				Desc: "local variable inside for loop",
-				SourceCode: `Integer Looper() {
-    for (Integer i = 1; i <= 10; i++) {
-        Integer k = 1;
-        Integer j = k;
-    }
+				Desc: "type check - library function return type assigned to Text",
+				SourceCode: `void main() {
+    Text name;
+    Text result = Get_project_name(name);
+    Print(result);
 }`,
-				Pos: protocol.Position{Line: 3, Character: 20},
-				Want: mustNewLocationResponseMessage(
-					"file:///12d/proj/main.4dm",
-					protocol.Position{Line: 2, Character: 16},
-					protocol.Position{Line: 2, Character: 17},
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 2, Character: 18},
+					protocol.Position{Line: 2, Character: 40},
+					protocol.DiagnosticSeverityError,
+					"Type \"Integer\" is not assignable to type \"Text\".",
 				),
 			},
 			{
-				Desc: "declaration without initialisation",
-				SourceCode: `void Validate_source_box(Source_Box source_box) {
-    Dynamic_Element elts;
-    Validate(source_box, elts);
+				Desc: "library call - wrong number of arguments",
+				SourceCode: `void main() {
+    Text text = Get_subtext("hello", 1);
+    Print(text);
 }`,
-				Pos: protocol.Position{Line: 2, Character: 25},
-				Want: mustNewLocationResponseMessage(
-					"file:///12d/proj/main.4dm",
-					protocol.Position{Line: 1, Character: 20},
-					protocol.Position{Line: 1, Character: 24},
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 1, Character: 27},
+					protocol.Position{Line: 1, Character: 39},
+					protocol.DiagnosticSeverityError,
+					"Arguments do not match \"Get_subtext\".\n"+
+						"Text Get_subtext(Text text, Integer start, Integer end): expected 3 arguments but got 2.",
 				),
 			},
 			{
-				Desc: "preproc definition",
-				SourceCode: `#define DEBUG 1
-
-void main() {
-    if (DEBUG) {
-        Print("debug");
-    }
+				Desc: "library call - no overload matches argument types",
+				SourceCode: `void main() {
+    Text values[2];
+    Print(values);
 }`,
-				Pos: protocol.Position{Line: 3, Character: 8},
-				Want: mustNewLocationResponseMessage(
-					"file:///12d/proj/main.4dm",
-					protocol.Position{Line: 0, Character: 8},
-					protocol.Position{Line: 0, Character: 13},
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 2, Character: 10},
+					protocol.Position{Line: 2, Character: 16},
+					protocol.DiagnosticSeverityError,
+					"No overload of \"Print\" matches the arguments, closest overloads:\n"+
+						"void Print(Uid uid): argument 1 of type \"Text[]\" is not assignable to parameter \"Uid uid\".\n"+
+						"void Print(Guid guid): argument 1 of type \"Text[]\" is not assignable to parameter \"Guid guid\".\n"+
+						"void Print(Text msg): argument 1 of type \"Text[]\" is not assignable to parameter \"Text msg\".",
 				),
 			},
 			{
-				Desc: "global scope",
-				SourceCode: `{
-    Integer AREA_CODE = 10;
-}
-
-void main() {
-    Integer result = AREA_CODE + 1;
+				Desc: "library call - closest overload has matching argument count",
+				SourceCode: `void main() {
+    Element elt;
+    Real level;
+    Get_super_2d_level(level, elt);
 }`,
-				Pos: protocol.Position{Line: 5, Character: 21},
-				Want: mustNewLocationResponseMessage(
-					"file:///12d/proj/main.4dm",
-					protocol.Position{Line: 1, Character: 12},
-					protocol.Position{Line: 1, Character: 21},
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 3, Character: 23},
+					protocol.Position{Line: 3, Character: 28},
+					protocol.DiagnosticSeverityError,
+					"Arguments do not match \"Get_super_2d_level\".\n"+
+						"Integer Get_super_2d_level(Element elt, Real &level): argument 1 of type \"Real\" is not assignable to parameter \"Element elt\".",
 				),
 			},
 			{
-				Desc: "multiple declaration - first var with initialisation",
+				Desc: "library call - matching calls are not reported",
 				SourceCode: `void main() {
-    Real x = 1, y;
-    Point pt;
-    Set_x(pt, x);
-    Set_y(pt, y);
+    Integer length = 1;
+    Print("hello");
+    Print(length);
+    Text text = Get_subtext("hello world", 1, length - 1);
+    Dynamic_Text items;
+    Text item;
+    Get_item(items, 1, item);
+    Print(text);
 }`,
-				Pos: protocol.Position{Line: 3, Character: 14},
-				Want: mustNewLocationResponseMessage(
-					"file:///12d/proj/main.4dm",
-					protocol.Position{Line: 1, Character: 9},
-					protocol.Position{Line: 1, Character: 10},
-				),
+				Want: mustNewEmptyDiagnosticResponseMessage(),
 			},
 			{
-				Desc: "multiple declaration - second var without initialisation",
+				Desc: "reference argument - literal passed to library function",
 				SourceCode: `void main() {
-    Real x = 1, y;
-    Point pt;
-    Set_x(pt, x);
-    Set_y(pt, y);
+    Get_project_name("name");
 }`,
-				Pos: protocol.Position{Line: 4, Character: 14},
-				Want: mustNewLocationResponseMessage(
-					"file:///12d/proj/main.4dm",
-					protocol.Position{Line: 1, Character: 16},
-					protocol.Position{Line: 1, Character: 17},
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 1, Character: 21},
+					protocol.Position{Line: 1, Character: 27},
+					protocol.DiagnosticSeverityError,
+					"Argument passed to reference parameter \"name\" must be a variable.",
 				),
 			},
 			{
-				Desc: "declaration in include file",
-				SourceCode: `#include "set_ups.h"
+				Desc: "reference argument - define passed to library function",
+				SourceCode: `#define PROJECT_NAME "name"
 
 void main() {
-    Integer mode = ALL_WIDGETS_OWN_WIDTH;
+    Get_project_name(PROJECT_NAME);
 }`,
-				Pos:         protocol.Position{Line: 3, Character: 19},
-				IncludesDir: includesDir,
-				Want: mustNewLocationResponseMessage(
-					protocol.URI(filepath.Join(includesDir, "set_ups.h")),
-					protocol.Position{Line: 0, Character: 8},
-					protocol.Position{Line: 0, Character: 29},
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 3, Character: 21},
+					protocol.Position{Line: 3, Character: 33},
+					protocol.DiagnosticSeverityError,
+					"Argument passed to reference parameter \"name\" must be a variable.",
 				),
 			},
 			{
-				Desc: "declaration in include file with include dirs as current dir",
-				SourceCode: `#include "lib.h"
+				Desc: "reference argument - expression passed to user function",
+				SourceCode: `void SetName(Integer idx, Text &name) {
+    if (idx > 0) {
+        name = "name";
+    }
+}
 
 void main() {
-    Integer hello = WORLD;
+    Text prefix = "a";
+    SetName(1, prefix + "b");
 }`,
-				Pos:         protocol.Position{Line: 3, Character: 20},
-				IncludesDir: server.SourceFileDirToken,
-				Want: mustNewLocationResponseMessage(
-					protocol.URI("/12d/proj/lib.h"),
-					protocol.Position{Line: 0, Character: 8},
-					protocol.Position{Line: 0, Character: 13},
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 8, Character: 15},
+					protocol.Position{Line: 8, Character: 27},
+					protocol.DiagnosticSeverityError,
+					"Argument passed to reference parameter \"name\" must be a variable.",
 				),
 			},
 			{
-				Desc: "declaration in include file with include dirs as current dir - relative path",
-				SourceCode: `#include "../set_ups.h"
+				Desc: "reference argument - variables are not reported",
+				SourceCode: `void SetName(Text &name) {
+    name = "name";
+}
 
 void main() {
-    Integer hello = TRUE;
+    Text name;
+    Text names[2];
+    SetName(name);
+    SetName(names[1]);
+    Get_project_name(name);
 }`,
-				Pos:         protocol.Position{Line: 3, Character: 20},
-				IncludesDir: server.SourceFileDirToken,
-				Want: mustNewLocationResponseMessage(
-					protocol.URI("/12d/set_ups.h"),
-					protocol.Position{Line: 1, Character: 8},
-					protocol.Position{Line: 1, Character: 12},
-				),
+				Want: mustNewEmptyDiagnosticResponseMessage(),
 			},
 			{
-				Desc: "preproc definition after include file",
-				SourceCode: `#include "set_ups.h"
-#define HELLO "hello"
+				Desc: "return - missing return on some paths",
+				SourceCode: `Integer IsPositive(Integer value) {
+    if (value > 0) {
+        return 1;
+    } else if (value < 0) {
+        return 0;
+    }
+}
 
 void main() {
-    Text hello = HELLO;
+    Print(IsPositive(1));
 }`,
-				Pos:         protocol.Position{Line: 4, Character: 17},
-				IncludesDir: includesDir,
-				Want: mustNewLocationResponseMessage(
-					"file:///12d/proj/main.4dm",
-					protocol.Position{Line: 1, Character: 8},
-					protocol.Position{Line: 1, Character: 13},
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 0, Character: 8},
+					protocol.Position{Line: 0, Character: 18},
+					protocol.DiagnosticSeverityError,
+					"Function \"IsPositive\" does not return a value on all code paths.",
 				),
 			},
 			{
-				Desc: "preproc definition before include file",
-				SourceCode: `#define HELLO "hello"
-#include "set_ups.h"
+				Desc: "return - loop with break can fall off the end",
+				SourceCode: `Integer Loop() {
+    while (1) {
+        break;
+    }
+}
 
 void main() {
-    Text hello = HELLO;
+    Print(Loop());
 }`,
-				Pos:         protocol.Position{Line: 4, Character: 17},
-				IncludesDir: includesDir,
-				Want: mustNewLocationResponseMessage(
-					"file:///12d/proj/main.4dm",
+				Want: mustNewDiagnosticResponseMessage(
 					protocol.Position{Line: 0, Character: 8},
-					protocol.Position{Line: 0, Character: 13},
+					protocol.Position{Line: 0, Character: 12},
+					protocol.DiagnosticSeverityError,
+					"Function \"Loop\" does not return a value on all code paths.",
 				),
 			},
+			// TODO: the parser does not support hex and exponent literals or for
+			// loops without a condition, the functions are not checked since
+			// they have syntax errors.
 			{
-				Desc: "preproc definition as array index",
-				SourceCode: `#define SIZE 1
+				Desc: "return - loop with a zero hex condition",
+				SourceCode: `Integer Loop() {
+    while (0x0) {
+        Print("never");
+    }
+}
 
 void main() {
-    Integer list[SIZE];
+    Print(Loop());
 }`,
-				Pos: protocol.Position{Line: 3, Character: 17},
-				Want: mustNewLocationResponseMessage(
-					"file:///12d/proj/main.4dm",
-					protocol.Position{Line: 0, Character: 8},
-					protocol.Position{Line: 0, Character: 12},
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 1, Character: 12},
+					protocol.Position{Line: 1, Character: 14},
+					protocol.DiagnosticSeverityError,
+					"Identifier \"x0\" is undefined.",
 				),
 			},
-			// TODO: func overloads.
-		}
-		for _, testCase := range testCases {
-			t.Run(testCase.Desc, func(t *testing.T) {
-				defer goleak.VerifyNone(t)
-				assert := assert.New(t)
-				logger, err := newLogger()
-				assert.NoError(err)
-				in, out, cleanUp := startServer(testCase.IncludesDir, langCompletions, mockIncludesResolver, logger)
-				defer cleanUp()
-
-				var id int64 = 1
-				didOpenMsgBytes, err := newDidOpenRequestMessageBytes(id, "file:///12d/proj/main.4dm", testCase.SourceCode)
-				assert.NoError(err)
-				_, err = in.Writer.Write([]byte(server.ToProtocolMessage(didOpenMsgBytes)))
-				assert.NoError(err)
-
-				definitionMsgBytes, err := newDefinitionRequestMessageBytes(id, "file:///12d/proj/main.4dm", testCase.Pos)
-				assert.NoError(err)
-				_, err = in.Writer.Write([]byte(server.ToProtocolMessage(definitionMsgBytes)))
-				assert.NoError(err)
-
-				got, err := getReponseMessage(out.Reader)
-				assert.NoError(err)
-				assertResponseMessageEqual(t, testCase.Want, got)
-			})
-		}
-	})
-
-	t.Run("textDocument/references", func(t *testing.T) {
-		type TestCase struct {
-			Desc               string
-			SourceCode         string
-			IncludesDir        string
-			IncludeDeclaration bool
-			Pos                protocol.Position
-			Want               protocol.ResponseMessage
-		}
-		testCases := []TestCase{
 			{
-				Desc: "local var - include declaration",
-				SourceCode: `void main() {
-    Integer a = 1;
-    Integer result = a;
-}`,
-				Pos:                protocol.Position{Line: 2, Character: 21},
-				IncludeDeclaration: true,
-				Want: mustNewLocationsResponseMessage(
-					[]protocol.Location{
-						{
-							URI: "file:///12d/proj/main.4dm",
-							Range: protocol.Range{
-								Start: protocol.Position{Line: 1, Character: 12},
-								End:   protocol.Position{Line: 1, Character: 13},
-							},
-						},
-						{
-							URI: "file:///12d/proj/main.4dm",
-							Range: protocol.Range{
-								Start: protocol.Position{Line: 2, Character: 21},
-								End:   protocol.Position{Line: 2, Character: 22},
-							},
-						},
-					},
+				Desc: "return - loop with a zero exponent condition",
+				SourceCode: `Integer Loop() {
+    while (0.0e0) {
+        Print("never");
+    }
+}
+
+void main() {
+    Print(Loop());
+}`,
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 1, Character: 14},
+					protocol.Position{Line: 1, Character: 16},
+					protocol.DiagnosticSeverityError,
+					"Identifier \"e0\" is undefined.",
 				),
 			},
 			{
-				Desc: "local var - no include declaration",
+				Desc: "return - for loop without a condition",
+				SourceCode: `Integer Loop() {
+    for (;;) {
+        return 1;
+    }
+}
+
+void main() {
+    Print(Loop());
+}`,
+				Want: mustNewEmptyDiagnosticResponseMessage(),
+			},
+			{
+				Desc: "return - value returned from void function",
 				SourceCode: `void main() {
-    Integer a = 1;
-    Integer result = a;
+    return 1;
 }`,
-				Pos:                protocol.Position{Line: 2, Character: 21},
-				IncludeDeclaration: false,
-				Want: mustNewLocationsResponseMessage(
-					[]protocol.Location{
-						{
-							URI: "file:///12d/proj/main.4dm",
-							Range: protocol.Range{
-								Start: protocol.Position{Line: 2, Character: 21},
-								End:   protocol.Position{Line: 2, Character: 22},
-							},
-						},
-					},
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 1, Character: 11},
+					protocol.Position{Line: 1, Character: 12},
+					protocol.DiagnosticSeverityError,
+					"Function \"main\" is void and cannot return a value.",
 				),
 			},
 			{
-				Desc: "user defined func - include declaration",
-				SourceCode: `Integer One() {
-    return 1;
+				Desc: "return - no value returned from non void function",
+				SourceCode: `Integer GetValue() {
+    return;
 }
 
 void main() {
-    Integer one = One();
+    Print(GetValue());
 }`,
-				Pos:                protocol.Position{Line: 5, Character: 18},
-				IncludeDeclaration: true,
-				Want: mustNewLocationsResponseMessage(
-					[]protocol.Location{
-						{
-							URI: "file:///12d/proj/main.4dm",
-							Range: protocol.Range{
-								Start: protocol.Position{Line: 0, Character: 8},
-								End:   protocol.Position{Line: 0, Character: 11},
-							},
-						},
-						{
-							URI: "file:///12d/proj/main.4dm",
-							Range: protocol.Range{
-								Start: protocol.Position{Line: 5, Character: 18},
-								End:   protocol.Position{Line: 5, Character: 21},
-							},
-						},
-					},
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 1, Character: 4},
+					protocol.Position{Line: 1, Character: 11},
+					protocol.DiagnosticSeverityError,
+					"Function \"GetValue\" must return a value of type \"Integer\".",
 				),
 			},
 			{
-				Desc: "user defined func - no include declaration",
-				SourceCode: `Integer One() {
-    return 1;
+				Desc: "return - returned type does not match return type",
+				SourceCode: `Integer GetValue() {
+    return "value";
 }
 
 void main() {
-    Integer one = One();
+    Print(GetValue());
 }`,
-				Pos:                protocol.Position{Line: 5, Character: 18},
-				IncludeDeclaration: false,
-				Want: mustNewLocationsResponseMessage(
-					[]protocol.Location{
-						{
-							URI: "file:///12d/proj/main.4dm",
-							Range: protocol.Range{
-								Start: protocol.Position{Line: 5, Character: 18},
-								End:   protocol.Position{Line: 5, Character: 21},
-							},
-						},
-					},
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 1, Character: 11},
+					protocol.Position{Line: 1, Character: 18},
+					protocol.DiagnosticSeverityError,
+					"Type \"Text\" is not assignable to return type \"Integer\".",
 				),
 			},
 			{
-				Desc: "user defined func - param - include declaration",
-				SourceCode: `Integer Identity(Integer subject) {
-    return subject;
+				Desc: "return - all paths return",
+				SourceCode: `Integer IfElse(Integer value) {
+    if (value > 0) {
+        return 1;
+    } else {
+        return 0;
+    }
+}
+
+Real Forever() {
+    while (1) {
+        Print("forever");
+    }
+}
+
+Integer Switch(Integer value) {
+    switch (value) {
+        case 1: {
+            return 1;
+        }
+        default:
+            return 0;
+    }
+}
+
+void main() {
+    IfElse(Switch(1));
+    Forever();
+    return;
 }`,
-				Pos:                protocol.Position{Line: 1, Character: 11},
-				IncludeDeclaration: true,
-				Want: mustNewLocationsResponseMessage(
-					[]protocol.Location{
-						{
-							URI: "file:///12d/proj/main.4dm",
-							Range: protocol.Range{
-								Start: protocol.Position{Line: 0, Character: 25},
-								End:   protocol.Position{Line: 0, Character: 32},
-							},
-						},
-						{
-							URI: "file:///12d/proj/main.4dm",
-							Range: protocol.Range{
-								Start: protocol.Position{Line: 1, Character: 11},
-								End:   protocol.Position{Line: 1, Character: 18},
-							},
-						},
-					},
-				),
+				Want: mustNewEmptyDiagnosticResponseMessage(),
 			},
 			{
-				Desc: "user defined func - param - no include declaration",
-				SourceCode: `Integer Identity(Integer subject) {
-    return subject;
+				Desc: "unused - local variables",
+				SourceCode: `void main() {
+    Integer a = 1, b;
+    Text values[2];
+    for (Integer i = 1; i <= 2; i++) {
+        Print(b);
+    }
 }`,
-				Pos:                protocol.Position{Line: 1, Character: 11},
-				IncludeDeclaration: false,
-				Want: mustNewLocationsResponseMessage(
-					[]protocol.Location{
-						{
-							URI: "file:///12d/proj/main.4dm",
-							Range: protocol.Range{
-								Start: protocol.Position{Line: 1, Character: 11},
-								End:   protocol.Position{Line: 1, Character: 18},
-							},
-						},
-					},
+				Want: mustNewDiagnosticsResponseMessage(
+					newUnusedDiagnostic(
+						protocol.Position{Line: 1, Character: 12},
+						protocol.Position{Line: 1, Character: 13},
+						server.DiagnosticCodeUnusedVariable,
+						"Variable \"a\" is declared but never used.",
+					),
+					newUnusedDiagnostic(
+						protocol.Position{Line: 2, Character: 9},
+						protocol.Position{Line: 2, Character: 15},
+						server.DiagnosticCodeUnusedVariable,
+						"Variable \"values\" is declared but never used.",
+					),
 				),
 			},
 			{
-				Desc: "user defined func - param in scope - include declaration",
-				SourceCode: `Integer Identity(Integer subject) {
-    return subject;
+				Desc: "unused - parameters",
+				SourceCode: `Integer Add(Integer a, Integer &b, Real values[]) {
+    return a + 1;
 }
 
 void main() {
-    Integer subject = 1;
-	Integer result = subject + 1;
-}`,
-				Pos:                protocol.Position{Line: 1, Character: 11},
-				IncludeDeclaration: true,
-				Want: mustNewLocationsResponseMessage(
-					[]protocol.Location{
-						{
-							URI: "file:///12d/proj/main.4dm",
-							Range: protocol.Range{
-								Start: protocol.Position{Line: 0, Character: 25},
-								End:   protocol.Position{Line: 0, Character: 32},
-							},
-						},
-						{
-							URI: "file:///12d/proj/main.4dm",
-							Range: protocol.Range{
-								Start: protocol.Position{Line: 1, Character: 11},
-								End:   protocol.Position{Line: 1, Character: 18},
-							},
-						},
-					},
+    Integer b;
+    Real values[2];
+    Print(Add(1, b, values));
+}`,
+				Want: mustNewDiagnosticsResponseMessage(
+					newUnusedDiagnostic(
+						protocol.Position{Line: 0, Character: 32},
+						protocol.Position{Line: 0, Character: 33},
+						server.DiagnosticCodeUnusedParameter,
+						"Parameter \"b\" is declared but never used.",
+					),
+					newUnusedDiagnostic(
+						protocol.Position{Line: 0, Character: 40},
+						protocol.Position{Line: 0, Character: 46},
+						server.DiagnosticCodeUnusedParameter,
+						"Parameter \"values\" is declared but never used.",
+					),
 				),
 			},
 			{
-				Desc: "user defined func - param in scope - include declaration",
-				SourceCode: `Integer Identity(Integer subject) {
-    return subject;
+				Desc: "unused - functions not reachable from main",
+				SourceCode: `void Helper() {
+    Print("helper");
+}
+
+void Run() {
+    Helper();
+}
+
+void Unused() {
+    Unused();
 }
 
 void main() {
-    Integer subject = 1;
-    Integer result = subject + 1;
+    Run();
+}`,
+				Want: mustNewDiagnosticsResponseMessage(
+					newUnusedDiagnostic(
+						protocol.Position{Line: 8, Character: 5},
+						protocol.Position{Line: 8, Character: 11},
+						server.DiagnosticCodeUnusedFunction,
+						"Function \"Unused\" is never called from \"main\".",
+					),
+				),
+			},
+			{
+				Desc: "unused - functions are not reported without main",
+				SourceCode: `void Helper() {
+    Print("helper");
 }`,
-				Pos:                protocol.Position{Line: 6, Character: 21},
-				IncludeDeclaration: true,
-				Want: mustNewLocationsResponseMessage(
-					[]protocol.Location{
-						{
-							URI: "file:///12d/proj/main.4dm",
-							Range: protocol.Range{
-								Start: protocol.Position{Line: 5, Character: 12},
-								End:   protocol.Position{Line: 5, Character: 19},
-							},
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 0, Character: 0},
+					protocol.Position{Line: 0, Character: 0},
+					protocol.DiagnosticSeverityError,
+					"Macro does not define \"void main()\", the macro cannot be run.",
+				),
+			},
+			{
+				Desc: "duplicate - variable declared twice in the same block",
+				SourceCode: `void main() {
+    Integer i = 1;
+    Integer i = 2;
+    Print(i);
+}`,
+				Want: mustNewDiagnosticsResponseMessage(protocol.Diagnostic{
+					Range:    protocol.Range{Start: protocol.Position{Line: 2, Character: 12}, End: protocol.Position{Line: 2, Character: 13}},
+					Severity: protocol.DiagnosticSeverityError,
+					Source:   "12d-lang-server",
+					Message:  "Variable \"i\" is already declared in this scope.",
+					RelatedInformation: []protocol.DiagnosticRelatedInformation{{
+						Location: protocol.Location{
+							URI:   "file:///12d/proj/main.4dm",
+							Range: protocol.Range{Start: protocol.Position{Line: 1, Character: 12}, End: protocol.Position{Line: 1, Character: 13}},
 						},
-						{
-							URI: "file:///12d/proj/main.4dm",
-							Range: protocol.Range{
-								Start: protocol.Position{Line: 6, Character: 21},
-								End:   protocol.Position{Line: 6, Character: 28},
+						Message: "\"i\" is first declared here.",
+					}},
+				}),
+			},
+			{
+				Desc: "duplicate - variables in nested blocks are not duplicates",
+				SourceCode: `void main() {
+    Integer i = 1;
+    if (i > 0) {
+        Integer i = 2;
+        Print(i);
+    }
+}`,
+				Want: mustNewEmptyDiagnosticResponseMessage(),
+			},
+			{
+				Desc: "duplicate - parameter declared twice and local shadowing a parameter",
+				SourceCode: `Integer Add(Integer a, Integer a) {
+    if (a > 0) {
+        Integer a = 1;
+        return a;
+    }
+    return 0;
+}
+
+void main() {
+    Print(Add(1, 2));
+}`,
+				Want: mustNewDiagnosticsResponseMessage(
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 0, Character: 31}, End: protocol.Position{Line: 0, Character: 32}},
+						Severity: protocol.DiagnosticSeverityError,
+						Source:   "12d-lang-server",
+						Message:  "Parameter \"a\" is already declared.",
+						RelatedInformation: []protocol.DiagnosticRelatedInformation{{
+							Location: protocol.Location{
+								URI:   "file:///12d/proj/main.4dm",
+								Range: protocol.Range{Start: protocol.Position{Line: 0, Character: 20}, End: protocol.Position{Line: 0, Character: 21}},
 							},
-						},
+							Message: "\"a\" is first declared here.",
+						}},
+					},
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 2, Character: 16}, End: protocol.Position{Line: 2, Character: 17}},
+						Severity: protocol.DiagnosticSeverityWarning,
+						Source:   "12d-lang-server",
+						Message:  "Variable \"a\" shadows a parameter.",
+						RelatedInformation: []protocol.DiagnosticRelatedInformation{{
+							Location: protocol.Location{
+								URI:   "file:///12d/proj/main.4dm",
+								Range: protocol.Range{Start: protocol.Position{Line: 0, Character: 20}, End: protocol.Position{Line: 0, Character: 21}},
+							},
+							Message: "Parameter \"a\" is declared here.",
+						}},
 					},
 				),
 			},
 			{
-				Desc: "user defined func - param in scope (reference) - include declaration",
-				SourceCode: `Integer Identity(Integer &subject) {
-    return subject;
+				Desc: "duplicate - function with the same signature as an included function",
+				SourceCode: `#include "strings.h"
+
+Text Trim(Text text) {
+    return text;
+}
+
+Text Trim(Text text, Integer count) {
+    return text;
 }
 
 void main() {
-    Integer subject = 1;
-    Integer result = subject + 1;
+    Print(Trim("a"));
+    Print(Trim("a", 1));
 }`,
-				Pos:                protocol.Position{Line: 1, Character: 11},
-				IncludeDeclaration: true,
-				Want: mustNewLocationsResponseMessage(
-					[]protocol.Location{
-						{
-							URI: "file:///12d/proj/main.4dm",
-							Range: protocol.Range{
-								Start: protocol.Position{Line: 0, Character: 26},
-								End:   protocol.Position{Line: 0, Character: 33},
-							},
-						},
-						{
-							URI: "file:///12d/proj/main.4dm",
-							Range: protocol.Range{
-								Start: protocol.Position{Line: 1, Character: 11},
-								End:   protocol.Position{Line: 1, Character: 18},
+				IncludesDir: includesDir,
+				Want: mustNewDiagnosticsResponseMessage(
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 2, Character: 5}, End: protocol.Position{Line: 2, Character: 9}},
+						Severity: protocol.DiagnosticSeverityError,
+						Source:   "12d-lang-server",
+						Message:  "Function \"Trim\" is already defined with the same parameter types.",
+						RelatedInformation: []protocol.DiagnosticRelatedInformation{{
+							Location: protocol.Location{
+								URI:   "file:///12d/strings.h",
+								Range: protocol.Range{Start: protocol.Position{Line: 0, Character: 5}, End: protocol.Position{Line: 0, Character: 9}},
 							},
-						},
+							Message: "\"Trim\" is first defined here.",
+						}},
 					},
+					newUnusedDiagnostic(
+						protocol.Position{Line: 6, Character: 29},
+						protocol.Position{Line: 6, Character: 34},
+						server.DiagnosticCodeUnusedParameter,
+						"Parameter \"count\" is declared but never used.",
+					),
 				),
 			},
 			{
-				Desc: "global param - consumption reference request - include declaration",
-				SourceCode: `{
-    Integer ONE = 1;
+				Desc: "duplicate - function shadowing a library function",
+				SourceCode: `void Print(Text message) {
+    return;
 }
 
-Integer AddOne(Integer subject) {
-    return subject + ONE;
-}`,
-				Pos:                protocol.Position{Line: 5, Character: 21},
-				IncludeDeclaration: true,
-				Want: mustNewLocationsResponseMessage(
-					[]protocol.Location{
-						{
-							URI: "file:///12d/proj/main.4dm",
-							Range: protocol.Range{
-								Start: protocol.Position{Line: 1, Character: 12},
-								End:   protocol.Position{Line: 1, Character: 15},
-							},
-						},
-						{
-							URI: "file:///12d/proj/main.4dm",
-							Range: protocol.Range{
-								Start: protocol.Position{Line: 5, Character: 21},
-								End:   protocol.Position{Line: 5, Character: 24},
+void main() {
+    Print("a");
+}`,
+				Want: mustNewDiagnosticsResponseMessage(
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 0, Character: 5}, End: protocol.Position{Line: 0, Character: 10}},
+						Severity: protocol.DiagnosticSeverityWarning,
+						Source:   "12d-lang-server",
+						Message:  "Function \"Print\" shadows the library function \"void Print(Text msg)\".",
+					},
+					newUnusedDiagnostic(
+						protocol.Position{Line: 0, Character: 16},
+						protocol.Position{Line: 0, Character: 23},
+						server.DiagnosticCodeUnusedParameter,
+						"Parameter \"message\" is declared but never used.",
+					),
+				),
+			},
+			{
+				Desc: "label - unreachable statements after return, break and goto",
+				SourceCode: `void main() {
+    Integer a = 1;
+    switch (a) {
+        case 1:
+            Print(a);
+            break;
+            Print(a);
+        default:
+            return;
+    }
+    goto done;
+    // skipped.
+    Print(a);
+    a = 2;
+done:
+    Print(a);
+    return;
+    a = 3;
+}`,
+				Want: mustNewDiagnosticsResponseMessage(
+					newUnusedDiagnostic(
+						protocol.Position{Line: 12, Character: 4},
+						protocol.Position{Line: 13, Character: 10},
+						server.DiagnosticCodeUnreachableCode,
+						"Unreachable code.",
+					),
+					newUnusedDiagnostic(
+						protocol.Position{Line: 17, Character: 4},
+						protocol.Position{Line: 17, Character: 10},
+						server.DiagnosticCodeUnreachableCode,
+						"Unreachable code.",
+					),
+					newUnusedDiagnostic(
+						protocol.Position{Line: 6, Character: 12},
+						protocol.Position{Line: 6, Character: 21},
+						server.DiagnosticCodeUnreachableCode,
+						"Unreachable code.",
+					),
+				),
+			},
+			{
+				Desc: "label - undefined, unused and duplicate labels",
+				SourceCode: `void main() {
+    goto missing;
+retry:
+    Print("a");
+    goto done;
+done:
+    return;
+done:
+    return;
+}`,
+				Want: mustNewDiagnosticsResponseMessage(
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 7, Character: 0}, End: protocol.Position{Line: 7, Character: 4}},
+						Severity: protocol.DiagnosticSeverityError,
+						Source:   "12d-lang-server",
+						Message:  "Label \"done\" is already defined in this function.",
+						RelatedInformation: []protocol.DiagnosticRelatedInformation{
+							{
+								Location: protocol.Location{
+									URI:   "file:///12d/proj/main.4dm",
+									Range: protocol.Range{Start: protocol.Position{Line: 5, Character: 0}, End: protocol.Position{Line: 5, Character: 4}},
+								},
+								Message: "\"done\" is first defined here.",
 							},
 						},
 					},
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 1, Character: 9}, End: protocol.Position{Line: 1, Character: 16}},
+						Severity: protocol.DiagnosticSeverityError,
+						Source:   "12d-lang-server",
+						Message:  "Label \"missing\" is not defined in function \"main\".",
+					},
+					newUnusedDiagnostic(
+						protocol.Position{Line: 2, Character: 0},
+						protocol.Position{Line: 2, Character: 5},
+						server.DiagnosticCodeUnusedLabel,
+						"Label \"retry\" is never used.",
+					),
 				),
 			},
 			{
-				Desc: "global param - definition reference request - include declaration",
-				SourceCode: `{
-    Integer ONE = 1;
-}
-
-Integer AddOne(Integer subject) {
-    return subject + ONE;
+				Desc: "switch - fall through, duplicate values, defaults and value types",
+				SourceCode: `#define RED 1
+void main() {
+    Integer a = 1;
+    switch (a) {
+        case RED:
+        case 2:
+            a++;
+            // Falls through.
+        case 1:
+            a++;
+        case "a":
+            a++;
+            break;
+        default:
+            break;
+        default:
+            break;
+    }
 }`,
-				Pos:                protocol.Position{Line: 1, Character: 12},
-				IncludeDeclaration: true,
-				Want: mustNewLocationsResponseMessage(
-					[]protocol.Location{
-						{
-							URI: "file:///12d/proj/main.4dm",
-							Range: protocol.Range{
-								Start: protocol.Position{Line: 1, Character: 12},
-								End:   protocol.Position{Line: 1, Character: 15},
+				Want: mustNewDiagnosticsResponseMessage(
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 8, Character: 13}, End: protocol.Position{Line: 8, Character: 14}},
+						Severity: protocol.DiagnosticSeverityError,
+						Source:   "12d-lang-server",
+						Message:  "Case value \"1\" is already used in this switch.",
+						RelatedInformation: []protocol.DiagnosticRelatedInformation{
+							{
+								Location: protocol.Location{
+									URI:   "file:///12d/proj/main.4dm",
+									Range: protocol.Range{Start: protocol.Position{Line: 4, Character: 13}, End: protocol.Position{Line: 4, Character: 16}},
+								},
+								Message: "\"RED\" is first used here.",
 							},
 						},
-						{
-							URI: "file:///12d/proj/main.4dm",
-							Range: protocol.Range{
-								Start: protocol.Position{Line: 5, Character: 21},
-								End:   protocol.Position{Line: 5, Character: 24},
+					},
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 8, Character: 8}, End: protocol.Position{Line: 8, Character: 12}},
+						Severity: protocol.DiagnosticSeverityWarning,
+						Source:   "12d-lang-server",
+						Message:  "Case falls through to the next case, add a \"// fall through\" comment if this is intended.",
+					},
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 10, Character: 13}, End: protocol.Position{Line: 10, Character: 16}},
+						Severity: protocol.DiagnosticSeverityError,
+						Source:   "12d-lang-server",
+						Message:  "Case value of type \"Text\" does not match switch type \"Integer\".",
+					},
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 15, Character: 8}, End: protocol.Position{Line: 15, Character: 15}},
+						Severity: protocol.DiagnosticSeverityError,
+						Source:   "12d-lang-server",
+						Message:  "Switch statement has more than one default case.",
+						RelatedInformation: []protocol.DiagnosticRelatedInformation{
+							{
+								Location: protocol.Location{
+									URI:   "file:///12d/proj/main.4dm",
+									Range: protocol.Range{Start: protocol.Position{Line: 13, Character: 8}, End: protocol.Position{Line: 13, Character: 15}},
+								},
+								Message: "\"default\" is first used here.",
 							},
 						},
 					},
 				),
 			},
 			{
-				Desc: "global param - no include declaration",
-				SourceCode: `{
-    Integer ONE = 1;
+				Desc: "constant - division by zero and overflow",
+				SourceCode: `#define MASK (1 << 31) | 1
+#define ZERO 0
+#define BIG 2147483647 + 1
+
+void Check(Integer a) {
+    Integer b = 10 / ZERO;
+    Integer c = a % 0;
+    Integer d = 65536 * 65536;
+    Integer e = MASK;
+    Print(To_text(b + c + d + e));
 }
 
-Integer AddOne(Integer subject) {
-    return subject + ONE;
-}`,
-				Pos:                protocol.Position{Line: 5, Character: 21},
-				IncludeDeclaration: false,
-				Want: mustNewLocationsResponseMessage(
-					[]protocol.Location{
-						{
-							URI: "file:///12d/proj/main.4dm",
-							Range: protocol.Range{
-								Start: protocol.Position{Line: 5, Character: 21},
-								End:   protocol.Position{Line: 5, Character: 24},
-							},
-						},
+void main() {
+    Check(1);
+}`,
+				Want: mustNewDiagnosticsResponseMessage(
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 2, Character: 12}, End: protocol.Position{Line: 2, Character: 26}},
+						Severity: protocol.DiagnosticSeverityWarning,
+						Source:   "12d-lang-server",
+						Message:  "Constant expression overflows \"Integer\", the result 2147483648 is out of range.",
+					},
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 5, Character: 16}, End: protocol.Position{Line: 5, Character: 25}},
+						Severity: protocol.DiagnosticSeverityWarning,
+						Source:   "12d-lang-server",
+						Message:  "Division by zero.",
+					},
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 6, Character: 16}, End: protocol.Position{Line: 6, Character: 21}},
+						Severity: protocol.DiagnosticSeverityWarning,
+						Source:   "12d-lang-server",
+						Message:  "Division by zero.",
+					},
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 7, Character: 16}, End: protocol.Position{Line: 7, Character: 29}},
+						Severity: protocol.DiagnosticSeverityWarning,
+						Source:   "12d-lang-server",
+						Message:  "Constant expression overflows \"Integer\", the result 4294967296 is out of range.",
 					},
 				),
 			},
 			{
-				Desc: "preproc def - include declaration",
-				SourceCode: `#define ONE 1
+				Desc: "constant - decimal literal does not fit in an Integer",
+				SourceCode: `#define MASK 037777777777
+#define BIG 3000000000
 
-Integer AddOne(Integer subject) {
-    return subject + ONE;
+void main() {
+    Integer a = 3000000000;
+    Print(To_text(a + MASK + BIG));
+}`,
+				Want: mustNewDiagnosticsResponseMessage(
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 1, Character: 12}, End: protocol.Position{Line: 1, Character: 22}},
+						Severity: protocol.DiagnosticSeverityWarning,
+						Source:   "12d-lang-server",
+						Message:  "Number 3000000000 does not fit in an \"Integer\".",
+					},
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 4, Character: 16}, End: protocol.Position{Line: 4, Character: 26}},
+						Severity: protocol.DiagnosticSeverityWarning,
+						Source:   "12d-lang-server",
+						Message:  "Number 3000000000 does not fit in an \"Integer\".",
+					},
+				),
+			},
+			{
+				Desc: "array - sizes, constant indices and loops starting at 0",
+				SourceCode: `#define SIZE 3
+void main() {
+    Integer values[10], others[SIZE], empty[SIZE - 3];
+    values[0] = 1;
+    values[10] = others[SIZE + 1];
+    for (Integer i = 0; i < 10; i++) {
+        values[i] = others[1] + empty[1];
+    }
 }`,
-				Pos:                protocol.Position{Line: 3, Character: 21},
-				IncludeDeclaration: true,
-				Want: mustNewLocationsResponseMessage(
-					[]protocol.Location{
-						{
-							URI: "file:///12d/proj/main.4dm",
-							Range: protocol.Range{
-								Start: protocol.Position{Line: 0, Character: 8},
-								End:   protocol.Position{Line: 0, Character: 11},
-							},
-						},
-						{
-							URI: "file:///12d/proj/main.4dm",
-							Range: protocol.Range{
-								Start: protocol.Position{Line: 3, Character: 21},
-								End:   protocol.Position{Line: 3, Character: 24},
-							},
-						},
+				Want: mustNewDiagnosticsResponseMessage(
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 2, Character: 44}, End: protocol.Position{Line: 2, Character: 52}},
+						Severity: protocol.DiagnosticSeverityError,
+						Source:   "12d-lang-server",
+						Message:  "Array \"empty\" must have a size of at least 1 but has a size of 0.",
+					},
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 3, Character: 11}, End: protocol.Position{Line: 3, Character: 12}},
+						Severity: protocol.DiagnosticSeverityError,
+						Source:   "12d-lang-server",
+						Message:  "Index 0 is out of bounds for array \"values\", arrays start at index 1.",
+					},
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 4, Character: 24}, End: protocol.Position{Line: 4, Character: 32}},
+						Severity: protocol.DiagnosticSeverityError,
+						Source:   "12d-lang-server",
+						Message:  "Index 4 is out of bounds for array \"others\" of size 3.",
+					},
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 6, Character: 15}, End: protocol.Position{Line: 6, Character: 16}},
+						Severity: protocol.DiagnosticSeverityWarning,
+						Source:   "12d-lang-server",
+						Message:  "Loop variable \"i\" starts at 0 and indexes array \"values\", arrays start at index 1.",
 					},
 				),
 			},
 			{
-				Desc: "preproc def - no include declaration",
-				SourceCode: `#define ONE 1
+				Desc: "widget - never appended, appended twice and appended after shown",
+				SourceCode: `void Add_box(Widget box, Vertical_Group group) {
+    Append(box, group);
+}
 
-Integer AddOne(Integer subject) {
-    return subject + ONE;
-}`,
-				Pos:                protocol.Position{Line: 3, Character: 21},
-				IncludeDeclaration: false,
-				Want: mustNewLocationsResponseMessage(
-					[]protocol.Location{
-						{
-							URI: "file:///12d/proj/main.4dm",
-							Range: protocol.Range{
-								Start: protocol.Position{Line: 3, Character: 21},
-								End:   protocol.Position{Line: 3, Character: 24},
+void main() {
+    Panel panel = Create_panel("Widgets");
+    Vertical_Group group = Create_vertical_group(0);
+    Message_Box message = Create_message_box("");
+    Input_Box name = Create_input_box("Name", message);
+    Input_Box unused = Create_input_box("Unused", message);
+    Set_data(unused, "unused");
+    Real_Box size = Create_real_box("Size", message);
+    Real_Box other = size;
+    Integer_Box count = Create_integer_box("Count", message);
+    Add_box(count, group);
+    Append(message, group);
+    Append(name, group);
+    Append(other, group);
+    Append(size, panel);
+    Append(group, panel);
+    Show_widget(panel);
+    Tick_Box late = Create_tick_box(message);
+    Append(late, group);
+    Integer id;
+    Text cmd, msg;
+    Wait_on_widgets(id, cmd, msg);
+}`,
+				Want: mustNewDiagnosticsResponseMessage(
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 18, Character: 4}, End: protocol.Position{Line: 18, Character: 23}},
+						Severity: protocol.DiagnosticSeverityWarning,
+						Source:   "12d-lang-server",
+						Message:  `Widget "size" is already appended to "group", a widget can only be appended to one container.`,
+						RelatedInformation: []protocol.DiagnosticRelatedInformation{
+							{
+								Location: protocol.Location{
+									URI:   "file:///12d/proj/main.4dm",
+									Range: protocol.Range{Start: protocol.Position{Line: 17, Character: 4}, End: protocol.Position{Line: 17, Character: 24}},
+								},
+								Message: `"size" is first appended here.`,
+							},
+						},
+					},
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 22, Character: 4}, End: protocol.Position{Line: 22, Character: 23}},
+						Severity: protocol.DiagnosticSeverityWarning,
+						Source:   "12d-lang-server",
+						Message:  `Widget "late" is appended after "panel" is shown, append the contents of a panel before showing it.`,
+						RelatedInformation: []protocol.DiagnosticRelatedInformation{
+							{
+								Location: protocol.Location{
+									URI:   "file:///12d/proj/main.4dm",
+									Range: protocol.Range{Start: protocol.Position{Line: 20, Character: 4}, End: protocol.Position{Line: 20, Character: 22}},
+								},
+								Message: `"panel" is shown here.`,
 							},
 						},
 					},
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 9, Character: 23}, End: protocol.Position{Line: 9, Character: 58}},
+						Severity: protocol.DiagnosticSeverityWarning,
+						Source:   "12d-lang-server",
+						Message:  `Widget "unused" is created but never appended to a group or panel.`,
+					},
 				),
 			},
 			{
-				Desc: "lib func",
+				Desc: "widget - panel appended inside a menu",
 				SourceCode: `void main() {
-    Print("hello");
-    Print("world");
+    Menu menu = Create_menu("Menu");
+    Panel panel = Create_panel("Panel");
+    Vertical_Group group = Create_vertical_group(0);
+    Append(panel, menu);
+    Show_widget(menu);
+    Append(group, panel);
+    Show_widget(panel);
 }`,
-				Pos:                protocol.Position{Line: 2, Character: 4},
-				IncludeDeclaration: false,
-				Want: mustNewLocationsResponseMessage(
-					[]protocol.Location{
-						{
-							URI: "file:///12d/proj/main.4dm",
-							Range: protocol.Range{
-								Start: protocol.Position{Line: 2, Character: 4},
-								End:   protocol.Position{Line: 2, Character: 9},
+				Want: mustNewDiagnosticResponseMessage(
+					protocol.Position{Line: 4, Character: 18},
+					protocol.Position{Line: 4, Character: 22},
+					protocol.DiagnosticSeverityError,
+					"No overload of \"Append\" matches the arguments, closest overloads:\n"+
+						"Integer Append(Widget widget, Panel panel): argument 2 of type \"Menu\" is not assignable to parameter \"Panel panel\".\n"+
+						"Integer Append(Widget widget, Horizontal_Group group): argument 2 of type \"Menu\" is not assignable to parameter \"Horizontal_Group group\".\n"+
+						"Integer Append(Widget widget, Vertical_Group group): argument 2 of type \"Menu\" is not assignable to parameter \"Vertical_Group group\".",
+				),
+			},
+			{
+				Desc: "widget - appended in different branches",
+				SourceCode: `void main() {
+    Panel panel = Create_panel("Widgets");
+    Vertical_Group vertical = Create_vertical_group(0);
+    Horizontal_Group horizontal = Create_horizontal_group(0);
+    Message_Box message = Create_message_box("");
+    Integer mode = 1;
+    if (mode == 1) {
+        Append(message, vertical);
+    } else {
+        Append(message, horizontal);
+    }
+    switch (mode) {
+    case 1:
+        Append(vertical, panel);
+        break;
+    default:
+        Append(vertical, horizontal);
+        break;
+    }
+    Append(horizontal, panel);
+    Append(message, panel);
+    Show_widget(panel);
+    Integer id;
+    Text cmd, msg;
+    Wait_on_widgets(id, cmd, msg);
+}`,
+				Want: mustNewDiagnosticsResponseMessage(
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 20, Character: 4}, End: protocol.Position{Line: 20, Character: 26}},
+						Severity: protocol.DiagnosticSeverityWarning,
+						Source:   "12d-lang-server",
+						Message:  `Widget "message" is already appended to "vertical", a widget can only be appended to one container.`,
+						RelatedInformation: []protocol.DiagnosticRelatedInformation{
+							{
+								Location: protocol.Location{
+									URI:   "file:///12d/proj/main.4dm",
+									Range: protocol.Range{Start: protocol.Position{Line: 7, Character: 8}, End: protocol.Position{Line: 7, Character: 33}},
+								},
+								Message: `"message" is first appended here.`,
 							},
 						},
-						{
-							URI: "file:///12d/proj/main.4dm",
-							Range: protocol.Range{
-								Start: protocol.Position{Line: 1, Character: 4},
-								End:   protocol.Position{Line: 1, Character: 9},
+					},
+				),
+			},
+			{
+				Desc: "entry point - main with parameters, a return type and defined twice",
+				SourceCode: `Integer main(Integer argc) {
+    return argc;
+}
+
+void main(Text name) {
+    Print(name);
+}`,
+				Want: mustNewDiagnosticsResponseMessage(
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 0, Character: 0}, End: protocol.Position{Line: 0, Character: 7}},
+						Severity: protocol.DiagnosticSeverityError,
+						Source:   "12d-lang-server",
+						Message:  `Function "main" must return "void" but returns "Integer".`,
+					},
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 0, Character: 12}, End: protocol.Position{Line: 0, Character: 26}},
+						Severity: protocol.DiagnosticSeverityError,
+						Source:   "12d-lang-server",
+						Message:  `Function "main" must not have parameters.`,
+					},
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 4, Character: 9}, End: protocol.Position{Line: 4, Character: 20}},
+						Severity: protocol.DiagnosticSeverityError,
+						Source:   "12d-lang-server",
+						Message:  `Function "main" must not have parameters.`,
+					},
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 4, Character: 5}, End: protocol.Position{Line: 4, Character: 9}},
+						Severity: protocol.DiagnosticSeverityError,
+						Source:   "12d-lang-server",
+						Message:  `Macro defines "main" more than once, a macro must define exactly one "main".`,
+						RelatedInformation: []protocol.DiagnosticRelatedInformation{
+							{
+								Location: protocol.Location{
+									URI:   "file:///12d/proj/main.4dm",
+									Range: protocol.Range{Start: protocol.Position{Line: 0, Character: 8}, End: protocol.Position{Line: 0, Character: 12}},
+								},
+								Message: `"main" is first defined here.`,
 							},
 						},
 					},
 				),
 			},
-			// TODO: references in include files.
+			{
+				Desc: "entry point - header defines main",
+				URI:  "file:///12d/proj/lib.h",
+				SourceCode: `void main() {
+    Print("main");
+}`,
+				Want: mustNewDiagnosticsResponseMessage(
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 0, Character: 5}, End: protocol.Position{Line: 0, Character: 9}},
+						Severity: protocol.DiagnosticSeverityWarning,
+						Source:   "12d-lang-server",
+						Message:  `Header defines "main", "main" should only be defined in a macro.`,
+					},
+				),
+			},
+			{
+				Desc: "status code - unchecked library calls",
+				SourceCode: `void main() {
+    Dynamic_Text items;
+    Integer count;
+    Append("a", items);
+    Get_number_of_items(items, count);
+    Print(count);
+    Text_length("a");
+    if (Append("b", items) != 0) {
+        return;
+    }
+    Integer ierr = Get_number_of_items(items, count);
+    Print(ierr);
+}`,
+				Settings: `{"diagnostics": {"uncheckedStatusCodes": true}}`,
+				Want: mustNewDiagnosticsResponseMessage(
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 3, Character: 4}, End: protocol.Position{Line: 3, Character: 22}},
+						Severity: protocol.DiagnosticSeverityWarning,
+						Source:   "12d-lang-server",
+						Message:  `Status code returned by "Append" is not checked, a return value of zero indicates success.`,
+						Code:     "unchecked-status-code",
+					},
+					protocol.Diagnostic{
+						Range:    protocol.Range{Start: protocol.Position{Line: 4, Character: 4}, End: protocol.Position{Line: 4, Character: 37}},
+						Severity: protocol.DiagnosticSeverityWarning,
+						Source:   "12d-lang-server",
+						Message:  `Status code returned by "Get_number_of_items" is not checked, a return value of zero indicates success.`,
+						Code:     "unchecked-status-code",
+					},
+				),
+			},
+			{
+				Desc: "status code - unchecked library calls are not reported by default",
+				SourceCode: `void main() {
+    Dynamic_Text items;
+    Integer count;
+    Append("a", items);
+    Get_number_of_items(items, count);
+    Print(count);
+    Text_length("a");
+    if (Append("b", items) != 0) {
+        return;
+    }
+    Integer ierr = Get_number_of_items(items, count);
+    Print(ierr);
+}`,
+				Want: mustNewEmptyDiagnosticResponseMessage(),
+			},
+			// TODO: parser is not throwing an error here.
+			// 			{
+			// 				Desc: "incomplete declaration - missing identifier",
+			// 				SourceCode: `void main() {
+			//     Integer = 1;
+			// }`,
+			//
+			// 				Want: mustNewDiagnosticResponseMessage(
+			// 					protocol.Position{Line: 1, Character: 12},
+			// 					protocol.Position{Line: 1, Character: 13},
+			// 					protocol.DiagnosticSeverityError,
+			// 					"Expected identifier.",
+			// 				),
+			// 			},
 		}
 		for _, testCase := range testCases {
 			t.Run(testCase.Desc, func(t *testing.T) {
@@ -2047,16 +4805,27 @@ Integer AddOne(Integer subject) {
 				assert := assert.New(t)
 				logger, err := newLogger()
 				assert.NoError(err)
-				in, out, cleanUp := startServer(testCase.IncludesDir, langCompletions, nil, logger)
+				in, out, cleanUp := startServer(testCase.IncludesDir, langCompletions, mockIncludesResolver, logger)
 				defer cleanUp()
 
 				var id int64 = 1
-				didOpenMsgBytes, err := newDidOpenRequestMessageBytes(id, "file:///12d/proj/main.4dm", testCase.SourceCode)
+				if testCase.Settings != "" {
+					configMsgBytes, err := newDidChangeConfigurationRequestMessageBytes(id, testCase.Settings)
+					assert.NoError(err)
+					_, err = in.Writer.Write([]byte(server.ToProtocolMessage(configMsgBytes)))
+					assert.NoError(err)
+				}
+
+				uri := testCase.URI
+				if uri == "" {
+					uri = "file:///12d/proj/main.4dm"
+				}
+				didOpenMsgBytes, err := newDidOpenRequestMessageBytes(id, uri, testCase.SourceCode)
 				assert.NoError(err)
 				_, err = in.Writer.Write([]byte(server.ToProtocolMessage(didOpenMsgBytes)))
 				assert.NoError(err)
 
-				reqMsgBytes, err := newReferencesRequestMessageBytes(id, "file:///12d/proj/main.4dm", testCase.Pos, testCase.IncludeDeclaration)
+				reqMsgBytes, err := newDiagnosticRequestMessageBytes(id, uri)
 				assert.NoError(err)
 				_, err = in.Writer.Write([]byte(server.ToProtocolMessage(reqMsgBytes)))
 				assert.NoError(err)
@@ -2068,108 +4837,203 @@ Integer AddOne(Integer subject) {
 		}
 	})
 
-	t.Run("textDocument/diagnostic", func(t *testing.T) {
-		mustNewDiagnosticResponseMessage := func(start, end protocol.Position, severity uint, errMsg string) protocol.ResponseMessage {
-			report := protocol.DocumentDiagnosticReport{
-				FullDocumentDiagnosticReport: protocol.FullDocumentDiagnosticReport{
-					Kind: protocol.DocumentDiagnosticReportKindFull,
-					Items: []protocol.Diagnostic{
-						{
-							Range:    protocol.Range{Start: start, End: end},
-							Severity: severity,
-							Source:   "12d-lang-server",
-							Message:  errMsg,
-						},
-					},
-				},
+	t.Run("textDocument/documentLink", func(t *testing.T) {
+		mustNewDocumentLinksResponseMessage := func(links []protocol.DocumentLink) protocol.ResponseMessage {
+			resultBytes, err := json.Marshal(links)
+			require.NoError(t, err)
+			return protocol.ResponseMessage{ID: 1, Result: json.RawMessage(resultBytes)}
+		}
+		newLink := func(target string, start, end protocol.Position) protocol.DocumentLink {
+			return protocol.DocumentLink{
+				Range:   protocol.Range{Start: start, End: end},
+				Target:  &target,
+				Tooltip: protocol.Filepath(target),
 			}
+		}
+		type TestCase struct {
+			Desc        string
+			SourceCode  string
+			IncludesDir string
+			Want        protocol.ResponseMessage
+		}
+		testCases := []TestCase{
+			{
+				Desc: "includes directory",
+				SourceCode: `#include "set_ups.h"
 
-			msg, err := newDiagnosticsResponseMessage(1, report)
-			require.NoError(t, err)
-			return msg
+void main() {}`,
+				IncludesDir: includesDir,
+				Want: mustNewDocumentLinksResponseMessage([]protocol.DocumentLink{
+					newLink("file:///12d/set_ups.h", protocol.Position{Line: 0, Character: 10}, protocol.Position{Line: 0, Character: 19}),
+				}),
+			},
+			{
+				Desc: "source file directory",
+				SourceCode: `#include "lib.h"
+
+void main() {}`,
+				IncludesDir: server.SourceFileDirToken,
+				Want: mustNewDocumentLinksResponseMessage([]protocol.DocumentLink{
+					newLink("file:///12d/proj/lib.h", protocol.Position{Line: 0, Character: 10}, protocol.Position{Line: 0, Character: 15}),
+				}),
+			},
+			{
+				Desc: "unresolved include has no link",
+				SourceCode: `#include "missing.h"
+#include "set_ups.h"
+
+void main() {}`,
+				IncludesDir: includesDir,
+				Want: mustNewDocumentLinksResponseMessage([]protocol.DocumentLink{
+					newLink("file:///12d/set_ups.h", protocol.Position{Line: 1, Character: 10}, protocol.Position{Line: 1, Character: 19}),
+				}),
+			},
+			{
+				Desc:        "no includes",
+				SourceCode:  `void main() {}`,
+				IncludesDir: includesDir,
+				Want:        mustNewDocumentLinksResponseMessage([]protocol.DocumentLink{}),
+			},
+		}
+		for _, testCase := range testCases {
+			t.Run(testCase.Desc, func(t *testing.T) {
+				defer goleak.VerifyNone(t)
+				assert := assert.New(t)
+				logger, err := newLogger()
+				assert.NoError(err)
+				in, out, cleanUp := startServer(testCase.IncludesDir, langCompletions, mockIncludesResolver, logger)
+				defer cleanUp()
+
+				var id int64 = 1
+				didOpenMsgBytes, err := newDidOpenRequestMessageBytes(id, "file:///12d/proj/main.4dm", testCase.SourceCode)
+				assert.NoError(err)
+				_, err = in.Writer.Write([]byte(server.ToProtocolMessage(didOpenMsgBytes)))
+				assert.NoError(err)
+
+				reqMsgBytes, err := newDocumentLinkRequestMessageBytes(id, "file:///12d/proj/main.4dm")
+				assert.NoError(err)
+				_, err = in.Writer.Write([]byte(server.ToProtocolMessage(reqMsgBytes)))
+				assert.NoError(err)
+
+				got, err := getReponseMessage(out.Reader)
+				assert.NoError(err)
+				assertResponseMessageEqual(t, testCase.Want, got)
+			})
 		}
+	})
 
+	t.Run("textDocument/codeAction", func(t *testing.T) {
+		mustNewCodeActionsResponseMessage := func(codeActions []protocol.CodeAction) protocol.ResponseMessage {
+			resultBytes, err := json.Marshal(codeActions)
+			require.NoError(t, err)
+			return protocol.ResponseMessage{ID: 1, Result: json.RawMessage(resultBytes)}
+		}
+		newOrganizeIncludesCodeAction := func(edits []protocol.TextEdit) protocol.CodeAction {
+			return protocol.CodeAction{
+				Title: "Organize includes",
+				Kind:  protocol.CodeActionKindSourceOrganizeImports,
+				Edit: &protocol.WorkspaceEdit{
+					Changes: map[string][]protocol.TextEdit{"file:///12d/proj/main.4dm": edits},
+				},
+			}
+		}
 		type TestCase struct {
 			Desc       string
 			SourceCode string
-			Report     protocol.DocumentDiagnosticReport
+			Only       []string
 			Want       protocol.ResponseMessage
 		}
 		testCases := []TestCase{
 			{
-				Desc: "incomplete declaration - missing semi colon",
-				SourceCode: `void main() {
-    Integer a = 1
-}`,
+				Desc: "organize includes - sort and remove unused includes",
+				SourceCode: `#include "strings.h"
+#include "ask.h"
+#include "set_ups.h"
+#include "missing.h"
+#include "ask.h"
 
-				Want: mustNewDiagnosticResponseMessage(
-					protocol.Position{Line: 1, Character: 17},
-					protocol.Position{Line: 1, Character: 17},
-					protocol.DiagnosticSeverityError,
-					"Expected \";\".",
-				),
+void main() {
+    Ask();
+}`,
+				Want: mustNewCodeActionsResponseMessage([]protocol.CodeAction{
+					newOrganizeIncludesCodeAction([]protocol.TextEdit{
+						{
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 0, Character: 0},
+								End:   protocol.Position{Line: 4, Character: 16},
+							},
+							NewText: "#include \"missing.h\"\n#include \"set_ups.h\"\n#include \"ask.h\"",
+						},
+					}),
+				}),
 			},
 			{
-				Desc: "incomplete declaration - multi-declaration missing semi colon",
-				SourceCode: `void main() {
-    Integer a = 1, b = 2
-}`,
+				Desc: "organize includes - defines stay before the includes after them",
+				SourceCode: `#include "set_ups.h"
+#define SCALE 2
+#include "ask.h"
+#include "set_ups.h"
 
-				Want: mustNewDiagnosticResponseMessage(
-					protocol.Position{Line: 1, Character: 24},
-					protocol.Position{Line: 1, Character: 24},
-					protocol.DiagnosticSeverityError,
-					"Expected \";\".",
-				),
+void main() {
+    Ask();
+    Integer a = TRUE * SCALE;
+}`,
+				Want: mustNewCodeActionsResponseMessage([]protocol.CodeAction{
+					newOrganizeIncludesCodeAction([]protocol.TextEdit{
+						{
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 0, Character: 0},
+								End:   protocol.Position{Line: 3, Character: 20},
+							},
+							NewText: "#include \"set_ups.h\"\n#define SCALE 2\n#include \"ask.h\"",
+						},
+					}),
+				}),
 			},
 			{
-				Desc: "incomplete declaration - missing expr",
-				SourceCode: `void main() {
-    Integer a = ;
-}`,
+				Desc: "organize includes - requested by source kind",
+				SourceCode: `#include "set_ups.h"
+#include "set_ups.h"
 
-				Want: mustNewDiagnosticResponseMessage(
-					protocol.Position{Line: 1, Character: 16},
-					protocol.Position{Line: 1, Character: 17},
-					protocol.DiagnosticSeverityError,
-					"Expected expression.",
-				),
+void main() {
+    Integer a = TRUE;
+}`,
+				Only: []string{protocol.CodeActionKindSource},
+				Want: mustNewCodeActionsResponseMessage([]protocol.CodeAction{
+					newOrganizeIncludesCodeAction([]protocol.TextEdit{
+						{
+							Range: protocol.Range{
+								Start: protocol.Position{Line: 0, Character: 0},
+								End:   protocol.Position{Line: 1, Character: 20},
+							},
+							NewText: "#include \"set_ups.h\"",
+						},
+					}),
+				}),
 			},
 			{
-				Desc: "undeclared var",
-				SourceCode: `void main() {
-    Integer a = b;
+				Desc: "organize includes - organized includes have no code actions",
+				SourceCode: `#include "set_ups.h"
+
+void main() {
+    Integer a = TRUE;
 }`,
+				Want: mustNewCodeActionsResponseMessage([]protocol.CodeAction{}),
+			},
+			{
+				Desc: "organize includes - other kinds requested",
+				SourceCode: `#include "strings.h"
 
-				Want: mustNewDiagnosticResponseMessage(
-					protocol.Position{Line: 1, Character: 16},
-					protocol.Position{Line: 1, Character: 17},
-					protocol.DiagnosticSeverityError,
-					"Identifier \"b\" is undefined.",
-				),
+void main() {}`,
+				Only: []string{"quickfix"},
+				Want: mustNewCodeActionsResponseMessage([]protocol.CodeAction{}),
 			},
-			// TODO: parser is not throwing an error here.
-			// 			{
-			// 				Desc: "incomplete declaration - missing identifier",
-			// 				SourceCode: `void main() {
-			//     Integer = 1;
-			// }`,
-			//
-			// 				Want: mustNewDiagnosticResponseMessage(
-			// 					protocol.Position{Line: 1, Character: 12},
-			// 					protocol.Position{Line: 1, Character: 13},
-			// 					protocol.DiagnosticSeverityError,
-			// 					"Expected identifier.",
-			// 				),
-			// 			},
 		}
 		for _, testCase := range testCases {
 			t.Run(testCase.Desc, func(t *testing.T) {
 				defer goleak.VerifyNone(t)
 				assert := assert.New(t)
-				logger, err := newLogger()
-				assert.NoError(err)
-				in, out, cleanUp := startServer("", langCompletions, nil, logger)
+				in, out, cleanUp := startServer(includesDir, langCompletions, mockIncludesResolver, nil)
 				defer cleanUp()
 
 				var id int64 = 1
@@ -2178,7 +5042,7 @@ Integer AddOne(Integer subject) {
 				_, err = in.Writer.Write([]byte(server.ToProtocolMessage(didOpenMsgBytes)))
 				assert.NoError(err)
 
-				reqMsgBytes, err := newDiagnosticRequestMessageBytes(id, "file:///12d/proj/main.4dm")
+				reqMsgBytes, err := newCodeActionRequestMessageBytes(id, "file:///12d/proj/main.4dm", testCase.Only)
 				assert.NoError(err)
 				_, err = in.Writer.Write([]byte(server.ToProtocolMessage(reqMsgBytes)))
 				assert.NoError(err)
@@ -3011,6 +5875,70 @@ void main() {
 					IncludesDir: includesDir,
 					Pattern:     []string{"```12dpl\n#define TRUE  1\n```"},
 				},
+				{
+					Desc: "preproc declaration - constant expression value",
+					SourceCode: `#define SHIFT 4
+#define FLAG 1 << 31 | SHIFT << 16 /* flag */
+
+void main() {
+    Exit(FLAG);
+}`,
+					Position: protocol.Position{Line: 4, Character: 10},
+					Pattern:  []string{"```12dpl\n#define FLAG 1 << 31 | SHIFT << 16 /* flag */\n```\n---\nValue: `-2147221504`"},
+				},
+				{
+					Desc: "preproc declaration - constant expression value ending in a literal",
+					SourceCode: `#define SIZE 4
+#define HALF SIZE / 2
+
+void main() {
+    Exit(HALF);
+}`,
+					Position: protocol.Position{Line: 4, Character: 10},
+					Pattern:  []string{"```12dpl\n#define HALF SIZE / 2\n```\n---\nValue: `2`"},
+				},
+				{
+					Desc: "preproc declaration - constant expression value starting with parentheses",
+					SourceCode: `#define FLAG (1 << 31) | (3 << 16)
+#define SCALE 4
+
+void main() {
+    Exit(FLAG * SCALE);
+}`,
+					Position: protocol.Position{Line: 4, Character: 10},
+					Pattern:  []string{"```12dpl\n#define FLAG (1 << 31) | (3 << 16)\n```\n---\nValue: `-2147287040`"},
+				},
+				{
+					Desc: "preproc declaration - after a value starting with parentheses",
+					SourceCode: `#define FLAG (1 << 31) | (3 << 16)
+#define SCALE 4
+
+void main() {
+    Exit(FLAG * SCALE);
+}`,
+					Position: protocol.Position{Line: 4, Character: 18},
+					Pattern:  []string{"```12dpl\n#define SCALE 4\n```"},
+				},
+				{
+					Desc: "preproc declaration - parenthesized value continued on the next line",
+					SourceCode: `#define AREA (2 + 1) \
+    * 3
+#define SCALE AREA * 2
+
+void main() {
+    Exit(SCALE);
+}`,
+					Position: protocol.Position{Line: 5, Character: 10},
+					Pattern:  []string{"```12dpl\n#define SCALE AREA * 2\n```\n---\nValue: `18`"},
+				},
+				{
+					Desc: "constant expression",
+					SourceCode: `void main() {
+    Exit(2 * 8 + 1);
+}`,
+					Position: protocol.Position{Line: 1, Character: 11},
+					Pattern:  []string{"```12dpl\n(constant) Integer 17\n```"},
+				},
 			}
 			testCases = append(testCases, doxygen...)
 			testCases = append(testCases, doxygenJavadoc...)
@@ -3096,6 +6024,34 @@ func newDefinitionRequestMessageBytes(id int64, uri string, position protocol.Po
 	return definitionMsgBytes, nil
 }
 
+// Creates a new protocol request message with type definition params and
+// returns the wire representation.
+func newTypeDefinitionRequestMessageBytes(id int64, uri string, position protocol.Position) ([]byte, error) {
+	params := protocol.TypeDefinitionParams{
+		TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+			TextDocument: protocol.TextDocumentIdentifier{
+				URI: uri,
+			},
+			Position: position,
+		},
+	}
+	paramsBytes, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	msg := protocol.RequestMessage{
+		JSONRPC: "2.0",
+		ID:      id,
+		Method:  "textDocument/typeDefinition",
+		Params:  json.RawMessage(paramsBytes),
+	}
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return msgBytes, nil
+}
+
 // Creates a new protocol request message with references params and returns the
 // wire representation.
 func newReferencesRequestMessageBytes(id int64, uri string, position protocol.Position, includeDeclaration bool) ([]byte, error) {
@@ -3152,6 +6108,58 @@ func newDiagnosticRequestMessageBytes(id int64, uri string) ([]byte, error) {
 	return msgBytes, nil
 }
 
+// Creates a new protocol request message with code action params and returns
+// the wire representation.
+func newCodeActionRequestMessageBytes(id int64, uri string, only []string) ([]byte, error) {
+	params := protocol.CodeActionParams{
+		TextDocument: protocol.TextDocumentIdentifier{
+			URI: uri,
+		},
+		Context: protocol.CodeActionContext{
+			Diagnostics: []protocol.Diagnostic{},
+			Only:        only,
+		},
+	}
+	paramsBytes, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	msg := protocol.RequestMessage{
+		JSONRPC: "2.0",
+		ID:      id,
+		Method:  "textDocument/codeAction",
+		Params:  json.RawMessage(paramsBytes),
+	}
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return msgBytes, nil
+}
+
+func newDocumentLinkRequestMessageBytes(id int64, uri string) ([]byte, error) {
+	params := protocol.DocumentLinkParams{
+		TextDocument: protocol.TextDocumentIdentifier{
+			URI: uri,
+		},
+	}
+	paramsBytes, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	msg := protocol.RequestMessage{
+		JSONRPC: "2.0",
+		ID:      id,
+		Method:  "textDocument/documentLink",
+		Params:  json.RawMessage(paramsBytes),
+	}
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return msgBytes, nil
+}
+
 // Creates a new protocol request message with rename params and returns the
 // wire representation.
 func newRenameRequestMessageBytes(id int64, uri, newText string, position protocol.Position) ([]byte, error) {
@@ -3211,11 +6219,38 @@ func newCompletionRequestMessageBytes(id int64, uri string, position protocol.Po
 
 // Creates a new protocol request message with formatting params and returns the
 // wire representation.
-func newFormattingRequestMessageBytes(id int64, uri string) ([]byte, error) {
+func newFormattingRequestMessageBytes(id int64, uri string, options protocol.FormattingOptions) ([]byte, error) {
 	params := protocol.DocumentFormattingParams{
 		TextDocument: protocol.TextDocumentIdentifier{
 			URI: uri,
 		},
+		Options: options,
+	}
+	paramsBytes, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	msg := protocol.RequestMessage{
+		JSONRPC: "2.0",
+		ID:      id,
+		Method:  "textDocument/formatting",
+		Params:  json.RawMessage(paramsBytes),
+	}
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return msgBytes, nil
+}
+
+// Creates a new protocol request message with range formatting params and
+// returns the wire representation.
+func newRangeFormattingRequestMessageBytes(id int64, uri string, textRange protocol.Range) ([]byte, error) {
+	params := protocol.DocumentRangeFormattingParams{
+		TextDocument: protocol.TextDocumentIdentifier{
+			URI: uri,
+		},
+		Range: textRange,
 		Options: protocol.FormattingOptions{
 			TabSize:      4,
 			InsertSpaces: true,
@@ -3228,7 +6263,38 @@ func newFormattingRequestMessageBytes(id int64, uri string) ([]byte, error) {
 	msg := protocol.RequestMessage{
 		JSONRPC: "2.0",
 		ID:      id,
-		Method:  "textDocument/formatting",
+		Method:  "textDocument/rangeFormatting",
+		Params:  json.RawMessage(paramsBytes),
+	}
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return msgBytes, nil
+}
+
+// Creates a new protocol request message with on type formatting params and
+// returns the wire representation.
+func newOnTypeFormattingRequestMessageBytes(id int64, uri string, position protocol.Position, ch string) ([]byte, error) {
+	params := protocol.DocumentOnTypeFormattingParams{
+		TextDocument: protocol.TextDocumentIdentifier{
+			URI: uri,
+		},
+		Position: position,
+		Ch:       ch,
+		Options: protocol.FormattingOptions{
+			TabSize:      4,
+			InsertSpaces: true,
+		},
+	}
+	paramsBytes, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	msg := protocol.RequestMessage{
+		JSONRPC: "2.0",
+		ID:      id,
+		Method:  "textDocument/onTypeFormatting",
 		Params:  json.RawMessage(paramsBytes),
 	}
 	msgBytes, err := json.Marshal(msg)
@@ -3264,6 +6330,40 @@ func newHoverRequestMessageBytes(id int64, uri string, position protocol.Positio
 	return hoverMsgBytes, nil
 }
 
+func newInitializeRequestMessageBytes(id int64, initializationOptions string) ([]byte, error) {
+	params := protocol.InitializeParams{
+		InitializationOptions: json.RawMessage(initializationOptions),
+	}
+	paramsBytes, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	msg := protocol.RequestMessage{
+		JSONRPC: "2.0",
+		ID:      id,
+		Method:  "initialize",
+		Params:  json.RawMessage(paramsBytes),
+	}
+	return json.Marshal(msg)
+}
+
+func newDidChangeConfigurationRequestMessageBytes(id int64, settings string) ([]byte, error) {
+	params := protocol.DidChangeConfigurationParams{
+		Settings: json.RawMessage(settings),
+	}
+	paramsBytes, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	msg := protocol.RequestMessage{
+		JSONRPC: "2.0",
+		ID:      id,
+		Method:  "workspace/didChangeConfiguration",
+		Params:  json.RawMessage(paramsBytes),
+	}
+	return json.Marshal(msg)
+}
+
 func newDidOpenRequestMessageBytes(id int64, uri, text string) ([]byte, error) {
 	didOpenParams := protocol.DidOpenTextDocumentParams{
 		TextDocument: protocol.TextDocumentItem{
@@ -3406,6 +6506,12 @@ func newLogger() (func(msg string), error) {
 // pipe and a clean up function.
 func startServer(includesDir string, langCompletions *server.LangCompletions, includesResolver server.IncludesResolver, logger func(msg string)) (Pipe, Pipe, func()) {
 	serv := server.NewServer(includesDir, langCompletions, includesResolver, true, logger)
+	return serveServer(&serv, logger)
+}
+
+// Serves the server over pipes, returns the pipe to write requests to, the
+// pipe to read responses from and a function to close the pipes.
+func serveServer(serv *server.Server, logger func(msg string)) (Pipe, Pipe, func()) {
 	inReader, inWriter := io.Pipe()
 	outReader, outWriter := io.Pipe()
 	go (func() {
@@ -3467,7 +6573,11 @@ func newMockIncludesResolver() MockIncludesResolver {
 type MockIncludesResolver struct{}
 
 func (rs MockIncludesResolver) Exists(path string) bool {
-	return path == filepath.Join("/12d", "set_ups.h") || path == filepath.Join("/12d/proj", "lib.h")
+	switch path {
+	case filepath.Join("/12d", "set_ups.h"), filepath.Join("/12d/proj", "lib.h"), filepath.Join("/12d", "strings.h"), filepath.Join("/12d", "ask.h"):
+		return true
+	}
+	return false
 }
 
 func (rs MockIncludesResolver) Read(name string) ([]byte, error) {
@@ -3478,6 +6588,13 @@ func (rs MockIncludesResolver) Read(name string) ([]byte, error) {
 	if name == filepath.Join("/12d/proj", "lib.h") {
 		return []byte(`#define WORLD "world"`), nil
 	}
+	if name == filepath.Join("/12d", "strings.h") {
+		return []byte(`Text Trim(Text value) { return value; }`), nil
+	}
+	// Depends on "set_ups.h" without including it.
+	if name == filepath.Join("/12d", "ask.h") {
+		return []byte(`Integer Ask() { return TRUE; }`), nil
+	}
 
 	return nil, errors.New("file does not exist")
 }
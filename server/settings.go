@@ -0,0 +1,94 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/kelly-lin/12d-lang-server/format"
+	"github.com/kelly-lin/12d-lang-server/protocol"
+)
+
+// Settings provided by the client through the initialization options and
+// configuration changes, for example:
+//
+//	{"formatting": {"style": "allman"}}
+type Settings struct {
+	Formatting  FormattingSettings  `json:"formatting"`
+	Diagnostics DiagnosticsSettings `json:"diagnostics"`
+}
+
+// Settings of the opt-in diagnostics.
+type DiagnosticsSettings struct {
+	// Warn when the status code returned by a library function call is not
+	// checked, the call is a statement on its own.
+	UncheckedStatusCodes bool `json:"uncheckedStatusCodes"`
+}
+
+type FormattingSettings struct {
+	// Name of the style profile, "k&r", "allman" or "12d-stock". When empty,
+	// brace placement and spacing are kept as they were written.
+	Style string `json:"style"`
+	// Maximum width of a line, argument and parameter lists which do not fit
+	// are broken one item per line. Zero disables wrapping.
+	MaxLineWidth int `json:"maxLineWidth"`
+	// Align the identifiers, assignment operators and trailing comments of
+	// consecutive declarations and assignments.
+	AlignConsecutive bool `json:"alignConsecutive"`
+	// Line ending which all line endings are converted to, "lf" or "crlf".
+	// When empty, line endings are kept as they were written.
+	LineEnding string `json:"lineEnding"`
+	// Sort consecutive includes, remove duplicate and unused includes and group
+	// the defines after them when formatting. Defines before an include are
+	// kept in place.
+	OrganizeIncludes bool `json:"organizeIncludes"`
+}
+
+// Line endings of the line ending setting.
+var lineEndings = map[string]string{
+	"lf":   format.LineEndingLF,
+	"crlf": format.LineEndingCRLF,
+}
+
+// Sets the settings from the JSON provided by the client. Settings which are
+// invalid are ignored.
+func (s *Server) setSettings(settingsJSON json.RawMessage) error {
+	if len(settingsJSON) == 0 || string(settingsJSON) == "null" {
+		return nil
+	}
+	var settings Settings
+	if err := json.Unmarshal(settingsJSON, &settings); err != nil {
+		return err
+	}
+	if settings.Formatting.Style != "" {
+		if _, ok := format.GetStyle(settings.Formatting.Style); !ok {
+			s.logger(fmt.Sprintf("[ERROR] unknown formatting style %s\n", settings.Formatting.Style))
+			settings.Formatting.Style = ""
+		}
+	}
+	if settings.Formatting.LineEnding != "" {
+		if _, ok := lineEndings[settings.Formatting.LineEnding]; !ok {
+			s.logger(fmt.Sprintf("[ERROR] unknown line ending %s\n", settings.Formatting.LineEnding))
+			settings.Formatting.LineEnding = ""
+		}
+	}
+	s.settings = settings
+	return nil
+}
+
+// Get the formatting options for the formatting options of a request on the
+// document described by uri with the style profile, wrapping, alignment, line
+// ending and include organization from the settings.
+func (s *Server) getFormattingOptions(uri string, options protocol.FormattingOptions) format.Options {
+	result := format.NewOptions(options)
+	if style, ok := format.GetStyle(s.settings.Formatting.Style); ok {
+		result.Style = style
+	}
+	result.MaxLineWidth = s.settings.Formatting.MaxLineWidth
+	result.AlignConsecutive = s.settings.Formatting.AlignConsecutive
+	result.LineEnding = lineEndings[s.settings.Formatting.LineEnding]
+	if s.settings.Formatting.OrganizeIncludes {
+		result.OrganizeIncludes = true
+		result.IncludeInfo = s.getIncludeInfo(uri)
+	}
+	return result
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/kelly-lin/12d-lang-server/lang"
+	"github.com/kelly-lin/12d-lang-server/protocol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Code of the diagnostic of a status code which is not checked.
+const DiagnosticCodeUncheckedStatusCode = "unchecked-status-code"
+
+// Get the warnings for the calls of library functions which return a status
+// code where the call is a statement on its own and the status code is
+// ignored. The diagnostics are opt-in through the settings.
+func (s *Server) getStatusCodeDiagnostics(uri string) []protocol.Diagnostic {
+	result := []protocol.Diagnostic{}
+	doc, ok := s.documents[uri]
+	if !ok || !s.settings.Diagnostics.UncheckedStatusCodes {
+		return result
+	}
+	checker := typeChecker{uri: uri, sourceCode: doc.SourceCode, documents: s.documents, includesDir: s.includesDir}
+	var visit func(node *sitter.Node)
+	visit = func(node *sitter.Node) {
+		if node.Type() == "expression_statement" && !node.HasError() {
+			if callNode := node.NamedChild(0); callNode != nil && callNode.Type() == "call_expression" {
+				if diagnostic, ok := checker.checkStatusCode(callNode); ok {
+					result = append(result, diagnostic)
+				}
+			}
+			return
+		}
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			visit(node.NamedChild(i))
+		}
+	}
+	visit(doc.RootNode)
+	return result
+}
+
+// Returns a diagnostic if the call node calls a library function overload
+// which returns a status code. When the types of the arguments match more than
+// one overload, all of them must return a status code.
+func (c typeChecker) checkStatusCode(callNode *sitter.Node) (protocol.Diagnostic, bool) {
+	funcNode := callNode.ChildByFieldName("function")
+	argsNode := callNode.ChildByFieldName("arguments")
+	if funcNode == nil || argsNode == nil || funcNode.Type() != "identifier" {
+		return protocol.Diagnostic{}, false
+	}
+	identifier := funcNode.Content(c.sourceCode)
+	if _, ok := lang.LibStatusCodes[identifier]; !ok {
+		return protocol.Diagnostic{}, false
+	}
+	// User defined functions shadow the library functions.
+	if _, err := findDefinition(funcNode, identifier, c.uri, c.documents, c.includesDir); err == nil {
+		return protocol.Diagnostic{}, false
+	}
+	signatures, err := lang.GetLibSignatures(identifier)
+	if err != nil {
+		return protocol.Diagnostic{}, false
+	}
+	argTypes := c.getArgTypes(argsNode)
+	isMatched := false
+	for _, signature := range signatures {
+		if !matchOverload(signature, argTypes).isMatch() {
+			continue
+		}
+		if !signature.ReturnsStatusCode {
+			return protocol.Diagnostic{}, false
+		}
+		isMatched = true
+	}
+	if !isMatched {
+		return protocol.Diagnostic{}, false
+	}
+	return protocol.Diagnostic{
+		Range:    getNodeRange(callNode),
+		Severity: protocol.DiagnosticSeverityWarning,
+		Source:   SourceName,
+		Message:  fmt.Sprintf(`Status code returned by "%s" is not checked, a return value of zero indicates success.`, identifier),
+		Code:     DiagnosticCodeUncheckedStatusCode,
+	}, true
+}
@@ -0,0 +1,149 @@
+package server
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kelly-lin/12d-lang-server/protocol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Get the diagnostics of the switch statements of the document, cases which
+// fall through to the next case without a comment saying so, case values used
+// more than once after evaluating them, more than one default case and case
+// values whose type does not match the type of the switch expression.
+func (s *Server) getSwitchDiagnostics(uri string) []protocol.Diagnostic {
+	result := []protocol.Diagnostic{}
+	doc, ok := s.documents[uri]
+	if !ok {
+		return result
+	}
+	checker := typeChecker{uri: uri, sourceCode: doc.SourceCode, documents: s.documents, includesDir: s.includesDir}
+	var visit func(node *sitter.Node)
+	visit = func(node *sitter.Node) {
+		if node.Type() == "switch_statement" && !node.HasError() {
+			result = append(result, checker.checkSwitch(node)...)
+		}
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			visit(node.NamedChild(i))
+		}
+	}
+	visit(doc.RootNode)
+	return result
+}
+
+// Check the cases of the switch statement node.
+func (c typeChecker) checkSwitch(switchNode *sitter.Node) []protocol.Diagnostic {
+	result := []protocol.Diagnostic{}
+	bodyNode := switchNode.ChildByFieldName("body")
+	conditionNode := switchNode.ChildByFieldName("condition")
+	if bodyNode == nil || conditionNode == nil {
+		return result
+	}
+	switchType := c.getType(conditionNode)
+	evaluator := newConstEvaluator(c.uri, c.documents, c.includesDir)
+	var defaultNode *sitter.Node
+	valueNodes := map[string]*sitter.Node{}
+	for i := 0; i < int(bodyNode.NamedChildCount()); i++ {
+		caseNode := bodyNode.NamedChild(i)
+		if caseNode.Type() != "case_statement" {
+			continue
+		}
+		// The "case" or "default" keyword.
+		keywordNode := caseNode.Child(0)
+
+		valueNode := caseNode.ChildByFieldName("value")
+		if valueNode == nil {
+			if defaultNode != nil {
+				result = append(result, newDuplicateDiagnostic(
+					keywordNode,
+					protocol.DiagnosticSeverityError,
+					"Switch statement has more than one default case.",
+					c.uri,
+					defaultNode,
+					`"default" is first used here.`,
+				))
+			} else {
+				defaultNode = keywordNode
+			}
+		} else {
+			valueType := c.getType(valueNode)
+			if !isAssignable(valueType, switchType) {
+				result = append(result, protocol.Diagnostic{
+					Range:    getNodeRange(valueNode),
+					Severity: protocol.DiagnosticSeverityError,
+					Source:   SourceName,
+					Message:  fmt.Sprintf(`Case value of type "%s" does not match switch type "%s".`, valueType, switchType),
+				})
+			}
+			if value, err := evaluator.eval(valueNode); err == nil {
+				// Integer and real cases with the same value are the same.
+				key := value.String()
+				if value.exprType.isNumeric() {
+					key = strconv.FormatFloat(value.toReal(), 'g', -1, 64)
+				}
+				if prevNode, ok := valueNodes[key]; ok {
+					result = append(result, newDuplicateDiagnostic(
+						valueNode,
+						protocol.DiagnosticSeverityError,
+						fmt.Sprintf(`Case value "%s" is already used in this switch.`, valueNode.Content(c.sourceCode)),
+						c.uri,
+						prevNode,
+						fmt.Sprintf(`"%s" is first used here.`, prevNode.Content(c.sourceCode)),
+					))
+				} else {
+					valueNodes[key] = valueNode
+				}
+			}
+		}
+
+		// Cases without statements share the statements of the next case.
+		nextCaseNode := getNextCaseNode(bodyNode, i)
+		statementNodes := getCaseStatementNodes(caseNode)
+		if nextCaseNode == nil || len(statementNodes) == 0 || !canStatementsCompleteNormally(statementNodes, c.sourceCode) {
+			continue
+		}
+		if !hasFallThroughComment(caseNode, nextCaseNode, c.sourceCode) {
+			result = append(result, protocol.Diagnostic{
+				Range:    getNodeRange(keywordNode),
+				Severity: protocol.DiagnosticSeverityWarning,
+				Source:   SourceName,
+				Message:  `Case falls through to the next case, add a "// fall through" comment if this is intended.`,
+			})
+		}
+	}
+	return result
+}
+
+// Get the case statement node after the case statement at the index of the
+// switch body node, returns nil if it is the last case.
+func getNextCaseNode(bodyNode *sitter.Node, idx int) *sitter.Node {
+	for i := idx + 1; i < int(bodyNode.NamedChildCount()); i++ {
+		if caseNode := bodyNode.NamedChild(i); caseNode.Type() == "case_statement" {
+			return caseNode
+		}
+	}
+	return nil
+}
+
+// Returns true if there is a comment such as "// fall through" or
+// "// fallthrough" at the end of the case, the parser puts comments at the end
+// of a case either inside of the case or between the cases.
+func hasFallThroughComment(caseNode, nextCaseNode *sitter.Node, sourceCode []byte) bool {
+	commentNodes := []*sitter.Node{}
+	for i := int(caseNode.NamedChildCount()) - 1; i >= 0 && caseNode.NamedChild(i).Type() == "comment"; i-- {
+		commentNodes = append(commentNodes, caseNode.NamedChild(i))
+	}
+	for node := nextCaseNode.PrevNamedSibling(); node != nil && node.Type() == "comment"; node = node.PrevNamedSibling() {
+		commentNodes = append(commentNodes, node)
+	}
+	for _, commentNode := range commentNodes {
+		comment := strings.ToLower(commentNode.Content(sourceCode))
+		comment = strings.NewReplacer(" ", "", "-", "", "_", "").Replace(comment)
+		if strings.Contains(comment, "fallthrough") || strings.Contains(comment, "fallsthrough") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,434 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kelly-lin/12d-lang-server/lang"
+	"github.com/kelly-lin/12d-lang-server/protocol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// The type of an expression. The zero value is an unknown type, expressions
+// of an unknown type are never reported since we cannot be sure they are
+// wrong, for example the type of a call to a function which is not defined or
+// a variable declared with a type aliased through a "#define".
+type exprType struct {
+	name    string
+	isArray bool
+}
+
+func (t exprType) isUnknown() bool {
+	return t.name == ""
+}
+
+func (t exprType) isNumeric() bool {
+	return !t.isArray && (t.name == "Integer" || t.name == "Real")
+}
+
+func (t exprType) isText() bool {
+	return !t.isArray && t.name == "Text"
+}
+
+// Returns true if the type is one of the types which the 12d operators are
+// defined for. Operators applied to other types, such as the vector types,
+// are not checked.
+func (t exprType) isPrimitive() bool {
+	return t.isNumeric() || t.isText() || t.isArray
+}
+
+func (t exprType) String() string {
+	if t.isArray {
+		return t.name + "[]"
+	}
+	return t.name
+}
+
+// Create the expression type from the declared type text, for example
+// "Integer" or "Text[]" for an array. Types which are neither built in nor
+// widget types are unknown.
+func newExprType(typeText string) exprType {
+	name, isArray := strings.CutSuffix(typeText, "[]")
+	if _, isWidget := lang.TypeAliases[name]; !lang.IsType(name) && !isWidget {
+		return exprType{}
+	}
+	return exprType{name: name, isArray: isArray}
+}
+
+var (
+	integerType = exprType{name: "Integer"}
+	realType    = exprType{name: "Real"}
+	textType    = exprType{name: "Text"}
+)
+
+// Computes the types of the expressions of a document.
+type typeChecker struct {
+	uri         string
+	sourceCode  []byte
+	documents   map[string]Document
+	includesDir string
+}
+
+// Get the diagnostics of the assignments, operators and calls of the document
+// whose operand or argument types do not match.
+func (s *Server) getTypeDiagnostics(uri string) []protocol.Diagnostic {
+	result := []protocol.Diagnostic{}
+	doc, ok := s.documents[uri]
+	if !ok {
+		return result
+	}
+	checker := typeChecker{uri: uri, sourceCode: doc.SourceCode, documents: s.documents, includesDir: s.includesDir}
+	var visit func(node *sitter.Node)
+	visit = func(node *sitter.Node) {
+		if node.IsError() {
+			return
+		}
+		if !node.HasError() {
+			if diagnostic, ok := checker.check(node); ok {
+				result = append(result, diagnostic)
+			}
+			if node.Type() == "call_expression" {
+				result = append(result, checker.checkRefArgs(node)...)
+			}
+		}
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			visit(node.NamedChild(i))
+		}
+	}
+	visit(doc.RootNode)
+	return result
+}
+
+// Check the types of the operands or arguments of the node, returns false if
+// the node is not an assignment, operator or call or its types match.
+func (c typeChecker) check(node *sitter.Node) (protocol.Diagnostic, bool) {
+	switch node.Type() {
+	case "init_declarator":
+		declaratorNode := node.ChildByFieldName("declarator")
+		valueNode := node.ChildByFieldName("value")
+		if declaratorNode == nil || valueNode == nil || declaratorNode.Type() != "identifier" {
+			return protocol.Diagnostic{}, false
+		}
+		typeText, err := getDefinitionType(declaratorNode, c.sourceCode)
+		if err != nil {
+			return protocol.Diagnostic{}, false
+		}
+		return c.checkAssignable(valueNode, c.getType(valueNode), newExprType(typeText))
+
+	case "assignment_expression":
+		leftNode := node.ChildByFieldName("left")
+		rightNode := node.ChildByFieldName("right")
+		operatorNode := node.ChildByFieldName("operator")
+		if leftNode == nil || rightNode == nil || operatorNode == nil {
+			return protocol.Diagnostic{}, false
+		}
+		leftType := c.getType(leftNode)
+		rightType := c.getType(rightNode)
+		operator := operatorNode.Content(c.sourceCode)
+		if operator == "=" {
+			return c.checkAssignable(rightNode, rightType, leftType)
+		}
+		// Compound assignments apply the operator before assigning, "a += b"
+		// is "a = a + b".
+		binaryOperator := strings.TrimSuffix(operator, "=")
+		resultType, ok := getBinaryExprType(binaryOperator, leftType, rightType)
+		if !ok {
+			return newBinaryOperatorDiagnostic(operatorNode, operator, leftType, rightType), true
+		}
+		return c.checkAssignable(rightNode, resultType, leftType)
+
+	case "binary_expression":
+		operatorNode := node.ChildByFieldName("operator")
+		leftNode := node.ChildByFieldName("left")
+		rightNode := node.ChildByFieldName("right")
+		if operatorNode == nil || leftNode == nil || rightNode == nil {
+			return protocol.Diagnostic{}, false
+		}
+		leftType := c.getType(leftNode)
+		rightType := c.getType(rightNode)
+		operator := operatorNode.Content(c.sourceCode)
+		if _, ok := getBinaryExprType(operator, leftType, rightType); !ok {
+			return newBinaryOperatorDiagnostic(operatorNode, operator, leftType, rightType), true
+		}
+
+	case "call_expression":
+		return c.checkCall(node)
+
+	case "unary_expression", "update_expression":
+		operatorNode := node.ChildByFieldName("operator")
+		argumentNode := node.ChildByFieldName("argument")
+		if operatorNode == nil || argumentNode == nil {
+			return protocol.Diagnostic{}, false
+		}
+		argumentType := c.getType(argumentNode)
+		operator := operatorNode.Content(c.sourceCode)
+		if _, ok := getUnaryExprType(operator, argumentType); !ok {
+			return protocol.Diagnostic{
+				Range:    getNodeRange(operatorNode),
+				Severity: protocol.DiagnosticSeverityError,
+				Source:   SourceName,
+				Message:  fmt.Sprintf(`Operator "%s" cannot be applied to type "%s".`, operator, argumentType),
+			}, true
+		}
+	}
+	return protocol.Diagnostic{}, false
+}
+
+// Returns a diagnostic on the value node if the value type is not assignable
+// to the target type.
+func (c typeChecker) checkAssignable(valueNode *sitter.Node, valueType, targetType exprType) (protocol.Diagnostic, bool) {
+	if isAssignable(valueType, targetType) {
+		return protocol.Diagnostic{}, false
+	}
+	return protocol.Diagnostic{
+		Range:    getNodeRange(valueNode),
+		Severity: protocol.DiagnosticSeverityError,
+		Source:   SourceName,
+		Message:  fmt.Sprintf(`Type "%s" is not assignable to type "%s".`, valueType, targetType),
+	}, true
+}
+
+func newBinaryOperatorDiagnostic(operatorNode *sitter.Node, operator string, leftType, rightType exprType) protocol.Diagnostic {
+	return protocol.Diagnostic{
+		Range:    getNodeRange(operatorNode),
+		Severity: protocol.DiagnosticSeverityError,
+		Source:   SourceName,
+		Message:  fmt.Sprintf(`Operator "%s" cannot be applied to types "%s" and "%s".`, operator, leftType, rightType),
+	}
+}
+
+// Get the range of the node.
+func getNodeRange(node *sitter.Node) protocol.Range {
+	return protocol.Range{
+		Start: protocol.Position{
+			Line:      uint(node.StartPoint().Row),
+			Character: uint(node.StartPoint().Column),
+		},
+		End: protocol.Position{
+			Line:      uint(node.EndPoint().Row),
+			Character: uint(node.EndPoint().Column),
+		},
+	}
+}
+
+// Get the type of the expression node, the type is unknown if it cannot be
+// determined or the expression is not valid.
+func (c typeChecker) getType(node *sitter.Node) exprType {
+	switch node.Type() {
+	case "number_literal":
+		return getNumberLiteralType(node.Content(c.sourceCode))
+
+	case "string_literal", "concatenated_string":
+		return textType
+
+	case "parenthesized_expression":
+		if node.NamedChildCount() != 1 {
+			return exprType{}
+		}
+		return c.getType(node.NamedChild(0))
+
+	case "identifier":
+		def, err := findDefinition(node, node.Content(c.sourceCode), c.uri, c.documents, c.includesDir)
+		if err != nil {
+			return exprType{}
+		}
+		defDoc, ok := c.documents[def.URI]
+		if !ok {
+			return exprType{}
+		}
+		// Functions are only typed by calling them.
+		if def.Node.Parent() != nil && def.Node.Parent().Type() == "function_declarator" {
+			return exprType{}
+		}
+		if isPreprocDefName(def.Node) {
+			evaluator := newConstEvaluator(def.URI, c.documents, c.includesDir)
+			if value, err := evaluator.evalPreprocDef(def.Node); err == nil {
+				return value.exprType
+			}
+		}
+		typeText, err := getDefinitionType(def.Node, defDoc.SourceCode)
+		if err != nil {
+			return exprType{}
+		}
+		return newExprType(typeText)
+
+	case "subscript_expression":
+		argumentNode := node.ChildByFieldName("argument")
+		if argumentNode == nil {
+			return exprType{}
+		}
+		argumentType := c.getType(argumentNode)
+		if !argumentType.isArray {
+			return exprType{}
+		}
+		return exprType{name: argumentType.name}
+
+	case "call_expression":
+		return c.getCallType(node)
+
+	case "binary_expression":
+		operatorNode := node.ChildByFieldName("operator")
+		leftNode := node.ChildByFieldName("left")
+		rightNode := node.ChildByFieldName("right")
+		if operatorNode == nil || leftNode == nil || rightNode == nil {
+			return exprType{}
+		}
+		result, _ := getBinaryExprType(operatorNode.Content(c.sourceCode), c.getType(leftNode), c.getType(rightNode))
+		return result
+
+	case "unary_expression", "update_expression":
+		operatorNode := node.ChildByFieldName("operator")
+		argumentNode := node.ChildByFieldName("argument")
+		if operatorNode == nil || argumentNode == nil {
+			return exprType{}
+		}
+		result, _ := getUnaryExprType(operatorNode.Content(c.sourceCode), c.getType(argumentNode))
+		return result
+
+	case "assignment_expression":
+		leftNode := node.ChildByFieldName("left")
+		if leftNode == nil {
+			return exprType{}
+		}
+		return c.getType(leftNode)
+	}
+	return exprType{}
+}
+
+// Get the return type of the function called by the call expression node.
+// User defined functions shadow the library functions, the return type of a
+// library function is only known when all of its overloads return the same
+// type.
+func (c typeChecker) getCallType(callNode *sitter.Node) exprType {
+	funcNode := callNode.ChildByFieldName("function")
+	if funcNode == nil || funcNode.Type() != "identifier" {
+		return exprType{}
+	}
+	identifier := funcNode.Content(c.sourceCode)
+	if def, err := findDefinition(funcNode, identifier, c.uri, c.documents, c.includesDir); err == nil {
+		defDoc, ok := c.documents[def.URI]
+		if !ok {
+			return exprType{}
+		}
+		typeText, err := getDefinitionType(def.Node, defDoc.SourceCode)
+		if err != nil {
+			return exprType{}
+		}
+		return newExprType(typeText)
+	}
+	libItems, ok := lang.Lib[identifier]
+	if !ok {
+		return exprType{}
+	}
+	result := exprType{}
+	for idx, item := range libItems {
+		returnType, err := lang.GetReturnType(item)
+		if err != nil {
+			return exprType{}
+		}
+		itemType := newExprType(returnType)
+		if idx > 0 && itemType != result {
+			return exprType{}
+		}
+		result = itemType
+	}
+	return result
+}
+
+// Get the type of the number literal, literals with a decimal point or an
+// exponent are Real.
+func getNumberLiteralType(literal string) exprType {
+	lowerLiteral := strings.ToLower(literal)
+	if strings.HasPrefix(lowerLiteral, "0x") {
+		return integerType
+	}
+	if strings.ContainsAny(lowerLiteral, ".e") {
+		return realType
+	}
+	return integerType
+}
+
+// Get the type of the result of applying the binary operator to operands of
+// the types. Returns false if the operator cannot be applied to the operand
+// types. Operators applied to an operand of a type which is unknown or not
+// primitive have an unknown result and are assumed to be valid.
+func getBinaryExprType(operator string, left, right exprType) (exprType, bool) {
+	if left.isUnknown() || right.isUnknown() || !left.isPrimitive() || !right.isPrimitive() {
+		return exprType{}, true
+	}
+	if left.isArray || right.isArray {
+		return exprType{}, false
+	}
+	switch operator {
+	case "+":
+		if left.isText() && right.isText() {
+			return textType, true
+		}
+		return getNumericExprType(left, right)
+
+	case "-", "*", "/", "%":
+		return getNumericExprType(left, right)
+
+	case "<", ">", "<=", ">=", "==", "!=":
+		if left.isText() && right.isText() {
+			return integerType, true
+		}
+		if left.isNumeric() && right.isNumeric() {
+			return integerType, true
+		}
+		return exprType{}, false
+
+	case "&&", "||", "&", "|", "^", "<<", ">>":
+		if left.isNumeric() && right.isNumeric() {
+			return integerType, true
+		}
+		return exprType{}, false
+	}
+	return exprType{}, true
+}
+
+// Get the type of an arithmetic operation on the operands, Integer operands
+// are promoted to Real if the other operand is Real.
+func getNumericExprType(left, right exprType) (exprType, bool) {
+	if !left.isNumeric() || !right.isNumeric() {
+		return exprType{}, false
+	}
+	if left.name == "Real" || right.name == "Real" {
+		return realType, true
+	}
+	return integerType, true
+}
+
+// Get the type of the result of applying the unary or update operator to an
+// argument of the type. Returns false if the operator cannot be applied to
+// the argument type.
+func getUnaryExprType(operator string, argument exprType) (exprType, bool) {
+	if argument.isUnknown() || !argument.isPrimitive() {
+		return exprType{}, true
+	}
+	if !argument.isNumeric() {
+		return exprType{}, false
+	}
+	if operator == "!" {
+		return integerType, true
+	}
+	return argument, true
+}
+
+// Returns true if a value of the value type can be assigned to a variable of
+// the target type. Integer and Real convert to each other and widgets convert
+// to the widget types they are a subtype of.
+func isAssignable(valueType, targetType exprType) bool {
+	if valueType.isUnknown() || targetType.isUnknown() || valueType == targetType {
+		return true
+	}
+	if valueType.isArray || targetType.isArray {
+		return false
+	}
+	for _, alias := range lang.TypeAliases[valueType.name] {
+		if alias == targetType.name {
+			return true
+		}
+	}
+	return false
+}
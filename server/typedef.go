@@ -0,0 +1,78 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kelly-lin/12d-lang-server/lang"
+	"github.com/kelly-lin/12d-lang-server/protocol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Find the location of the definition of the type of the identifier described
+// by the identifier node. Types which are aliased through a "#define" resolve
+// to the preprocessor definition, built in types resolve to a generated read
+// only document describing the type and the library functions which use it.
+func (s *Server) findTypeDefinition(identifierNode *sitter.Node, identifier, uri string) (protocol.Location, error) {
+	def, err := findDefinition(identifierNode, identifier, uri, s.documents, s.includesDir)
+	if err != nil {
+		return protocol.Location{}, err
+	}
+	defDoc, ok := s.documents[def.URI]
+	if !ok {
+		return protocol.Location{}, errors.New("definition document not found")
+	}
+	varType, err := getDefinitionType(def.Node, defDoc.SourceCode)
+	if err != nil {
+		return protocol.Location{}, err
+	}
+	varType = strings.TrimSuffix(varType, "[]")
+
+	// User defined aliases take precedence over the built in types since they
+	// shadow them.
+	if typeDef, err := findDefinition(def.Node, varType, def.URI, s.documents, s.includesDir); err == nil && isPreprocDefName(typeDef.Node) {
+		return protocol.Location{URI: typeDef.URI, Range: ToProtocolRange(typeDef.Range)}, nil
+	}
+
+	return s.writeTypeDoc(varType)
+}
+
+// Writes the read only type document for the built in type to the type
+// documents directory and returns the location of the type declaration inside
+// of the document.
+func (s *Server) writeTypeDoc(typeName string) (protocol.Location, error) {
+	contents, ok := lang.CreateTypeDoc(typeName)
+	if !ok {
+		return protocol.Location{}, fmt.Errorf("%s is not a built in type", typeName)
+	}
+	if err := os.MkdirAll(s.typeDocsDir, 0755); err != nil {
+		return protocol.Location{}, err
+	}
+	docFilepath := filepath.Join(s.typeDocsDir, typeName+".h")
+	// The document is read only since it is generated, it is made writable to
+	// regenerate it in case the library documentation changed.
+	_ = os.Chmod(docFilepath, 0644)
+	if err := os.WriteFile(docFilepath, []byte(contents), 0444); err != nil {
+		return protocol.Location{}, err
+	}
+	if err := os.Chmod(docFilepath, 0444); err != nil {
+		return protocol.Location{}, err
+	}
+	line := 0
+	for idx, lineText := range strings.Split(contents, "\n") {
+		if lineText == typeName+";" {
+			line = idx
+			break
+		}
+	}
+	return protocol.Location{
+		URI: protocol.URI(docFilepath),
+		Range: protocol.Range{
+			Start: protocol.Position{Line: uint(line), Character: 0},
+			End:   protocol.Position{Line: uint(line), Character: uint(len(typeName))},
+		},
+	}, nil
+}
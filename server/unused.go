@@ -0,0 +1,134 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/kelly-lin/12d-lang-server/protocol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Codes of the diagnostics of unused declarations.
+const (
+	DiagnosticCodeUnusedVariable  = "unused-variable"
+	DiagnosticCodeUnusedParameter = "unused-parameter"
+	DiagnosticCodeUnusedFunction  = "unused-function"
+)
+
+// Get the hints for the local variables and parameters of the document which
+// are never referenced and the functions which are never called from "main".
+// The hints are tagged as unnecessary so that clients fade them out.
+func (s *Server) getUnusedDiagnostics(uri string) []protocol.Diagnostic {
+	result := []protocol.Diagnostic{}
+	doc, ok := s.documents[uri]
+	if !ok {
+		return result
+	}
+	sourceCode := doc.SourceCode
+	funcDefNodes := []*sitter.Node{}
+	for i := 0; i < int(doc.RootNode.NamedChildCount()); i++ {
+		node := doc.RootNode.NamedChild(i)
+		if node.Type() == "function_definition" && getFuncDefIdentifierNode(node) != nil {
+			funcDefNodes = append(funcDefNodes, node)
+		}
+	}
+
+	for _, funcDefNode := range funcDefNodes {
+		bodyNode := funcDefNode.ChildByFieldName("body")
+		if bodyNode == nil || bodyNode.HasError() {
+			continue
+		}
+		if paramsNode := getFuncDefParamsNode(funcDefNode); paramsNode != nil {
+			for i := 0; i < int(paramsNode.NamedChildCount()); i++ {
+				paramNode := paramsNode.NamedChild(i)
+				if paramNode.Type() != "parameter_declaration" {
+					continue
+				}
+				identifierNode := getDeclaratorIdentifierNode(paramNode.ChildByFieldName("declarator"))
+				if identifierNode == nil {
+					continue
+				}
+				identifier := identifierNode.Content(sourceCode)
+				if len(getReferenceNodes(bodyNode, identifierNode, identifier, sourceCode)) == 0 {
+					result = append(result, newUnusedDiagnostic(identifierNode, DiagnosticCodeUnusedParameter, fmt.Sprintf(`Parameter "%s" is declared but never used.`, identifier)))
+				}
+			}
+		}
+		for _, identifierNode := range getLocalIdentifierNodes(bodyNode) {
+			identifier := identifierNode.Content(sourceCode)
+			if len(getReferenceNodes(getScopeNode(identifierNode), identifierNode, identifier, sourceCode)) == 0 {
+				result = append(result, newUnusedDiagnostic(identifierNode, DiagnosticCodeUnusedVariable, fmt.Sprintf(`Variable "%s" is declared but never used.`, identifier)))
+			}
+		}
+	}
+
+	isCalled := getCalledFuncs(funcDefNodes, sourceCode)
+	if !isCalled["main"] {
+		return result
+	}
+	for _, funcDefNode := range funcDefNodes {
+		identifierNode := getFuncDefIdentifierNode(funcDefNode)
+		identifier := identifierNode.Content(sourceCode)
+		if !isCalled[identifier] {
+			result = append(result, newUnusedDiagnostic(identifierNode, DiagnosticCodeUnusedFunction, fmt.Sprintf(`Function "%s" is never called from "main".`, identifier)))
+		}
+	}
+	return result
+}
+
+func newUnusedDiagnostic(identifierNode *sitter.Node, code, message string) protocol.Diagnostic {
+	return protocol.Diagnostic{
+		Range:    getNodeRange(identifierNode),
+		Severity: protocol.DiagnosticSeverityHint,
+		Source:   SourceName,
+		Message:  message,
+		Code:     code,
+		Tags:     []uint{protocol.DiagnosticTagUnnecessary},
+	}
+}
+
+// Get the identifier nodes of the variables declared inside of the function
+// body node.
+func getLocalIdentifierNodes(bodyNode *sitter.Node) []*sitter.Node {
+	result := []*sitter.Node{}
+	for i := 0; i < int(bodyNode.NamedChildCount()); i++ {
+		childNode := bodyNode.NamedChild(i)
+		if childNode.Type() == "declaration" {
+			result = append(result, getDeclaratorIdentifierNodes(childNode)...)
+			continue
+		}
+		result = append(result, getLocalIdentifierNodes(childNode)...)
+	}
+	return result
+}
+
+// Get the names of the functions of the document which are reachable from
+// "main", including "main" itself if it is defined. Functions are reachable if
+// they are referenced from the body of a reachable function.
+func getCalledFuncs(funcDefNodes []*sitter.Node, sourceCode []byte) map[string]bool {
+	result := map[string]bool{}
+	bodyNodesByName := map[string][]*sitter.Node{}
+	for _, funcDefNode := range funcDefNodes {
+		identifier := getFuncDefIdentifierNode(funcDefNode).Content(sourceCode)
+		if bodyNode := funcDefNode.ChildByFieldName("body"); bodyNode != nil {
+			bodyNodesByName[identifier] = append(bodyNodesByName[identifier], bodyNode)
+		}
+	}
+	if _, ok := bodyNodesByName["main"]; !ok {
+		return result
+	}
+	queue := []string{"main"}
+	result["main"] = true
+	for len(queue) > 0 {
+		identifier := queue[0]
+		queue = queue[1:]
+		for _, bodyNode := range bodyNodesByName[identifier] {
+			for name := range getReferencedNames(bodyNode, sourceCode) {
+				if _, ok := bodyNodesByName[name]; ok && !result[name] {
+					result[name] = true
+					queue = append(queue, name)
+				}
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,345 @@
+package server
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kelly-lin/12d-lang-server/lang"
+	"github.com/kelly-lin/12d-lang-server/protocol"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// A widget created by a "Create_" library call and stored in a local
+// variable.
+type widget struct {
+	// Name of the variable the widget is created in.
+	name     string
+	typeName string
+	// The "Create_" call expression which created the widget.
+	createNode *sitter.Node
+	// The "Append" call which first appended the widget and the argument of
+	// the container it was appended to, nil if it is never appended.
+	appendNode    *sitter.Node
+	containerNode *sitter.Node
+	// The widget it was first appended to, nil if it is not appended or the
+	// container is not a widget created in the function.
+	parent *widget
+	// The "Show_widget" call which showed the widget, nil if it is not shown.
+	showNode *sitter.Node
+	// The widget is passed to a user defined function, returned or stored
+	// somewhere which is not a local variable, it may be appended elsewhere.
+	isEscaped bool
+}
+
+// Follows the widgets created in the local variables of a function in the
+// order of the statements of the function. Loops are not taken into account
+// and branches only in that the different branches of an "if" or "switch"
+// statement do not both run.
+type widgetTracker struct {
+	checker typeChecker
+	// The body of the function being tracked.
+	bodyNode *sitter.Node
+	// The widget each local variable currently holds by the start byte of the
+	// identifier node which declares the variable.
+	variables map[uint32]*widget
+	// The widgets in the order they are created.
+	widgets     []*widget
+	diagnostics []protocol.Diagnostic
+}
+
+// Widgets which are shown on their own rather than being appended.
+var topLevelWidgetTypes = []string{"Panel", "Menu"}
+
+// Get the diagnostics of the widgets created in the functions of the document,
+// widgets which are never appended to a group or panel, widgets appended to
+// more than one container and widgets appended to a panel after the panel is
+// shown with "Show_widget".
+func (s *Server) getWidgetDiagnostics(uri string) []protocol.Diagnostic {
+	result := []protocol.Diagnostic{}
+	doc, ok := s.documents[uri]
+	if !ok {
+		return result
+	}
+	checker := typeChecker{uri: uri, sourceCode: doc.SourceCode, documents: s.documents, includesDir: s.includesDir}
+	for i := 0; i < int(doc.RootNode.NamedChildCount()); i++ {
+		funcDefNode := doc.RootNode.NamedChild(i)
+		if funcDefNode.Type() != "function_definition" {
+			continue
+		}
+		bodyNode := funcDefNode.ChildByFieldName("body")
+		if bodyNode == nil || bodyNode.HasError() {
+			continue
+		}
+		tracker := widgetTracker{checker: checker, bodyNode: bodyNode, variables: map[uint32]*widget{}}
+		tracker.visit(bodyNode)
+		result = append(result, tracker.diagnostics...)
+		for _, w := range tracker.widgets {
+			if w.appendNode != nil || w.isEscaped || slices.Contains(topLevelWidgetTypes, w.typeName) {
+				continue
+			}
+			result = append(result, protocol.Diagnostic{
+				Range:    getNodeRange(w.createNode),
+				Severity: protocol.DiagnosticSeverityWarning,
+				Source:   SourceName,
+				Message:  fmt.Sprintf(`Widget "%s" is created but never appended to a group or panel.`, w.name),
+			})
+		}
+	}
+	return result
+}
+
+func (t *widgetTracker) visit(node *sitter.Node) {
+	switch node.Type() {
+	case "init_declarator":
+		identifierNode := node.ChildByFieldName("declarator")
+		valueNode := node.ChildByFieldName("value")
+		if identifierNode != nil && valueNode != nil && identifierNode.Type() == "identifier" {
+			t.assign(identifierNode, valueNode)
+			if valueNode.Type() != "identifier" {
+				t.visit(valueNode)
+			}
+			return
+		}
+
+	case "assignment_expression":
+		leftNode := node.ChildByFieldName("left")
+		rightNode := node.ChildByFieldName("right")
+		operatorNode := node.ChildByFieldName("operator")
+		if leftNode != nil && rightNode != nil && operatorNode != nil && operatorNode.Type() == "=" {
+			if leftNode.Type() == "identifier" {
+				t.assign(leftNode, rightNode)
+			} else if w := t.getWidget(rightNode); w != nil {
+				w.isEscaped = true
+			}
+			if rightNode.Type() != "identifier" {
+				t.visit(rightNode)
+			}
+			return
+		}
+
+	case "call_expression":
+		t.call(node)
+
+	case "return_statement":
+		for _, identifierNode := range getDescendantsOfType(node, "identifier") {
+			if w := t.getWidget(identifierNode); w != nil {
+				w.isEscaped = true
+			}
+		}
+	}
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		t.visit(node.NamedChild(i))
+	}
+}
+
+// Track the assignment of the value node to the variable of the identifier
+// node. Assigning the result of a "Create_" call creates a new widget and
+// assigning another variable makes both variables hold the same widget.
+func (t *widgetTracker) assign(identifierNode, valueNode *sitter.Node) {
+	key, typeName, ok := t.getLocalVariable(identifierNode)
+	if !ok || !isWidgetType(typeName) {
+		if w := t.getWidget(valueNode); w != nil {
+			w.isEscaped = true
+		}
+		return
+	}
+	if valueNode.Type() == "call_expression" && t.isLibCall(valueNode) {
+		funcNode := valueNode.ChildByFieldName("function")
+		if strings.HasPrefix(funcNode.Content(t.checker.sourceCode), "Create_") {
+			w := &widget{name: identifierNode.Content(t.checker.sourceCode), typeName: typeName, createNode: valueNode}
+			t.variables[key] = w
+			t.widgets = append(t.widgets, w)
+			return
+		}
+	}
+	if w := t.getWidget(valueNode); w != nil {
+		t.variables[key] = w
+		return
+	}
+	delete(t.variables, key)
+}
+
+// Track the widgets passed to the call. Library calls do not affect the
+// widgets passed to them except for "Append" and "Show_widget", widgets passed
+// to user defined functions may be appended by the function.
+func (t *widgetTracker) call(callNode *sitter.Node) {
+	argsNode := callNode.ChildByFieldName("arguments")
+	if argsNode == nil {
+		return
+	}
+	argNodes := getArgNodes(argsNode)
+	if !t.isLibCall(callNode) {
+		for _, argNode := range argNodes {
+			if w := t.getWidget(argNode); w != nil {
+				w.isEscaped = true
+			}
+		}
+		return
+	}
+	switch callNode.ChildByFieldName("function").Content(t.checker.sourceCode) {
+	case "Append":
+		// Appends which do not match an overload are reported by the type
+		// checker, nothing can be appended to a "Menu" for example.
+		if len(argNodes) >= 2 && t.isMatchingOverload("Append", argsNode) {
+			t.append(callNode, argNodes[0], argNodes[len(argNodes)-1])
+		}
+
+	case "Show_widget":
+		if len(argNodes) >= 1 {
+			if w := t.getWidget(argNodes[0]); w != nil && w.showNode == nil {
+				w.showNode = callNode
+			}
+		}
+	}
+}
+
+// Track appending the widget node to the container node.
+func (t *widgetTracker) append(callNode, widgetNode, containerNode *sitter.Node) {
+	w := t.getWidget(widgetNode)
+	if w == nil {
+		return
+	}
+	sourceCode := t.checker.sourceCode
+	container := t.getWidget(containerNode)
+	for shown := container; shown != nil; shown = shown.parent {
+		if shown.showNode != nil && !isInExclusiveBranches(callNode, shown.showNode) {
+			t.diagnostics = append(t.diagnostics, protocol.Diagnostic{
+				Range:    getNodeRange(callNode),
+				Severity: protocol.DiagnosticSeverityWarning,
+				Source:   SourceName,
+				Message:  fmt.Sprintf(`Widget "%s" is appended after "%s" is shown, append the contents of a panel before showing it.`, widgetNode.Content(sourceCode), shown.name),
+				RelatedInformation: []protocol.DiagnosticRelatedInformation{
+					{
+						Location: protocol.Location{URI: t.checker.uri, Range: getNodeRange(shown.showNode)},
+						Message:  fmt.Sprintf(`"%s" is shown here.`, shown.name),
+					},
+				},
+			})
+			break
+		}
+	}
+	if w.appendNode == nil {
+		w.appendNode = callNode
+		w.containerNode = containerNode
+		w.parent = container
+		return
+	}
+	// Appending to the same container again is not a different container.
+	isSameContainer := container != nil && container == w.parent
+	if container == nil && w.parent == nil {
+		isSameContainer = containerNode.Content(sourceCode) == w.containerNode.Content(sourceCode)
+	}
+	// Only one of the appends runs.
+	if isSameContainer || isInExclusiveBranches(callNode, w.appendNode) {
+		return
+	}
+	t.diagnostics = append(t.diagnostics, newDuplicateDiagnostic(
+		callNode,
+		protocol.DiagnosticSeverityWarning,
+		fmt.Sprintf(`Widget "%s" is already appended to "%s", a widget can only be appended to one container.`, widgetNode.Content(sourceCode), w.containerNode.Content(sourceCode)),
+		t.checker.uri,
+		w.appendNode,
+		fmt.Sprintf(`"%s" is first appended here.`, widgetNode.Content(sourceCode)),
+	))
+}
+
+// Get the widget the local variable of the identifier node holds, nil if the
+// node is not a local variable holding a widget created in the function.
+func (t *widgetTracker) getWidget(node *sitter.Node) *widget {
+	if node.Type() != "identifier" {
+		return nil
+	}
+	key, _, ok := t.getLocalVariable(node)
+	if !ok {
+		return nil
+	}
+	return t.variables[key]
+}
+
+// Get the key and the type of the local variable of the function which the
+// identifier node refers to. Returns false if the identifier is not a local
+// variable, parameters are not local variables.
+func (t *widgetTracker) getLocalVariable(identifierNode *sitter.Node) (uint32, string, bool) {
+	identifier := identifierNode.Content(t.checker.sourceCode)
+	def, err := findDefinition(identifierNode, identifier, t.checker.uri, t.checker.documents, t.checker.includesDir)
+	if err != nil || def.URI != t.checker.uri {
+		return 0, "", false
+	}
+	if def.Node.StartByte() < t.bodyNode.StartByte() || def.Node.EndByte() > t.bodyNode.EndByte() {
+		return 0, "", false
+	}
+	typeText, err := getDefinitionType(def.Node, t.checker.sourceCode)
+	if err != nil {
+		return 0, "", false
+	}
+	return def.Node.StartByte(), typeText, true
+}
+
+// Returns true if the call expression node calls a library function which is
+// not shadowed by a user defined function.
+func (t *widgetTracker) isLibCall(callNode *sitter.Node) bool {
+	funcNode := callNode.ChildByFieldName("function")
+	if funcNode == nil || funcNode.Type() != "identifier" {
+		return false
+	}
+	identifier := funcNode.Content(t.checker.sourceCode)
+	if _, ok := lang.Lib[identifier]; !ok {
+		return false
+	}
+	_, err := findDefinition(funcNode, identifier, t.checker.uri, t.checker.documents, t.checker.includesDir)
+	return err != nil
+}
+
+// Returns true if the nodes are in different branches of the same "if" or
+// "switch" statement so that only one of them runs. Falling through from one
+// case of a switch to the next is not taken into account.
+func isInExclusiveBranches(node, otherNode *sitter.Node) bool {
+	isWithin := func(node, parentNode *sitter.Node) bool {
+		return parentNode != nil && node.StartByte() >= parentNode.StartByte() && node.EndByte() <= parentNode.EndByte()
+	}
+	// Find the innermost statement containing both nodes, the child node is
+	// the child of it which contains the node.
+	childNode := node
+	parentNode := node.Parent()
+	for parentNode != nil && !isWithin(otherNode, parentNode) {
+		childNode = parentNode
+		parentNode = parentNode.Parent()
+	}
+	if parentNode == nil {
+		return false
+	}
+	switch parentNode.Type() {
+	case "if_statement":
+		consequenceNode := parentNode.ChildByFieldName("consequence")
+		alternativeNode := parentNode.ChildByFieldName("alternative")
+		return isWithin(node, consequenceNode) && isWithin(otherNode, alternativeNode) ||
+			isWithin(node, alternativeNode) && isWithin(otherNode, consequenceNode)
+
+	case "compound_statement":
+		return parentNode.Parent() != nil && parentNode.Parent().Type() == "switch_statement" &&
+			childNode.Type() == "case_statement" && !isWithin(otherNode, childNode)
+	}
+	return false
+}
+
+// Returns true if the types of the arguments match an overload of the library
+// function.
+func (t *widgetTracker) isMatchingOverload(identifier string, argsNode *sitter.Node) bool {
+	signatures, err := lang.GetLibSignatures(identifier)
+	if err != nil {
+		return false
+	}
+	argTypes := t.checker.getArgTypes(argsNode)
+	for _, signature := range signatures {
+		if matchOverload(signature, argTypes).isMatch() {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if the type is "Widget" or one of the widget types.
+func isWidgetType(typeName string) bool {
+	return typeName == "Widget" || slices.Contains(lang.TypeAliases[typeName], "Widget")
+}